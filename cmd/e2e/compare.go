@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/histogram"
+)
+
+func runCompare(_ context.Context, args []string) error {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	configPath := fs.String("config", "config", "directory containing test-config.yaml")
+	var baseline, current stringList
+	fs.Var(&baseline, "baseline", "baseline histogram file or directory (repeatable)")
+	fs.Var(&current, "current", "histogram file or directory of the run to check (repeatable)")
+	p95 := fs.Float64("p95-growth", 0, "allowed p95 growth factor; 0 uses perf.p95_growth")
+	p99 := fs.Float64("p99-growth", 0, "allowed p99 growth factor; 0 uses perf.p99_growth")
+	_ = fs.Parse(args)
+
+	if len(baseline) == 0 || len(current) == 0 {
+		return errors.New("both -baseline and -current are required")
+	}
+	cfg, _, err := setup(*configPath)
+	if err != nil {
+		return err
+	}
+	th := histogram.ThresholdsFrom(cfg.Perf)
+	if *p95 != 0 {
+		th.P95Growth = *p95
+	}
+	if *p99 != 0 {
+		th.P99Growth = *p99
+	}
+
+	base, baseMeta, err := histogram.Load(baseline...)
+	if err != nil {
+		return fmt.Errorf("load baseline: %w", err)
+	}
+	cur, curMeta, err := histogram.Load(current...)
+	if err != nil {
+		return fmt.Errorf("load current run: %w", err)
+	}
+
+	report := histogram.Compare(base, cur, th)
+	fmt.Printf("baseline %s (%d files), current %s (%d files)\n\n",
+		labelOf(baseMeta), len(baseMeta), labelOf(curMeta), len(curMeta))
+	fmt.Print(report)
+
+	if regressed := report.Regressed(); len(regressed) > 0 {
+		return fmt.Errorf("%d endpoints regressed beyond p95 x%.2f / p99 x%.2f at alpha %g",
+			len(regressed), th.P95Growth, th.P99Growth, th.Alpha)
+	}
+	return nil
+}
+
+// labelOf returns the label the files share, or a note that they differ.
+func labelOf(metas []histogram.Meta) string {
+	label := metas[0].Label
+	for _, m := range metas[1:] {
+		if m.Label != label {
+			return "(mixed labels)"
+		}
+	}
+	if label == "" {
+		return "(unlabelled)"
+	}
+	return label
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/histogram"
+	"github.com/Soloda1/pinstack-system-tests/internal/load"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/Soloda1/pinstack-system-tests/internal/seed"
+	"github.com/Soloda1/pinstack-system-tests/internal/socialgraph"
+)
+
+// vuFlags are the flags shared by the commands that drive virtual users.
+type vuFlags struct {
+	manifest    *string
+	users       *int
+	seed        *int64
+	concurrency *int
+	hist        *string
+}
+
+func addVUFlags(fs *flag.FlagSet) vuFlags {
+	return vuFlags{
+		manifest:    fs.String("manifest", "", "run against a seeded dataset; empty seeds -users users and tears them down afterwards"),
+		users:       fs.Int("users", 0, "virtual users when seeding; 0 uses load.users"),
+		seed:        fs.Int64("seed", 0, "generator seed; 0 uses "+fixtures.SeedEnv+" or the clock"),
+		concurrency: fs.Int("concurrency", 0, "requests in flight while seeding, starting and cleaning up; 0 uses test.concurrent"),
+		hist:        fs.String("hist", "", "write per-endpoint latency histograms into this directory"),
+	}
+}
+
+// startVUs builds a runner with one started VU per user of the manifest, or
+// of a dataset it seeds itself. The returned stop saves the latency
+// histograms as source if asked to, undoes what the VUs did and tears a
+// self-seeded dataset down; it uses a fresh context, so an interrupted run
+// still leaves nothing behind, and must be called even when startVUs fails.
+func startVUs(ctx context.Context, cfg *config.Config, log *logger.Logger, f vuFlags, source string) (*load.Runner, func(), error) {
+	users, concurrency, seedValue := *f.users, *f.concurrency, *f.seed
+	if users == 0 {
+		users = cfg.Load.Users
+	}
+	if concurrency == 0 {
+		concurrency = cfg.Test.Concurrent
+	}
+	if seedValue == 0 {
+		seedValue = fixtures.RootSeed()
+	}
+	gen := fixtures.NewGenerator(seedValue)
+
+	var (
+		m        *seed.Manifest
+		err      error
+		r        = &load.Runner{Mix: load.DefaultMix(), Think: cfg.Load.ThinkTime, Refresh: cfg.Load.RefreshInterval}
+		teardown = func() {}
+	)
+	// Only requests that got a response count towards latency; transport
+	// failures would drag the histograms down.
+	hists := histogram.NewSet()
+	if *f.hist != "" {
+		r.Observe = func(s load.Sample) {
+			if s.Err == nil || client.StatusCode(s.Err) != 0 {
+				hists.Record(s.Endpoint, s.Latency)
+			}
+		}
+	}
+
+	stop := func() {
+		if *f.hist != "" {
+			if err := hists.Save(*f.hist, source, cfg.Perf.Label); err != nil {
+				log.Error("Saving latency histograms failed", "error", err)
+			}
+		}
+		if err := r.Cleanup(context.Background(), concurrency); err != nil {
+			log.Error("VU cleanup failed", "error", err)
+		}
+		teardown()
+	}
+
+	if *f.manifest != "" {
+		if m, err = seed.LoadManifest(*f.manifest); err != nil {
+			return nil, stop, err
+		}
+		if m.BaseURL != cfg.API.BaseURL {
+			return nil, stop, fmt.Errorf("manifest was seeded against %s, config points at %s", m.BaseURL, cfg.API.BaseURL)
+		}
+	} else {
+		log.Info("Seeding VU users", "seed", seedValue, "users", users)
+		m, err = seed.New(cfg, log, gen).Seed(ctx, seed.Options{
+			Users:       users,
+			Graph:       socialgraph.ScaledConfig(seedValue, users),
+			Concurrency: concurrency,
+		})
+		if m != nil {
+			teardown = func() {
+				if _, err := seed.Teardown(context.Background(), cfg, log, m, concurrency); err != nil {
+					log.Error("Teardown failed", "error", err)
+				}
+			}
+		}
+		if err != nil {
+			return nil, stop, err
+		}
+	}
+
+	// Each VU reads all its notifications on start and takes that as its
+	// baseline, so the notifications of the seeded follows must have arrived
+	// by then or they would be counted as unexpected later. A manifest may
+	// have been seeded moments ago too.
+	settle := cfg.Outbox.DeliveryBound(len(m.Follows))
+	log.Info("Waiting for seeded follow notifications", "follows", len(m.Follows), "wait", settle)
+	select {
+	case <-ctx.Done():
+		return nil, stop, ctx.Err()
+	case <-time.After(settle):
+	}
+
+	attached := m.Attach(ctx, cfg, log)
+	model := load.NewUnreadModel(cfg.Outbox.DeliveryBound(len(attached)))
+	for i, u := range attached {
+		r.VUs = append(r.VUs, load.NewVU(i, u, attached, gen, model))
+	}
+	if err := r.Start(ctx, concurrency); err != nil {
+		return nil, stop, err
+	}
+	return r, stop, nil
+}
+
+func runLoad(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("load", flag.ExitOnError)
+	configPath := fs.String("config", "config", "directory containing test-config.yaml")
+	profileName := fs.String("profile", "staged", "arrival profile from arrival.profiles")
+	vus := addVUFlags(fs)
+	_ = fs.Parse(args)
+
+	cfg, log, err := setup(*configPath)
+	if err != nil {
+		return err
+	}
+	profile, err := load.ProfileFrom(cfg.Arrival, *profileName)
+	if err != nil {
+		return err
+	}
+
+	r, stop, err := startVUs(ctx, cfg, log, vus, "load-"+profile.Name)
+	defer stop()
+	if err != nil {
+		return err
+	}
+
+	log.Info("Starting open-model run", "profile", profile.Name, "stages", len(profile.Stages), "vus", len(r.VUs))
+	results, err := r.RunProfile(ctx, profile)
+	for _, res := range results {
+		fmt.Println(res)
+	}
+	if err != nil {
+		return err
+	}
+
+	if point, ok := load.SaturationPoint(results); ok {
+		fmt.Printf("saturated at stage %s: target %.1f rps, achieved %.1f rps, %d dropped\n",
+			point.Name, point.TargetRPS(), point.AchievedRPS, point.Dropped)
+		return errors.New("gateway did not sustain the profile")
+	}
+	fmt.Printf("sustained every stage of %s\n", profile.Name)
+	return nil
+}
@@ -0,0 +1,71 @@
+// Command e2e holds the tooling that runs outside `go test`: seeding a
+// pre-populated environment, tearing it down again, sweeping up after
+// crashed runs, driving the gateway with virtual users, either at a
+// scheduled arrival rate or for hours to catch drift, and comparing latency
+// histograms against a baseline.
+//
+//	go run ./cmd/e2e seed -users 200 -posts 1000 -notifications 500
+//	go run ./cmd/e2e teardown -manifest seed-manifest.json
+//	go run ./cmd/e2e sweep -dry-run
+//	go run ./cmd/e2e load -profile steps -users 100
+//	go run ./cmd/e2e soak -users 50 -duration 4h
+//	go run ./cmd/e2e compare -baseline perf/main -current perf/pr
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, args []string) error
+}
+
+var commands = []command{
+	{"seed", "create users, posts, a follow graph and notifications and write a manifest", runSeed},
+	{"teardown", "delete everything listed in a manifest", runTeardown},
+	{"sweep", "delete users and data left behind by crashed runs", runSweep},
+	{"load", "play an arrival-rate profile and report achieved against target rate per stage", runLoad},
+	{"soak", "run the load mix for hours and flag latency, error-rate and resource drift", runSoak},
+	{"compare", "compare latency histograms with a baseline and fail on p95/p99 regressions", runCompare},
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	name, args := flag.Arg(0), flag.Args()[1:]
+	for _, c := range commands {
+		if c.name == name {
+			if err := c.run(ctx, args); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command %q\n", name)
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: e2e <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", c.name, c.usage)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/Soloda1/pinstack-system-tests/internal/seed"
+	"github.com/Soloda1/pinstack-system-tests/internal/socialgraph"
+)
+
+const defaultManifest = "seed-manifest.json"
+
+// setup loads the config and the process logger the same way the scenario
+// suites do in TestMain.
+func setup(configPath string) (*config.Config, *logger.Logger, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	log := logger.New(cfg.Env, cfg.Test.LogLevel)
+	logger.SetRedactedFields(cfg.Redaction.Fields...)
+	return cfg, log, nil
+}
+
+func runSeed(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ExitOnError)
+	configPath := fs.String("config", "config", "directory containing test-config.yaml")
+	manifest := fs.String("manifest", defaultManifest, "where to write the manifest")
+	users := fs.Int("users", 100, "number of users")
+	posts := fs.Int("posts", 500, "total number of posts")
+	notifications := fs.Int("notifications", 200, "total number of notifications")
+	followsPerUser := fs.Int("follows-per-user", 3, "follows per user by preferential attachment")
+	celebrities := fs.Int("celebrities", 2, "number of users followed by a large share of everyone")
+	seedValue := fs.Int64("seed", 0, "generator seed; 0 uses "+fixtures.SeedEnv+" or the clock")
+	concurrency := fs.Int("concurrency", 0, "requests in flight; 0 uses test.concurrent")
+	_ = fs.Parse(args)
+
+	cfg, log, err := setup(*configPath)
+	if err != nil {
+		return err
+	}
+
+	if *seedValue == 0 {
+		*seedValue = fixtures.RootSeed()
+	}
+	graph := socialgraph.ScaledConfig(*seedValue, *users)
+	graph.FollowsPerUser = *followsPerUser
+	graph.Celebrities = *celebrities
+
+	log.Info("Seeding dataset", "seed", *seedValue, "users", *users, "posts", *posts, "notifications", *notifications)
+
+	m, err := seed.New(cfg, log, fixtures.NewGenerator(*seedValue)).Seed(ctx, seed.Options{
+		Users:         *users,
+		Posts:         *posts,
+		Notifications: *notifications,
+		Graph:         graph,
+		Concurrency:   *concurrency,
+	})
+	// A partial manifest is still written so the teardown command can remove
+	// whatever was created before the failure.
+	if m != nil {
+		if saveErr := m.Save(*manifest); saveErr != nil {
+			return saveErr
+		}
+		fmt.Printf("manifest %s: %d users, %d posts, %d follows, %d notifications\n",
+			*manifest, len(m.Users), len(m.Posts), len(m.Follows), len(m.Notifications))
+	}
+	return err
+}
+
+func runTeardown(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("teardown", flag.ExitOnError)
+	configPath := fs.String("config", "config", "directory containing test-config.yaml")
+	manifest := fs.String("manifest", defaultManifest, "manifest written by seed")
+	concurrency := fs.Int("concurrency", 0, "requests in flight; 0 uses test.concurrent")
+	keep := fs.Bool("keep-manifest", false, "keep the manifest file after a successful teardown")
+	_ = fs.Parse(args)
+
+	cfg, log, err := setup(*configPath)
+	if err != nil {
+		return err
+	}
+
+	m, err := seed.LoadManifest(*manifest)
+	if err != nil {
+		return err
+	}
+	if m.BaseURL != cfg.API.BaseURL {
+		return fmt.Errorf("manifest was seeded against %s, config points at %s", m.BaseURL, cfg.API.BaseURL)
+	}
+
+	res, err := seed.Teardown(ctx, cfg, log, m, *concurrency)
+	fmt.Printf("deleted %d notifications, %d follows, %d posts, %d users; %d already gone, %d unverified\n",
+		res.Notifications, res.Follows, res.Posts, res.Users, res.Missing, res.Unverified)
+	if err != nil {
+		return err
+	}
+
+	if !*keep {
+		return os.Remove(*manifest)
+	}
+	return nil
+}
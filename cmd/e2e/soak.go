@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/soak"
+)
+
+func runSoak(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("soak", flag.ExitOnError)
+	configPath := fs.String("config", "config", "directory containing test-config.yaml")
+	duration := fs.Duration("duration", 0, "run length; 0 uses soak.duration")
+	window := fs.Duration("window", 0, "sampling window; 0 uses soak.sample_interval")
+	out := fs.String("out", "soak-timeseries.jsonl", "where to write one JSON line per window")
+	vus := addVUFlags(fs)
+	_ = fs.Parse(args)
+
+	cfg, log, err := setup(*configPath)
+	if err != nil {
+		return err
+	}
+	if *duration == 0 {
+		*duration = cfg.Soak.Duration
+	}
+	if *window == 0 {
+		*window = cfg.Soak.SampleInterval
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r, stop, err := startVUs(ctx, cfg, log, vus, "soak")
+	defer stop()
+	if err != nil {
+		return err
+	}
+
+	log.Info("Starting soak run", "vus", len(r.VUs), "duration", *duration, "window", *window)
+	verdict, err := soak.Run(ctx, r, soak.Options{
+		Duration:   *duration,
+		Window:     *window,
+		Thresholds: soak.ThresholdsFrom(cfg.Soak),
+	}, f, log)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("time series %s\n%s", *out, verdict)
+	if !verdict.Pass {
+		return errors.New("soak run drifted")
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/sweep"
+)
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+func runSweep(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("sweep", flag.ExitOnError)
+	configPath := fs.String("config", "config", "directory containing test-config.yaml")
+	runID := fs.String("run", "", "sweep only this run ID; empty sweeps every run")
+	minAge := fs.Duration("min-age", sweep.DefaultMinAge, "skip users younger than this, to spare runs still in progress")
+	dryRun := fs.Bool("dry-run", false, "list what would be removed without deleting")
+	concurrency := fs.Int("concurrency", 0, "users swept in parallel; 0 uses test.concurrent")
+	var queries stringList
+	fs.Var(&queries, "query", "extra SearchUsers query (repeatable)")
+	_ = fs.Parse(args)
+
+	cfg, log, err := setup(*configPath)
+	if err != nil {
+		return err
+	}
+	if !fixtures.RunSecretSet() {
+		log.Warn(fixtures.RunSecretEnv + " is not set; the sweeper cannot log in as users of other runs")
+	}
+
+	report, err := sweep.New(cfg, log).Sweep(ctx, sweep.Options{
+		RunID:       *runID,
+		Queries:     queries,
+		MinAge:      *minAge,
+		DryRun:      *dryRun,
+		Concurrency: *concurrency,
+	})
+	if report != nil {
+		for _, l := range report.Leftovers {
+			fmt.Printf("run %s  user %d %-32s  %d posts, %d follows, %d notifications\n",
+				l.RunID, l.User.ID, l.User.Username, len(l.Posts), len(l.Followees), len(l.Notifications))
+		}
+		if report.DryRun {
+			fmt.Printf("dry run: %d users would be removed\n", len(report.Leftovers))
+		} else {
+			fmt.Printf("deleted %d users, %d posts, %d follows, %d notifications\n",
+				report.Users, report.Posts, report.Follows, report.Notifications)
+		}
+	}
+	return err
+}
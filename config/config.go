@@ -1,31 +1,83 @@
 package config
 
 import (
-	"github.com/spf13/viper"
+	"errors"
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// EnvPrefix is the prefix of environment variables that override config values,
+	// e.g. PINSTACK_E2E_API_BASE_URL overrides api.base_url.
+	EnvPrefix = "PINSTACK_E2E"
+
+	// ProfileEnv selects a profile file that is layered on top of test-config.yaml.
+	ProfileEnv = EnvPrefix + "_PROFILE"
+
+	configName = "test-config"
+	configType = "yaml"
 )
 
 type Config struct {
-	Env      string       `mapstructure:"env"`
-	API      API          `mapstructure:"api"`
-	Test     Test         `mapstructure:"test"`
-	Services Services     `mapstructure:"services"`
-	JWT      JWT          `mapstructure:"jwt"`
-	Outbox   OutboxConfig `mapstructure:"outbox"`
+	Env       string       `mapstructure:"env"`
+	Profile   string       `mapstructure:"-"`
+	API       API          `mapstructure:"api"`
+	Test      Test         `mapstructure:"test"`
+	Services  Services     `mapstructure:"services"`
+	JWT       JWT          `mapstructure:"jwt"`
+	Outbox    OutboxConfig `mapstructure:"outbox"`
+	Redaction Redaction    `mapstructure:"redaction"`
+	Tracing   Tracing      `mapstructure:"tracing"`
+	Kafka     Kafka        `mapstructure:"kafka"`
+
+	Idempotency Idempotency `mapstructure:"idempotency"`
+	Cache       Cache       `mapstructure:"cache"`
+	Race        Race        `mapstructure:"race"`
+	Load        LoadConfig  `mapstructure:"load"`
+	Soak        Soak        `mapstructure:"soak"`
+	Arrival     Arrival     `mapstructure:"arrival"`
+	Perf        Perf        `mapstructure:"perf"`
+	RateLimit   RateLimit   `mapstructure:"rate_limit"`
+	Security    Security    `mapstructure:"security"`
 }
 
 type OutboxConfig struct {
 	Concurrency    int
 	TickIntervalMs int
 	BatchSize      int
+
+	// DeliverySlackMs covers Kafka and the consuming service on top of the
+	// outbox schedule in DeliveryBound.
+	DeliverySlackMs int
+	// LatencySamples and LatencyParallel size the delivery-latency
+	// measurement.
+	LatencySamples  int
+	LatencyParallel int
 }
 
 func (o OutboxConfig) TickInterval() time.Duration {
 	return time.Duration(o.TickIntervalMs) * time.Millisecond
 }
 
+// DeliveryBound is the longest an event should take from its write to its
+// consumer when inFlight events are queued together: up to one tick until the
+// next poll, one more tick for every further round of batches the workers
+// need to drain the queue, plus the delivery slack.
+func (o OutboxConfig) DeliveryBound(inFlight int) time.Duration {
+	perTick := o.BatchSize * o.Concurrency
+	rounds := 1
+	if perTick > 0 && inFlight > perTick {
+		rounds = (inFlight + perTick - 1) / perTick
+	}
+	return time.Duration(rounds)*o.TickInterval() + time.Duration(o.DeliverySlackMs)*time.Millisecond
+}
+
 type API struct {
 	BaseURL      string        `mapstructure:"base_url"`
 	Timeout      time.Duration `mapstructure:"timeout"`
@@ -54,126 +106,494 @@ type ServiceConfig struct {
 	Port    int    `mapstructure:"port"`
 }
 
+// Redaction lists extra JSON fields and log keys masked in logs and artifacts
+// on top of the built-in secrets (passwords, tokens, emails).
+type Redaction struct {
+	Fields []string `mapstructure:"fields"`
+}
+
+const (
+	TracingExporterNone = "none"
+	TracingExporterFile = "file"
+	TracingExporterOTLP = "otlp"
+)
+
+// Tracing configures OpenTelemetry spans for tests, steps and client calls.
+// Exporter "file" appends spans to FilePath and needs no collector; a
+// relative path is resolved against the parent of the config directory, the
+// repository root, so every test package writes to the same file.
+type Tracing struct {
+	Exporter     string `mapstructure:"exporter"`
+	FilePath     string `mapstructure:"file_path"`
+	OTLPEndpoint string `mapstructure:"otlp_endpoint"`
+	ServiceName  string `mapstructure:"service_name"`
+}
+
+// Idempotency sizes the duplicate-delivery suite: how many
+// follow/unfollow cycles it runs for one pair and how many identical follows
+// it fires at once.
+type Idempotency struct {
+	FollowCycles      int `mapstructure:"follow_cycles"`
+	ConcurrentFollows int `mapstructure:"concurrent_follows"`
+}
+
+// Cache sizes the read-after-write coherence suite. A read that still
+// returns pre-write data StalenessWindowMs after the write returned is
+// reported as stale; Rounds probes of every kind run on each of Concurrency
+// workers at once. KeyPatterns names the cache key behind each probe, as the
+// deployment documents it, so a report says which key misbehaved.
+type Cache struct {
+	StalenessWindowMs int               `mapstructure:"staleness_window_ms"`
+	Rounds            int               `mapstructure:"rounds"`
+	Concurrency       int               `mapstructure:"concurrency"`
+	KeyPatterns       []CacheKeyPattern `mapstructure:"key_patterns"`
+}
+
+// CacheKeyPattern labels the probe named Endpoint, e.g.
+// "GET /v1/users/{id} (avatar)", with the key pattern of the cache it reads.
+type CacheKeyPattern struct {
+	Endpoint string `mapstructure:"endpoint"`
+	Pattern  string `mapstructure:"pattern"`
+}
+
+func (c Cache) StalenessWindow() time.Duration {
+	return time.Duration(c.StalenessWindowMs) * time.Millisecond
+}
+
+// KeyPattern returns the configured key pattern of the probe named endpoint,
+// or "" if there is none.
+func (c Cache) KeyPattern(endpoint string) string {
+	for _, kp := range c.KeyPatterns {
+		if kp.Endpoint == endpoint {
+			return kp.Pattern
+		}
+	}
+	return ""
+}
+
+// Race sizes the concurrent-write suites: how many conflicting updates are
+// fired at one post or one unique value at once.
+type Race struct {
+	Writers int `mapstructure:"writers"`
+}
+
+// LoadConfig configures the virtual users of load and soak runs: how many there
+// are, how long each pauses between operations and how often each refreshes
+// its tokens (below jwt.access_expires_at, so they never expire mid-run).
+type LoadConfig struct {
+	Users           int           `mapstructure:"users"`
+	ThinkTime       time.Duration `mapstructure:"think_time"`
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+// Soak configures long runs: how long they last, how often latency and error
+// rates are sampled, and how much the late part of the run may drift from
+// the early part before the verdict fails. P95Growth and ResourceGrowth are
+// ratios, ErrorRateGrowth is an absolute increase of the error rate.
+type Soak struct {
+	Duration        time.Duration `mapstructure:"duration"`
+	SampleInterval  time.Duration `mapstructure:"sample_interval"`
+	P95Growth       float64       `mapstructure:"p95_growth"`
+	ErrorRateGrowth float64       `mapstructure:"error_rate_growth"`
+	ResourceGrowth  float64       `mapstructure:"resource_growth"`
+}
+
+// Arrival configures open-model load runs, where operations arrive at a
+// scheduled rate whatever the gateway's latency. Profiles are named sequences
+// of stages. Arrivals that find MaxBacklog operations already waiting for a
+// free virtual user are dropped, and a stage that completes less than
+// MinAchievedRatio of its target rate is reported as saturated.
+type Arrival struct {
+	MaxBacklog       int                       `mapstructure:"max_backlog"`
+	MinAchievedRatio float64                   `mapstructure:"min_achieved_ratio"`
+	Profiles         map[string][]ArrivalStage `mapstructure:"profiles"`
+}
+
+// ArrivalStage moves the arrival rate linearly from FromRPS to ToRPS over
+// Duration; equal rates hold it constant.
+type ArrivalStage struct {
+	Name     string        `mapstructure:"name"`
+	Duration time.Duration `mapstructure:"duration"`
+	FromRPS  float64       `mapstructure:"from_rps"`
+	ToRPS    float64       `mapstructure:"to_rps"`
+}
+
+// Perf configures per-endpoint latency histograms and their comparison with a
+// baseline. When Dir is set, every functional suite writes the latencies of
+// its client requests there, labelled with Label. An endpoint regresses when
+// its p95 or p99 grew by more than P95Growth or P99Growth times and the shift
+// is significant at Alpha; endpoints with fewer than MinSamples requests on
+// either side are not judged.
+type Perf struct {
+	Dir        string  `mapstructure:"dir"`
+	Label      string  `mapstructure:"label"`
+	P95Growth  float64 `mapstructure:"p95_growth"`
+	P99Growth  float64 `mapstructure:"p99_growth"`
+	Alpha      float64 `mapstructure:"alpha"`
+	MinSamples int     `mapstructure:"min_samples"`
+}
+
+// RateLimit configures the flood tests. Burst requests go to each of
+// Endpoints, Concurrency at a time. A throttled request must be a 429 with a
+// Retry-After of at most Window, and the limiter must accept requests again
+// within Window after the flood. With ExpectLimit the tests also fail when no
+// request of a burst is throttled; without it a gateway that ships no limits
+// only has to stay free of 5xx.
+type RateLimit struct {
+	Burst       int           `mapstructure:"burst"`
+	Concurrency int           `mapstructure:"concurrency"`
+	Window      time.Duration `mapstructure:"window"`
+	ExpectLimit bool          `mapstructure:"expect_limit"`
+	Endpoints   []string      `mapstructure:"endpoints"`
+}
+
+// Security configures the injection suite. PayloadsFile names a YAML
+// catalogue whose payloads and leak patterns are added to the built-in ones;
+// a relative path is resolved against the config directory. Oversized bodies
+// are OversizedBytes long and nested JSON documents NestingDepth levels deep.
+// AllowVerbatim accepts markup stored unchanged, for backends whose policy is
+// to escape on output instead of on input.
+type Security struct {
+	PayloadsFile   string `mapstructure:"payloads_file"`
+	OversizedBytes int    `mapstructure:"oversized_bytes"`
+	NestingDepth   int    `mapstructure:"nesting_depth"`
+	AllowVerbatim  bool   `mapstructure:"allow_verbatim"`
+}
+
+// Kafka points the event consumers at the broker the services publish to.
+type Kafka struct {
+	Brokers             []string `mapstructure:"brokers"`
+	RelationEventsTopic string   `mapstructure:"relation_events_topic"`
+}
+
 type JWT struct {
 	Secret           string        `mapstructure:"secret"`
 	AccessExpiresAt  time.Duration `mapstructure:"access_expires_at"`
 	RefreshExpiresAt time.Duration `mapstructure:"refresh_expires_at"`
 }
 
+// MustLoad loads the config like Load and exits the process on any error.
 func MustLoad(configPath string) *Config {
-	viper.SetConfigName("test-config")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(configPath)
-
-	viper.SetDefault("outbox.concurrency", 10)
-	viper.SetDefault("outbox.tick_interval_ms", 2000)
-	viper.SetDefault("outbox.batch_size", 100)
-
-	viper.SetDefault("env", "test")
-
-	viper.SetDefault("api.base_url", "http://localhost:42080/api")
-	viper.SetDefault("api.timeout", "10s")
-	viper.SetDefault("api.client_id", "e2e-test-client")
-	viper.SetDefault("api.client_secret", "e2e-test-secret")
-
-	viper.SetDefault("test.concurrent", 5)
-	viper.SetDefault("test.requests_per_test", 100)
-	viper.SetDefault("test.test_timeout", "2m")
-	viper.SetDefault("test.cleanup", true)
-	viper.SetDefault("test.log_level", "info")
-
-	viper.SetDefault("services.user_service.address", "localhost")
-	viper.SetDefault("services.user_service.port", 42051)
-	viper.SetDefault("services.auth_service.address", "localhost")
-	viper.SetDefault("services.auth_service.port", 42052)
-	viper.SetDefault("services.post_service.address", "localhost")
-	viper.SetDefault("services.post_service.port", 42053)
-	viper.SetDefault("services.relation_service.address", "localhost")
-	viper.SetDefault("services.relation_service.port", 42054)
-	viper.SetDefault("services.notification_service.address", "localhost")
-	viper.SetDefault("services.notification_service.port", 42055)
-
-	viper.SetDefault("jwt.secret", "my-secret")
-	viper.SetDefault("jwt.access_expires_at", "1m")
-	viper.SetDefault("jwt.refresh_expires_at", "30m")
-
-	if err := viper.ReadInConfig(); err != nil {
-		log.Printf("Error reading config file: %s", err)
+	cfg, err := Load(configPath)
+	if err != nil {
+		log.Printf("Error loading config: %s", err)
 		os.Exit(1)
 	}
+	return cfg
+}
 
-	apiTimeout, err := time.ParseDuration(viper.GetString("api.timeout"))
-	if err != nil {
-		log.Printf("Error reading api.timeout: %s", err)
-		apiTimeout = 10 * time.Second
+// Load reads test-config.yaml from configPath, layers the profile named by
+// PINSTACK_E2E_PROFILE on top of it, applies PINSTACK_E2E_* environment
+// overrides and validates the result.
+func Load(configPath string) (*Config, error) {
+	return LoadProfile(configPath, os.Getenv(ProfileEnv))
+}
+
+// LoadProfile is like Load but takes the profile name explicitly. An empty
+// profile loads only the base file. The profile file is looked up as
+// test-config.<profile>.yaml next to the base file.
+func LoadProfile(configPath, profile string) (*Config, error) {
+	v := viper.New()
+	v.SetConfigName(configName)
+	v.SetConfigType(configType)
+	v.AddConfigPath(configPath)
+
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	setDefaults(v)
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
 	}
 
-	testTimeout, err := time.ParseDuration(viper.GetString("test.test_timeout"))
-	if err != nil {
-		log.Printf("Error reading  test.test_timeout: %s", err)
-		testTimeout = 2 * time.Minute
+	if profile != "" {
+		profileFile := filepath.Join(configPath, fmt.Sprintf("%s.%s.%s", configName, profile, configType))
+		v.SetConfigFile(profileFile)
+		if err := v.MergeInConfig(); err != nil {
+			return nil, fmt.Errorf("merge profile %q: %w", profile, err)
+		}
 	}
 
-	accessExpiresAt, err := time.ParseDuration(viper.GetString("jwt.access_expires_at"))
+	cfg, err := build(v)
 	if err != nil {
-		log.Printf("Error reading jwt.access_expires_at: %s", err)
-		accessExpiresAt = 1 * time.Minute
+		return nil, err
+	}
+	cfg.Profile = profile
+	if p := cfg.Security.PayloadsFile; p != "" && !filepath.IsAbs(p) {
+		cfg.Security.PayloadsFile = filepath.Join(configPath, p)
+	}
+	if p := cfg.Tracing.FilePath; p != "" && !filepath.IsAbs(p) {
+		cfg.Tracing.FilePath = filepath.Join(filepath.Dir(filepath.Clean(configPath)), p)
 	}
 
-	refreshExpiresAt, err := time.ParseDuration(viper.GetString("jwt.refresh_expires_at"))
-	if err != nil {
-		log.Printf("Error reading  jwt.refresh_expires_at: %s", err)
-		refreshExpiresAt = 5 * time.Minute
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("outbox.concurrency", 10)
+	v.SetDefault("outbox.tick_interval_ms", 2000)
+	v.SetDefault("outbox.batch_size", 100)
+	v.SetDefault("outbox.delivery_slack_ms", 2000)
+	v.SetDefault("outbox.latency_samples", 20)
+	v.SetDefault("outbox.latency_parallel", 4)
+
+	v.SetDefault("env", "test")
+
+	v.SetDefault("api.base_url", "http://localhost:42080/api")
+	v.SetDefault("api.timeout", "10s")
+	v.SetDefault("api.client_id", "e2e-test-client")
+	v.SetDefault("api.client_secret", "e2e-test-secret")
+
+	v.SetDefault("test.concurrent", 5)
+	v.SetDefault("test.requests_per_test", 100)
+	v.SetDefault("test.test_timeout", "2m")
+	v.SetDefault("test.cleanup", true)
+	v.SetDefault("test.log_level", "info")
+
+	v.SetDefault("services.user_service.address", "localhost")
+	v.SetDefault("services.user_service.port", 42051)
+	v.SetDefault("services.auth_service.address", "localhost")
+	v.SetDefault("services.auth_service.port", 42052)
+	v.SetDefault("services.post_service.address", "localhost")
+	v.SetDefault("services.post_service.port", 42053)
+	v.SetDefault("services.relation_service.address", "localhost")
+	v.SetDefault("services.relation_service.port", 42054)
+	v.SetDefault("services.notification_service.address", "localhost")
+	v.SetDefault("services.notification_service.port", 42055)
+
+	v.SetDefault("jwt.secret", "my-secret")
+	v.SetDefault("jwt.access_expires_at", "1m")
+	v.SetDefault("jwt.refresh_expires_at", "30m")
+
+	v.SetDefault("redaction.fields", []string{})
+
+	v.SetDefault("tracing.exporter", TracingExporterNone)
+	v.SetDefault("tracing.file_path", "traces.jsonl")
+	v.SetDefault("tracing.otlp_endpoint", "localhost:4318")
+	v.SetDefault("tracing.service_name", "pinstack-e2e-tests")
+
+	v.SetDefault("idempotency.follow_cycles", 5)
+	v.SetDefault("idempotency.concurrent_follows", 10)
+
+	v.SetDefault("cache.staleness_window_ms", 500)
+	v.SetDefault("cache.rounds", 3)
+	v.SetDefault("cache.concurrency", 4)
+	v.SetDefault("cache.key_patterns", []map[string]string{})
+
+	v.SetDefault("race.writers", 8)
+
+	v.SetDefault("load.users", 20)
+	v.SetDefault("load.think_time", "200ms")
+	v.SetDefault("load.refresh_interval", "30s")
+
+	v.SetDefault("soak.duration", "4h")
+	v.SetDefault("soak.sample_interval", "1m")
+	v.SetDefault("soak.p95_growth", 1.5)
+	v.SetDefault("soak.error_rate_growth", 0.01)
+	v.SetDefault("soak.resource_growth", 2.0)
+
+	v.SetDefault("arrival.max_backlog", 1000)
+	v.SetDefault("arrival.min_achieved_ratio", 0.95)
+
+	v.SetDefault("perf.dir", "")
+	v.SetDefault("perf.label", "")
+	v.SetDefault("perf.p95_growth", 1.2)
+	v.SetDefault("perf.p99_growth", 1.3)
+	v.SetDefault("perf.alpha", 0.01)
+	v.SetDefault("perf.min_samples", 50)
+
+	v.SetDefault("rate_limit.burst", 100)
+	v.SetDefault("rate_limit.concurrency", 20)
+	v.SetDefault("rate_limit.window", "1m")
+	v.SetDefault("rate_limit.expect_limit", false)
+	v.SetDefault("rate_limit.endpoints", []string{"get_user", "list_posts", "unread_count", "login"})
+
+	v.SetDefault("security.payloads_file", "")
+	v.SetDefault("security.oversized_bytes", 10<<20)
+	v.SetDefault("security.nesting_depth", 100000)
+	v.SetDefault("security.allow_verbatim", false)
+
+	v.SetDefault("kafka.brokers", []string{"localhost:42092"})
+	v.SetDefault("kafka.relation_events_topic", "relation-events")
+}
+
+func build(v *viper.Viper) (*Config, error) {
+	var errs []error
+
+	parseDuration := func(key string) time.Duration {
+		d, err := time.ParseDuration(v.GetString(key))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+		return d
+	}
+
+	apiTimeout := parseDuration("api.timeout")
+	testTimeout := parseDuration("test.test_timeout")
+	accessExpiresAt := parseDuration("jwt.access_expires_at")
+	refreshExpiresAt := parseDuration("jwt.refresh_expires_at")
+	thinkTime := parseDuration("load.think_time")
+	refreshInterval := parseDuration("load.refresh_interval")
+	soakDuration := parseDuration("soak.duration")
+	sampleInterval := parseDuration("soak.sample_interval")
+	rateLimitWindow := parseDuration("rate_limit.window")
+
+	var keyPatterns []CacheKeyPattern
+	if err := v.UnmarshalKey("cache.key_patterns", &keyPatterns); err != nil {
+		errs = append(errs, fmt.Errorf("cache.key_patterns: %w", err))
+	}
+
+	profiles := make(map[string][]ArrivalStage)
+	var rawProfiles map[string][]struct {
+		Name     string  `mapstructure:"name"`
+		Duration string  `mapstructure:"duration"`
+		FromRPS  float64 `mapstructure:"from_rps"`
+		ToRPS    float64 `mapstructure:"to_rps"`
+	}
+	if err := v.UnmarshalKey("arrival.profiles", &rawProfiles); err != nil {
+		errs = append(errs, fmt.Errorf("arrival.profiles: %w", err))
+	}
+	for name, raw := range rawProfiles {
+		stages := make([]ArrivalStage, len(raw))
+		for i, st := range raw {
+			d, err := time.ParseDuration(st.Duration)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("arrival.profiles.%s[%d].duration: %w", name, i, err))
+			}
+			stages[i] = ArrivalStage{Name: st.Name, Duration: d, FromRPS: st.FromRPS, ToRPS: st.ToRPS}
+			if stages[i].Name == "" {
+				stages[i].Name = fmt.Sprintf("stage-%d", i+1)
+			}
+		}
+		profiles[name] = stages
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
 	}
 
 	config := &Config{
-		Env: viper.GetString("env"),
+		Env: v.GetString("env"),
 		API: API{
-			BaseURL:      viper.GetString("api.base_url"),
+			BaseURL:      v.GetString("api.base_url"),
 			Timeout:      apiTimeout,
-			ClientID:     viper.GetString("api.client_id"),
-			ClientSecret: viper.GetString("api.client_secret"),
+			ClientID:     v.GetString("api.client_id"),
+			ClientSecret: v.GetString("api.client_secret"),
 		},
 		Test: Test{
-			Concurrent:      viper.GetInt("test.concurrent"),
-			RequestsPerTest: viper.GetInt("test.requests_per_test"),
+			Concurrent:      v.GetInt("test.concurrent"),
+			RequestsPerTest: v.GetInt("test.requests_per_test"),
 			TestTimeout:     testTimeout,
-			Cleanup:         viper.GetBool("test.cleanup"),
-			LogLevel:        viper.GetString("test.log_level"),
+			Cleanup:         v.GetBool("test.cleanup"),
+			LogLevel:        v.GetString("test.log_level"),
 		},
 		Services: Services{
 			UserService: ServiceConfig{
-				Address: viper.GetString("services.user_service.address"),
-				Port:    viper.GetInt("services.user_service.port"),
+				Address: v.GetString("services.user_service.address"),
+				Port:    v.GetInt("services.user_service.port"),
 			},
 			AuthService: ServiceConfig{
-				Address: viper.GetString("services.auth_service.address"),
-				Port:    viper.GetInt("services.auth_service.port"),
+				Address: v.GetString("services.auth_service.address"),
+				Port:    v.GetInt("services.auth_service.port"),
 			},
 			PostService: ServiceConfig{
-				Address: viper.GetString("services.post_service.address"),
-				Port:    viper.GetInt("services.post_service.port"),
+				Address: v.GetString("services.post_service.address"),
+				Port:    v.GetInt("services.post_service.port"),
 			},
 			RelationService: ServiceConfig{
-				Address: viper.GetString("services.relation_service.address"),
-				Port:    viper.GetInt("services.relation_service.port"),
+				Address: v.GetString("services.relation_service.address"),
+				Port:    v.GetInt("services.relation_service.port"),
 			},
 			NotificationService: ServiceConfig{
-				Address: viper.GetString("services.notification_service.address"),
-				Port:    viper.GetInt("services.notification_service.port"),
+				Address: v.GetString("services.notification_service.address"),
+				Port:    v.GetInt("services.notification_service.port"),
 			},
 		},
 		Outbox: OutboxConfig{
-			Concurrency:    viper.GetInt("outbox.concurrency"),
-			TickIntervalMs: viper.GetInt("outbox.tick_interval_ms"),
-			BatchSize:      viper.GetInt("outbox.batch_size"),
+			Concurrency:    v.GetInt("outbox.concurrency"),
+			TickIntervalMs: v.GetInt("outbox.tick_interval_ms"),
+			BatchSize:      v.GetInt("outbox.batch_size"),
+
+			DeliverySlackMs: v.GetInt("outbox.delivery_slack_ms"),
+			LatencySamples:  v.GetInt("outbox.latency_samples"),
+			LatencyParallel: v.GetInt("outbox.latency_parallel"),
 		},
 		JWT: JWT{
-			Secret:           viper.GetString("jwt.secret"),
+			Secret:           v.GetString("jwt.secret"),
 			AccessExpiresAt:  accessExpiresAt,
 			RefreshExpiresAt: refreshExpiresAt,
 		},
+		Redaction: Redaction{
+			Fields: v.GetStringSlice("redaction.fields"),
+		},
+		Tracing: Tracing{
+			Exporter:     v.GetString("tracing.exporter"),
+			FilePath:     v.GetString("tracing.file_path"),
+			OTLPEndpoint: v.GetString("tracing.otlp_endpoint"),
+			ServiceName:  v.GetString("tracing.service_name"),
+		},
+		Kafka: Kafka{
+			Brokers:             v.GetStringSlice("kafka.brokers"),
+			RelationEventsTopic: v.GetString("kafka.relation_events_topic"),
+		},
+		Idempotency: Idempotency{
+			FollowCycles:      v.GetInt("idempotency.follow_cycles"),
+			ConcurrentFollows: v.GetInt("idempotency.concurrent_follows"),
+		},
+		Cache: Cache{
+			StalenessWindowMs: v.GetInt("cache.staleness_window_ms"),
+			Rounds:            v.GetInt("cache.rounds"),
+			Concurrency:       v.GetInt("cache.concurrency"),
+			KeyPatterns:       keyPatterns,
+		},
+		Race: Race{
+			Writers: v.GetInt("race.writers"),
+		},
+		Load: LoadConfig{
+			Users:           v.GetInt("load.users"),
+			ThinkTime:       thinkTime,
+			RefreshInterval: refreshInterval,
+		},
+		Soak: Soak{
+			Duration:        soakDuration,
+			SampleInterval:  sampleInterval,
+			P95Growth:       v.GetFloat64("soak.p95_growth"),
+			ErrorRateGrowth: v.GetFloat64("soak.error_rate_growth"),
+			ResourceGrowth:  v.GetFloat64("soak.resource_growth"),
+		},
+		Arrival: Arrival{
+			MaxBacklog:       v.GetInt("arrival.max_backlog"),
+			MinAchievedRatio: v.GetFloat64("arrival.min_achieved_ratio"),
+			Profiles:         profiles,
+		},
+		Perf: Perf{
+			Dir:        v.GetString("perf.dir"),
+			Label:      v.GetString("perf.label"),
+			P95Growth:  v.GetFloat64("perf.p95_growth"),
+			P99Growth:  v.GetFloat64("perf.p99_growth"),
+			Alpha:      v.GetFloat64("perf.alpha"),
+			MinSamples: v.GetInt("perf.min_samples"),
+		},
+		RateLimit: RateLimit{
+			Burst:       v.GetInt("rate_limit.burst"),
+			Concurrency: v.GetInt("rate_limit.concurrency"),
+			Window:      rateLimitWindow,
+			ExpectLimit: v.GetBool("rate_limit.expect_limit"),
+			Endpoints:   v.GetStringSlice("rate_limit.endpoints"),
+		},
+		Security: Security{
+			PayloadsFile:   v.GetString("security.payloads_file"),
+			OversizedBytes: v.GetInt("security.oversized_bytes"),
+			NestingDepth:   v.GetInt("security.nesting_depth"),
+			AllowVerbatim:  v.GetBool("security.allow_verbatim"),
+		},
 	}
 
-	return config
+	return config, nil
 }
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
+
+func TestLoadDefaults(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "test-config.yaml", "env: test\n")
+
+	cfg, err := LoadProfile(dir, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "http://localhost:42080/api", cfg.API.BaseURL)
+	assert.Equal(t, 10*time.Second, cfg.API.Timeout)
+	assert.Equal(t, 5, cfg.Test.Concurrent)
+	assert.Equal(t, 2*time.Second, cfg.Outbox.TickInterval())
+}
+
+func TestLoadProfileAndEnvOverrides(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "test-config.yaml", "api:\n  base_url: \"http://base:1/api\"\ntest:\n  concurrent: 5\n")
+	writeConfig(t, dir, "test-config.ci.yaml", "api:\n  base_url: \"http://ci:2/api\"\ntest:\n  concurrent: 7\n")
+
+	t.Setenv("PINSTACK_E2E_TEST_CONCURRENT", "9")
+	t.Setenv("PINSTACK_E2E_OUTBOX_TICK_INTERVAL_MS", "250")
+
+	cfg, err := LoadProfile(dir, "ci")
+	require.NoError(t, err)
+
+	assert.Equal(t, "ci", cfg.Profile)
+	assert.Equal(t, "http://ci:2/api", cfg.API.BaseURL)
+	assert.Equal(t, 9, cfg.Test.Concurrent)
+	assert.Equal(t, 250*time.Millisecond, cfg.Outbox.TickInterval())
+}
+
+func TestLoadMissingProfile(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "test-config.yaml", "env: test\n")
+
+	_, err := LoadProfile(dir, "nope")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `profile "nope"`)
+}
+
+func TestLoadValidationAggregatesErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "test-config.yaml", `
+api:
+  base_url: "localhost:42080"
+test:
+  concurrent: -1
+  log_level: "loud"
+outbox:
+  batch_size: 0
+`)
+
+	_, err := LoadProfile(dir, "")
+	require.Error(t, err)
+	for _, key := range []string{"api.base_url", "test.concurrent", "test.log_level", "outbox.batch_size"} {
+		assert.Contains(t, err.Error(), key)
+	}
+}
+
+func TestLoadInvalidDuration(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "test-config.yaml", "api:\n  timeout: \"soon\"\n")
+
+	_, err := LoadProfile(dir, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "api.timeout")
+}
+
+func TestOutboxDeliveryBound(t *testing.T) {
+	o := OutboxConfig{Concurrency: 2, TickIntervalMs: 1000, BatchSize: 10, DeliverySlackMs: 500}
+
+	assert.Equal(t, 1500*time.Millisecond, o.DeliveryBound(1))
+	assert.Equal(t, 1500*time.Millisecond, o.DeliveryBound(20), "one round drains two full batches")
+	assert.Equal(t, 2500*time.Millisecond, o.DeliveryBound(21))
+	assert.Equal(t, 3500*time.Millisecond, o.DeliveryBound(60))
+}
+
+func TestLoadArrivalProfiles(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "test-config.yaml", `
+arrival:
+  profiles:
+    staged:
+      - { name: ramp, duration: "5m", from_rps: 0, to_rps: 200 }
+      - { duration: "1m", from_rps: 1000, to_rps: 1000 }
+`)
+
+	cfg, err := LoadProfile(dir, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []ArrivalStage{
+		{Name: "ramp", Duration: 5 * time.Minute, FromRPS: 0, ToRPS: 200},
+		{Name: "stage-2", Duration: time.Minute, FromRPS: 1000, ToRPS: 1000},
+	}, cfg.Arrival.Profiles["staged"])
+
+	writeConfig(t, dir, "test-config.yaml", `
+arrival:
+  profiles:
+    broken:
+      - { duration: "soon", from_rps: 1, to_rps: 1 }
+`)
+	_, err = LoadProfile(dir, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "arrival.profiles.broken[0].duration")
+}
+
+func TestSecurityPayloadsFileIsRelativeToConfigDir(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "test-config.yaml", "security:\n  payloads_file: \"extra.yaml\"\n")
+
+	_, err := LoadProfile(dir, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "security.payloads_file")
+
+	writeConfig(t, dir, "extra.yaml", "payloads: []\n")
+	cfg, err := LoadProfile(dir, "")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "extra.yaml"), cfg.Security.PayloadsFile)
+}
+
+func TestTracingFilePathIsRelativeToRepoRoot(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "config")
+	require.NoError(t, os.Mkdir(dir, 0o700))
+	writeConfig(t, dir, "test-config.yaml", "tracing:\n  file_path: \"out/traces.jsonl\"\n")
+
+	cfg, err := LoadProfile(dir, "")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "out", "traces.jsonl"), cfg.Tracing.FilePath)
+
+	writeConfig(t, dir, "test-config.yaml", "tracing:\n  file_path: \"/tmp/traces.jsonl\"\n")
+	cfg, err = LoadProfile(dir, "")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/traces.jsonl", cfg.Tracing.FilePath)
+}
+
+func TestCacheKeyPatterns(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "test-config.yaml", `
+cache:
+  key_patterns:
+    - { endpoint: "GET /v1/users/{id}", pattern: "user:{id}" }
+`)
+
+	cfg, err := LoadProfile(dir, "")
+	require.NoError(t, err)
+	assert.Equal(t, "user:{id}", cfg.Cache.KeyPattern("GET /v1/users/{id}"))
+	assert.Empty(t, cfg.Cache.KeyPattern("GET /v1/posts/{id}"))
+
+	writeConfig(t, dir, "test-config.yaml", `
+cache:
+  key_patterns:
+    - { endpoint: "GET /v1/users/{id}" }
+`)
+	_, err = LoadProfile(dir, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cache.key_patterns[0]")
+}
@@ -0,0 +1,232 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+)
+
+// Validate checks the loaded values and returns every problem it finds joined
+// into a single error, so one run reports all misconfigured keys at once.
+func (c *Config) Validate() error {
+	var errs []error
+	add := func(key, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	if c.Env == "" {
+		add("env", "must not be empty")
+	}
+
+	if u, err := url.Parse(c.API.BaseURL); err != nil {
+		add("api.base_url", "invalid URL %q: %v", c.API.BaseURL, err)
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		add("api.base_url", "scheme must be http or https, got %q", c.API.BaseURL)
+	} else if u.Host == "" {
+		add("api.base_url", "host is missing in %q", c.API.BaseURL)
+	}
+	if c.API.Timeout <= 0 {
+		add("api.timeout", "must be positive, got %s", c.API.Timeout)
+	}
+
+	if c.Test.Concurrent <= 0 {
+		add("test.concurrent", "must be positive, got %d", c.Test.Concurrent)
+	}
+	if c.Test.RequestsPerTest < 0 {
+		add("test.requests_per_test", "must not be negative, got %d", c.Test.RequestsPerTest)
+	}
+	if c.Test.TestTimeout <= 0 {
+		add("test.test_timeout", "must be positive, got %s", c.Test.TestTimeout)
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Test.LogLevel)); err != nil {
+		add("test.log_level", "unknown level %q", c.Test.LogLevel)
+	}
+
+	services := []struct {
+		key string
+		svc ServiceConfig
+	}{
+		{"services.user_service", c.Services.UserService},
+		{"services.auth_service", c.Services.AuthService},
+		{"services.post_service", c.Services.PostService},
+		{"services.relation_service", c.Services.RelationService},
+		{"services.notification_service", c.Services.NotificationService},
+	}
+	for _, s := range services {
+		if s.svc.Address == "" {
+			add(s.key+".address", "must not be empty")
+		}
+		if s.svc.Port <= 0 || s.svc.Port > 65535 {
+			add(s.key+".port", "must be in 1..65535, got %d", s.svc.Port)
+		}
+	}
+
+	if c.JWT.AccessExpiresAt <= 0 {
+		add("jwt.access_expires_at", "must be positive, got %s", c.JWT.AccessExpiresAt)
+	}
+	if c.JWT.RefreshExpiresAt < c.JWT.AccessExpiresAt {
+		add("jwt.refresh_expires_at", "must not be shorter than jwt.access_expires_at")
+	}
+
+	if c.Outbox.Concurrency <= 0 {
+		add("outbox.concurrency", "must be positive, got %d", c.Outbox.Concurrency)
+	}
+	if c.Outbox.TickIntervalMs <= 0 {
+		add("outbox.tick_interval_ms", "must be positive, got %d", c.Outbox.TickIntervalMs)
+	}
+	if c.Outbox.BatchSize <= 0 {
+		add("outbox.batch_size", "must be positive, got %d", c.Outbox.BatchSize)
+	}
+	if c.Outbox.DeliverySlackMs < 0 {
+		add("outbox.delivery_slack_ms", "must not be negative, got %d", c.Outbox.DeliverySlackMs)
+	}
+	if c.Outbox.LatencySamples <= 0 {
+		add("outbox.latency_samples", "must be positive, got %d", c.Outbox.LatencySamples)
+	}
+	if c.Outbox.LatencyParallel <= 0 {
+		add("outbox.latency_parallel", "must be positive, got %d", c.Outbox.LatencyParallel)
+	}
+
+	switch c.Tracing.Exporter {
+	case TracingExporterNone:
+	case TracingExporterFile:
+		if c.Tracing.FilePath == "" {
+			add("tracing.file_path", "must be set for the file exporter")
+		}
+	case TracingExporterOTLP:
+		if c.Tracing.OTLPEndpoint == "" {
+			add("tracing.otlp_endpoint", "must be set for the otlp exporter")
+		}
+	default:
+		add("tracing.exporter", "must be one of none, file, otlp, got %q", c.Tracing.Exporter)
+	}
+
+	if len(c.Kafka.Brokers) == 0 {
+		add("kafka.brokers", "must list at least one broker")
+	}
+	for _, b := range c.Kafka.Brokers {
+		if _, port, err := net.SplitHostPort(b); err != nil || port == "" {
+			add("kafka.brokers", "%q must be host:port", b)
+		}
+	}
+	if c.Kafka.RelationEventsTopic == "" {
+		add("kafka.relation_events_topic", "must not be empty")
+	}
+
+	if c.Idempotency.FollowCycles <= 0 {
+		add("idempotency.follow_cycles", "must be positive, got %d", c.Idempotency.FollowCycles)
+	}
+	if c.Idempotency.ConcurrentFollows <= 1 {
+		add("idempotency.concurrent_follows", "must be at least 2 to race, got %d", c.Idempotency.ConcurrentFollows)
+	}
+
+	if c.Cache.StalenessWindowMs < 0 {
+		add("cache.staleness_window_ms", "must not be negative, got %d", c.Cache.StalenessWindowMs)
+	}
+	if c.Cache.Rounds <= 0 {
+		add("cache.rounds", "must be positive, got %d", c.Cache.Rounds)
+	}
+	if c.Cache.Concurrency <= 0 {
+		add("cache.concurrency", "must be positive, got %d", c.Cache.Concurrency)
+	}
+	for i, kp := range c.Cache.KeyPatterns {
+		if kp.Endpoint == "" || kp.Pattern == "" {
+			add(fmt.Sprintf("cache.key_patterns[%d]", i), "needs both endpoint and pattern")
+		}
+	}
+
+	if c.Race.Writers <= 1 {
+		add("race.writers", "must be at least 2 to race, got %d", c.Race.Writers)
+	}
+
+	if c.Load.Users <= 0 {
+		add("load.users", "must be positive, got %d", c.Load.Users)
+	}
+	if c.Load.ThinkTime < 0 {
+		add("load.think_time", "must not be negative, got %s", c.Load.ThinkTime)
+	}
+	if c.Load.RefreshInterval <= 0 || c.Load.RefreshInterval >= c.JWT.AccessExpiresAt {
+		add("load.refresh_interval", "must be positive and shorter than jwt.access_expires_at, got %s", c.Load.RefreshInterval)
+	}
+
+	if c.Soak.SampleInterval <= 0 {
+		add("soak.sample_interval", "must be positive, got %s", c.Soak.SampleInterval)
+	}
+	if c.Soak.Duration < c.Soak.SampleInterval {
+		add("soak.duration", "must be at least one soak.sample_interval, got %s", c.Soak.Duration)
+	}
+	if c.Soak.P95Growth < 1 {
+		add("soak.p95_growth", "must be at least 1, got %g", c.Soak.P95Growth)
+	}
+	if c.Soak.ErrorRateGrowth < 0 || c.Soak.ErrorRateGrowth > 1 {
+		add("soak.error_rate_growth", "must be in 0..1, got %g", c.Soak.ErrorRateGrowth)
+	}
+	if c.Soak.ResourceGrowth < 1 {
+		add("soak.resource_growth", "must be at least 1, got %g", c.Soak.ResourceGrowth)
+	}
+
+	if c.Arrival.MaxBacklog <= 0 {
+		add("arrival.max_backlog", "must be positive, got %d", c.Arrival.MaxBacklog)
+	}
+	if c.Arrival.MinAchievedRatio <= 0 || c.Arrival.MinAchievedRatio > 1 {
+		add("arrival.min_achieved_ratio", "must be in (0, 1], got %g", c.Arrival.MinAchievedRatio)
+	}
+	for name, stages := range c.Arrival.Profiles {
+		if len(stages) == 0 {
+			add("arrival.profiles."+name, "must have at least one stage")
+		}
+		for i, st := range stages {
+			key := fmt.Sprintf("arrival.profiles.%s[%d]", name, i)
+			if st.Duration <= 0 {
+				add(key+".duration", "must be positive, got %s", st.Duration)
+			}
+			if st.FromRPS < 0 || st.ToRPS < 0 {
+				add(key, "rates must not be negative, got %g -> %g", st.FromRPS, st.ToRPS)
+			}
+		}
+	}
+
+	if c.Perf.P95Growth < 1 {
+		add("perf.p95_growth", "must be at least 1, got %g", c.Perf.P95Growth)
+	}
+	if c.Perf.P99Growth < 1 {
+		add("perf.p99_growth", "must be at least 1, got %g", c.Perf.P99Growth)
+	}
+	if c.Perf.Alpha <= 0 || c.Perf.Alpha >= 1 {
+		add("perf.alpha", "must be in (0, 1), got %g", c.Perf.Alpha)
+	}
+	if c.Perf.MinSamples <= 0 {
+		add("perf.min_samples", "must be positive, got %d", c.Perf.MinSamples)
+	}
+
+	if c.RateLimit.Burst <= 0 {
+		add("rate_limit.burst", "must be positive, got %d", c.RateLimit.Burst)
+	}
+	if c.RateLimit.Concurrency <= 0 {
+		add("rate_limit.concurrency", "must be positive, got %d", c.RateLimit.Concurrency)
+	}
+	if c.RateLimit.Window <= 0 {
+		add("rate_limit.window", "must be positive, got %s", c.RateLimit.Window)
+	}
+
+	if p := c.Security.PayloadsFile; p != "" {
+		if _, err := os.Stat(p); err != nil {
+			add("security.payloads_file", "%v", err)
+		}
+	}
+	if c.Security.OversizedBytes <= 0 {
+		add("security.oversized_bytes", "must be positive, got %d", c.Security.OversizedBytes)
+	}
+	if c.Security.NestingDepth <= 0 {
+		add("security.nesting_depth", "must be positive, got %d", c.Security.NestingDepth)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config: %w", errors.Join(errs...))
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
@@ -9,17 +10,32 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/Soloda1/pinstack-system-tests/config"
 	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
 	"github.com/Soloda1/pinstack-system-tests/internal/logger"
 )
 
+// RawBody is sent as the request body byte for byte, for requests that must
+// not go through json.Marshal: malformed, oversized or deeply nested JSON.
+type RawBody []byte
+
 type Client struct {
 	BaseURL    string
 	HTTPClient *http.Client
 	Token      string
 	log        *logger.Logger
+	tracer     *requestTracer
+
+	// ctx is guarded by mu: factories hand one user's client to parallel
+	// subtests, each setting its own context.
+	mu  sync.RWMutex
+	ctx context.Context
 }
 
 func NewClient(cfg *config.Config, log *logger.Logger) *Client {
@@ -28,7 +44,9 @@ func NewClient(cfg *config.Config, log *logger.Logger) *Client {
 		HTTPClient: &http.Client{
 			Timeout: cfg.API.Timeout,
 		},
-		log: log,
+		log:    log,
+		tracer: newRequestTracer(log),
+		ctx:    context.Background(),
 	}
 }
 
@@ -40,7 +58,30 @@ func (c *Client) GetToken() string {
 	return c.Token
 }
 
+// SetContext sets the context requests are made with; spans of HTTP calls
+// become children of the span it carries.
+func (c *Client) SetContext(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ctx = ctx
+}
+
+func (c *Client) Context() context.Context {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ctx
+}
+
 func (c *Client) makeRequest(method, path string, queryParams url.Values, body interface{}, result interface{}) error {
+	ctx, span := c.startSpan(method, path)
+	ri := c.tracer.next(ctx)
+
+	err := c.do(ctx, ri, method, path, queryParams, body, result)
+	endSpan(span, ri, err)
+	return err
+}
+
+func (c *Client) do(ctx context.Context, ri requestInfo, method, path string, queryParams url.Values, body interface{}, result interface{}) error {
 	reqURL, err := url.Parse(c.BaseURL + path)
 	if err != nil {
 		c.log.Error("Invalid URL", slog.String("path", path), slog.String("error", err.Error()))
@@ -52,7 +93,9 @@ func (c *Client) makeRequest(method, path string, queryParams url.Values, body i
 	}
 
 	var reqBody io.Reader
-	if body != nil {
+	if raw, ok := body.(RawBody); ok {
+		reqBody = bytes.NewReader(raw)
+	} else if body != nil {
 		jsonData, err := json.Marshal(body)
 		if err != nil {
 			c.log.Error("Failed to marshal request body", slog.String("path", path), slog.String("error", err.Error()))
@@ -61,7 +104,7 @@ func (c *Client) makeRequest(method, path string, queryParams url.Values, body i
 		reqBody = bytes.NewBuffer(jsonData)
 	}
 
-	req, err := http.NewRequest(method, reqURL.String(), reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), reqBody)
 	if err != nil {
 		c.log.Error("Failed to create request", slog.String("path", path), slog.String("error", err.Error()))
 		return custom_errors.ErrRequestCreationFailed
@@ -72,10 +115,21 @@ func (c *Client) makeRequest(method, path string, queryParams url.Values, body i
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
 	}
 
+	ri.apply(req)
+
+	c.log.Debug("request",
+		slog.String("method", method),
+		slog.String("path", path),
+		slog.String("request_id", ri.RequestID),
+		slog.String("traceparent", ri.TraceParent),
+		slog.Any("headers", c.log.Redactor().Header(req.Header)),
+	)
+
+	start := time.Now()
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		c.log.Error("Failed to execute request", slog.String("path", path), slog.String("error", err.Error()))
-		return custom_errors.ErrRequestFailed
+		c.log.Error("Failed to execute request", slog.String("path", path), slog.String("request_id", ri.RequestID), slog.String("error", err.Error()))
+		return withRequestID(custom_errors.ErrRequestFailed, ri)
 	}
 	defer func(body io.ReadCloser) {
 		err := body.Close()
@@ -84,22 +138,34 @@ func (c *Client) makeRequest(method, path string, queryParams url.Values, body i
 		}
 	}(resp.Body)
 
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int(AttrStatusCode, resp.StatusCode))
+
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		c.log.Error("Failed to read response", slog.String("path", path), slog.String("error", err.Error()))
-		return custom_errors.ErrResponseReadFailed
+		c.log.Error("Failed to read response", slog.String("path", path), slog.String("request_id", ri.RequestID), slog.String("error", err.Error()))
+		return withRequestID(custom_errors.ErrResponseReadFailed, ri)
 	}
+	observe(method, path, time.Since(start))
 
-	c.log.Debug("response body", slog.Any("body", string(respBody)))
+	c.log.Debug("response body", slog.String("request_id", ri.RequestID), slog.Any("body", c.log.Redactor().JSONString(string(respBody))))
 
 	if resp.StatusCode >= 300 || resp.StatusCode < 200 {
 		var errorResp fixtures.ErrorBody
+		apiErr := &APIError{
+			StatusCode:  resp.StatusCode,
+			RequestID:   ri.RequestID,
+			TraceParent: ri.TraceParent,
+			Header:      resp.Header.Clone(),
+			Body:        respBody,
+		}
 		if err := json.Unmarshal(respBody, &errorResp); err != nil {
-			c.log.Debug("API error failed to unmarshal", slog.String("status code", resp.Status), slog.String("body", string(respBody)))
-			return custom_errors.ErrJSONUnmarshalFailed
+			c.log.Debug("API error failed to unmarshal", slog.String("status code", resp.Status), slog.String("request_id", ri.RequestID), slog.String("body", c.log.Redactor().JSONString(string(respBody))))
+			apiErr.Err = custom_errors.ErrJSONUnmarshalFailed
+			return apiErr
 		}
-		c.log.Error("API error", slog.String("status code", resp.Status), slog.Any("errors", errorResp))
-		return fmt.Errorf("%s", errorResp.Message)
+		c.log.Error("API error", slog.String("status code", resp.Status), slog.String("request_id", ri.RequestID), slog.Any("body", c.log.Redactor().JSONString(string(respBody))))
+		apiErr.Message = errorResp.Message
+		return apiErr
 	}
 
 	if result != nil {
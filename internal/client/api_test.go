@@ -0,0 +1,127 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var traceParentRe = regexp.MustCompile(`^00-[0-9a-f]{32}-[0-9a-f]{16}-01$`)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cfg := &config.Config{API: config.API{BaseURL: srv.URL, Timeout: 5 * time.Second}}
+	return NewClient(cfg, logger.ForTest(t))
+}
+
+func TestRequestCarriesCorrelationHeaders(t *testing.T) {
+	var requestIDs, traceParents []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requestIDs = append(requestIDs, r.Header.Get(HeaderRequestID))
+		traceParents = append(traceParents, r.Header.Get(HeaderTraceParent))
+		_, _ = w.Write([]byte(`{"status":200,"data":{}}`))
+	})
+
+	require.NoError(t, c.Get("/a", nil, nil))
+	require.NoError(t, c.Get("/b", nil, nil))
+
+	correlationID := c.log.CorrelationID()
+	assert.Equal(t, []string{"e2e-" + correlationID + "-1", "e2e-" + correlationID + "-2"}, requestIDs)
+	for _, tp := range traceParents {
+		assert.Regexp(t, traceParentRe, tp)
+	}
+	assert.Equal(t, traceParents[0][:35], traceParents[1][:35], "requests of one client share a trace ID")
+	assert.NotEqual(t, traceParents[0], traceParents[1])
+}
+
+func TestAPIErrorIncludesRequestID(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status":400,"message":"validation failed"}`))
+	})
+
+	err := c.Post("/x", map[string]string{"a": "b"}, nil)
+	require.Error(t, err)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, StatusCode(err))
+	assert.Contains(t, err.Error(), "validation failed")
+	assert.Contains(t, err.Error(), apiErr.RequestID)
+}
+
+func TestObserverGetsTemplatedRoutes(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/posts/9" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_, _ = w.Write([]byte(`{"status":200,"data":{}}`))
+	})
+
+	var routes []string
+	SetObserver(func(route string, latency time.Duration) {
+		routes = append(routes, route)
+	})
+	t.Cleanup(func() { SetObserver(nil) })
+
+	require.NoError(t, NewUserClient(c).DeleteUser(42))
+	_, _ = NewUserClient(c).GetUserByUsername("4711")
+	_, _ = NewPostClient(c).GetPostByID(9)
+	_ = c.Get("/v1/relation/7/followers", nil, nil)
+
+	assert.Equal(t, []string{
+		"DELETE /v1/users/{id}",
+		"GET /v1/users/username/{username}",
+		"GET /v1/posts/{id}",
+		"GET /v1/relation/{id}/followers",
+	}, routes, "error responses are observed too")
+}
+
+func TestAPIErrorKeepsStatusAndHeaders(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte("slow down"))
+	})
+
+	err := c.Get("/x", nil, nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, StatusCode(err), "a body that is not JSON still reports the status")
+	assert.ErrorIs(t, err, custom_errors.ErrJSONUnmarshalFailed)
+
+	wait, ok := RetryAfter(err)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, wait)
+
+	_, ok = RetryAfter(errors.New("plain"))
+	assert.False(t, ok)
+}
+
+func TestRawBodyIsSentAsIs(t *testing.T) {
+	var got []byte
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status":400,"message":"bad json"}`))
+	})
+
+	err := c.Post("/x", RawBody(`{"a":`), nil)
+	assert.Equal(t, `{"a":`, string(got))
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.JSONEq(t, `{"status":400,"message":"bad json"}`, string(apiErr.Body))
+}
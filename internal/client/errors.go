@@ -0,0 +1,73 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// APIError is returned for non-2xx gateway responses. Error() keeps the
+// gateway message first so existing assertions on the message still match,
+// and appends the request ID for grepping backend logs.
+type APIError struct {
+	StatusCode  int
+	Message     string
+	RequestID   string
+	TraceParent string
+	// Header is the response header, for Retry-After and rate-limit headers.
+	Header http.Header
+	// Body is the raw response body. It is not redacted; print it through
+	// logger.Redactor().JSONString.
+	Body []byte
+	// Err is set when the body was not the gateway's JSON error format;
+	// Message is then empty.
+	Err error
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" && e.Err != nil {
+		msg = e.Err.Error()
+	}
+	return fmt.Sprintf("%s (status=%d request_id=%s)", msg, e.StatusCode, e.RequestID)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns the HTTP status of err if it is an *APIError, or 0.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// RetryAfter returns the Retry-After of err if it is an *APIError carrying
+// one, given either in seconds or as an HTTP date.
+func RetryAfter(err error) (time.Duration, bool) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Header == nil {
+		return 0, false
+	}
+	v := apiErr.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// withRequestID wraps a sentinel client error with the request ID.
+func withRequestID(err error, ri requestInfo) error {
+	return fmt.Errorf("%w (request_id=%s)", err, ri.RequestID)
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Observer receives the route and latency of every request that got a
+// response, from any client. Routes are templated, such as
+// "GET /v1/users/{id}", so all calls of one endpoint add up.
+type Observer func(route string, latency time.Duration)
+
+var observer atomic.Pointer[Observer]
+
+// SetObserver installs o for every client; nil removes it. Suites set it in
+// TestMain to record latencies of all their requests.
+func SetObserver(o Observer) {
+	if o == nil {
+		observer.Store(nil)
+		return
+	}
+	observer.Store(&o)
+}
+
+func observe(method, path string, latency time.Duration) {
+	if o := observer.Load(); o != nil {
+		(*o)(Route(method, path), latency)
+	}
+}
+
+// Route templates a request path: numeric segments become {id} and the
+// segment after users/username or users/email becomes {username} or {email}.
+func Route(method, path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if i >= 2 && segments[i-2] == "users" && (segments[i-1] == "username" || segments[i-1] == "email") {
+			segments[i] = "{" + segments[i-1] + "}"
+			continue
+		}
+		if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+			segments[i] = "{id}"
+		}
+	}
+	return method + " " + strings.Join(segments, "/")
+}
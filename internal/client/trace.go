@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+)
+
+const (
+	tracerName = "github.com/Soloda1/pinstack-system-tests/internal/client"
+
+	AttrEndpoint   = "http.endpoint"
+	AttrStatusCode = "http.response.status_code"
+	AttrUser       = "enduser.id"
+	AttrRequestID  = "http.request_id"
+)
+
+const (
+	HeaderRequestID   = "X-Request-ID"
+	HeaderTraceParent = "traceparent"
+
+	traceVersion = "00"
+	traceSampled = "01"
+)
+
+// requestTracer stamps every outgoing request with an X-Request-ID and a W3C
+// traceparent header. All requests of one client share the correlation ID of
+// the test logger and one trace ID, so the gateway and service logs of a
+// failed test can be found by grepping for either.
+type requestTracer struct {
+	correlationID string
+	traceID       string
+	seq           atomic.Uint64
+}
+
+func newRequestTracer(log *logger.Logger) *requestTracer {
+	correlationID := log.CorrelationID()
+	if correlationID == "" {
+		correlationID = logger.NewCorrelationID()
+	}
+	return &requestTracer{
+		correlationID: correlationID,
+		traceID:       randomHex(16),
+	}
+}
+
+// requestInfo identifies a single request in logs and errors.
+type requestInfo struct {
+	RequestID   string
+	TraceParent string
+}
+
+// next returns the identifiers of the next request. When ctx carries a valid
+// span the traceparent points at it, so backend spans join the test trace.
+func (rt *requestTracer) next(ctx context.Context) requestInfo {
+	n := rt.seq.Add(1)
+	ri := requestInfo{
+		RequestID:   fmt.Sprintf("e2e-%s-%d", rt.correlationID, n),
+		TraceParent: strings.Join([]string{traceVersion, rt.traceID, randomHex(8), traceSampled}, "-"),
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		ri.TraceParent = strings.Join([]string{traceVersion, sc.TraceID().String(), sc.SpanID().String(), sc.TraceFlags().String()}, "-")
+	}
+	return ri
+}
+
+func (ri requestInfo) apply(req *http.Request) {
+	req.Header.Set(HeaderRequestID, ri.RequestID)
+	req.Header.Set(HeaderTraceParent, ri.TraceParent)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return strings.Repeat("0", n*2)
+	}
+	return hex.EncodeToString(b)
+}
+
+// startSpan starts the client span of a single HTTP call.
+func (c *Client) startSpan(method, path string) (context.Context, trace.Span) {
+	route := Route(method, path)
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", method),
+		attribute.String(AttrEndpoint, route),
+	}
+	if user := tokenSubject(c.Token); user != "" {
+		attrs = append(attrs, attribute.String(AttrUser, user))
+	}
+
+	return otel.Tracer(tracerName).Start(c.Context(), route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+func endSpan(span trace.Span, ri requestInfo, err error) {
+	span.SetAttributes(attribute.String(AttrRequestID, ri.RequestID))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tokenSubject extracts the user from an access token without verifying it.
+// It returns an empty string for anything that is not a readable JWT.
+func tokenSubject(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	for _, key := range []string{"user_id", "sub"} {
+		switch v := claims[key].(type) {
+		case string:
+			return v
+		case float64:
+			return strconv.FormatInt(int64(v), 10)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,188 @@
+// Package coherence runs write-then-read probes against cached endpoints and
+// reports reads that keep returning pre-write data for longer than the
+// allowed staleness window.
+package coherence
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// UnknownKeyPattern stands for the key pattern of a probe that has none
+// configured.
+const UnknownKeyPattern = "unknown"
+
+// Probe is one write followed by reads that must reflect it.
+type Probe struct {
+	// Endpoint names the read, e.g. "GET /v1/users/{id}".
+	Endpoint string
+	// KeyPattern labels the cache key the read is served from, as
+	// configured for the deployment; empty means UnknownKeyPattern.
+	KeyPattern string
+
+	// Write changes the data. It runs once, after one warming Read.
+	Write func() error
+	// Read reports whether the data it got reflects the write, and what it
+	// observed for the report when it does not.
+	Read func() (fresh bool, observed string, err error)
+}
+
+// Options controls how long and how often a probe reads after its write.
+type Options struct {
+	// Window is how long reads may return pre-write data after the write
+	// returned.
+	Window time.Duration
+	// Poll is the pause between reads.
+	Poll time.Duration
+	// Confirm is how many further reads must stay fresh once the first
+	// fresh read came back, to catch caches that flip back to old data.
+	Confirm int
+}
+
+// Result is the outcome of one probe.
+type Result struct {
+	Endpoint   string
+	KeyPattern string
+
+	// Lag is the time from the write returning to the first fresh read.
+	Lag time.Duration
+	// StaleReads counts reads that returned pre-write data.
+	StaleReads int
+	// Stale is set when pre-write data was read after the window, or after a
+	// fresh read, or never went away.
+	Stale bool
+	// Observed is the last pre-write data read, for the report.
+	Observed string
+	Err      error
+}
+
+// Run warms the cache with one read, performs the write and reads until the
+// data is fresh and stays fresh for opts.Confirm reads, or until the window
+// passes.
+func Run(ctx context.Context, p Probe, opts Options) Result {
+	res := Result{Endpoint: p.Endpoint, KeyPattern: p.KeyPattern}
+	if res.KeyPattern == "" {
+		res.KeyPattern = UnknownKeyPattern
+	}
+
+	if _, _, err := p.Read(); err != nil {
+		res.Err = fmt.Errorf("warm %s: %w", p.Endpoint, err)
+		return res
+	}
+	if err := p.Write(); err != nil {
+		res.Err = fmt.Errorf("write before %s: %w", p.Endpoint, err)
+		return res
+	}
+
+	written := time.Now()
+	confirmed := -1
+	for {
+		fresh, observed, err := p.Read()
+		since := time.Since(written)
+		switch {
+		case err != nil:
+			res.Err = fmt.Errorf("read %s: %w", p.Endpoint, err)
+			return res
+		case !fresh:
+			res.StaleReads++
+			res.Observed = observed
+			if confirmed >= 0 || since > opts.Window {
+				res.Stale = true
+				return res
+			}
+		case confirmed < 0:
+			res.Lag = since
+			confirmed = 0
+		default:
+			confirmed++
+		}
+		if confirmed >= opts.Confirm {
+			return res
+		}
+
+		select {
+		case <-ctx.Done():
+			res.Err = ctx.Err()
+			return res
+		case <-time.After(opts.Poll):
+		}
+	}
+}
+
+// EndpointStats aggregates the results of one endpoint.
+type EndpointStats struct {
+	Endpoint   string
+	KeyPattern string
+	Probes     int
+	Stale      int
+	Errors     int
+	MaxLag     time.Duration
+	// Example is one stale observation, to make the report actionable.
+	Example string
+}
+
+// Report groups results by endpoint.
+type Report struct {
+	Endpoints []EndpointStats
+}
+
+// Summarize builds a Report sorted by endpoint.
+func Summarize(results []Result) Report {
+	byEndpoint := make(map[string]*EndpointStats)
+	for _, r := range results {
+		s, ok := byEndpoint[r.Endpoint]
+		if !ok {
+			s = &EndpointStats{Endpoint: r.Endpoint, KeyPattern: r.KeyPattern}
+			byEndpoint[r.Endpoint] = s
+		}
+		s.Probes++
+		switch {
+		case r.Err != nil:
+			s.Errors++
+		case r.Stale:
+			s.Stale++
+			if s.Example == "" {
+				s.Example = r.Observed
+			}
+		}
+		if r.Lag > s.MaxLag {
+			s.MaxLag = r.Lag
+		}
+	}
+
+	var rep Report
+	for _, s := range byEndpoint {
+		rep.Endpoints = append(rep.Endpoints, *s)
+	}
+	sort.Slice(rep.Endpoints, func(i, j int) bool {
+		return rep.Endpoints[i].Endpoint < rep.Endpoints[j].Endpoint
+	})
+	return rep
+}
+
+// Misbehaving returns the endpoints that served stale data at least once.
+func (r Report) Misbehaving() []EndpointStats {
+	var out []EndpointStats
+	for _, s := range r.Endpoints {
+		if s.Stale > 0 {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func (r Report) String() string {
+	var b strings.Builder
+	for _, s := range r.Endpoints {
+		fmt.Fprintf(&b, "%-40s key=%s probes=%d stale=%d errors=%d max_lag=%s",
+			s.Endpoint, s.KeyPattern, s.Probes, s.Stale, s.Errors, s.MaxLag.Round(time.Millisecond))
+		if s.Example != "" {
+			fmt.Fprintf(&b, " example=%q", s.Example)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
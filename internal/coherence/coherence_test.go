@@ -0,0 +1,107 @@
+package coherence
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedProbe answers reads from a script of freshness values; the last
+// value repeats once the script runs out.
+func scriptedProbe(endpoint string, script ...bool) (Probe, *int) {
+	reads := 0
+	written := false
+	return Probe{
+		Endpoint: endpoint,
+		Write: func() error {
+			written = true
+			return nil
+		},
+		Read: func() (bool, string, error) {
+			if !written {
+				return false, "warm", nil
+			}
+			i := reads
+			if i >= len(script) {
+				i = len(script) - 1
+			}
+			reads++
+			return script[i], "old", nil
+		},
+	}, &reads
+}
+
+func TestRun(t *testing.T) {
+	opts := Options{Window: 50 * time.Millisecond, Poll: time.Millisecond, Confirm: 2}
+
+	t.Run("fresh at once", func(t *testing.T) {
+		p, reads := scriptedProbe("a", true)
+		res := Run(context.Background(), p, opts)
+		require.NoError(t, res.Err)
+		assert.False(t, res.Stale)
+		assert.Zero(t, res.StaleReads)
+		assert.Equal(t, 3, *reads, "first fresh read plus two confirmations")
+	})
+
+	t.Run("catches up inside the window", func(t *testing.T) {
+		p, _ := scriptedProbe("a", false, false, true)
+		res := Run(context.Background(), p, opts)
+		require.NoError(t, res.Err)
+		assert.False(t, res.Stale)
+		assert.Equal(t, 2, res.StaleReads)
+		assert.Positive(t, res.Lag)
+	})
+
+	t.Run("never catches up", func(t *testing.T) {
+		p, _ := scriptedProbe("a", false)
+		res := Run(context.Background(), p, opts)
+		require.NoError(t, res.Err)
+		assert.True(t, res.Stale)
+		assert.Equal(t, "old", res.Observed)
+	})
+
+	t.Run("flips back after a fresh read", func(t *testing.T) {
+		p, _ := scriptedProbe("a", true, false, true)
+		res := Run(context.Background(), p, opts)
+		require.NoError(t, res.Err)
+		assert.True(t, res.Stale)
+		assert.Equal(t, 1, res.StaleReads)
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		p, _ := scriptedProbe("a", true)
+		p.Write = func() error { return errors.New("boom") }
+		res := Run(context.Background(), p, opts)
+		assert.ErrorContains(t, res.Err, "boom")
+	})
+
+	t.Run("key pattern", func(t *testing.T) {
+		p, _ := scriptedProbe("a", true)
+		assert.Equal(t, UnknownKeyPattern, Run(context.Background(), p, opts).KeyPattern)
+		p.KeyPattern = "a:{id}"
+		assert.Equal(t, "a:{id}", Run(context.Background(), p, opts).KeyPattern)
+	})
+}
+
+func TestSummarize(t *testing.T) {
+	rep := Summarize([]Result{
+		{Endpoint: "GET /b", Lag: 5 * time.Millisecond},
+		{Endpoint: "GET /a", KeyPattern: "a:{id}", Stale: true, Observed: "old"},
+		{Endpoint: "GET /b", Lag: 9 * time.Millisecond},
+		{Endpoint: "GET /a", KeyPattern: "a:{id}", Err: errors.New("x")},
+	})
+
+	require.Len(t, rep.Endpoints, 2)
+	assert.Equal(t, EndpointStats{Endpoint: "GET /a", KeyPattern: "a:{id}", Probes: 2, Stale: 1, Errors: 1, Example: "old"}, rep.Endpoints[0])
+	assert.Equal(t, EndpointStats{Endpoint: "GET /b", Probes: 2, MaxLag: 9 * time.Millisecond}, rep.Endpoints[1])
+
+	bad := rep.Misbehaving()
+	require.Len(t, bad, 1)
+	assert.Equal(t, "GET /a", bad[0].Endpoint)
+	assert.Contains(t, rep.String(), `example="old"`)
+	assert.Contains(t, rep.String(), "key=a:{id}")
+}
@@ -0,0 +1,182 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+)
+
+// Source is the part of a topic subscription the consumer needs. The Kafka
+// implementation is returned by Subscribe; MemoryBroker provides one for unit
+// tests.
+type Source interface {
+	// ReadMessage blocks until the next message or until ctx is done.
+	ReadMessage(ctx context.Context) (Message, error)
+	Close() error
+}
+
+// Consumer reads a relation-events subscription in the background and keeps
+// every event, so a test can act first and then wait for what it expects.
+// Parallel tests share nothing but filter on their own user IDs.
+type Consumer struct {
+	src Source
+	log *logger.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu        sync.Mutex
+	events    []RelationEvent
+	malformed []malformed
+	err       error
+	changed   chan struct{}
+}
+
+// NewConsumer starts reading src. Close stops it and closes src.
+func NewConsumer(src Source, log *logger.Logger) *Consumer {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Consumer{
+		src:     src,
+		log:     log,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		changed: make(chan struct{}),
+	}
+	go c.run(ctx)
+	return c
+}
+
+func (c *Consumer) run(ctx context.Context) {
+	defer close(c.done)
+
+	for {
+		m, err := c.src.ReadMessage(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				c.log.Error("Failed to read relation event", slog.String("error", err.Error()))
+				c.mu.Lock()
+				c.err = err
+				c.notify()
+				c.mu.Unlock()
+			}
+			return
+		}
+
+		e, err := DecodeRelationEvent(m)
+		c.mu.Lock()
+		if err != nil {
+			c.log.Warn("Malformed relation event", slog.Int64("offset", m.Offset), slog.String("error", err.Error()))
+			c.malformed = append(c.malformed, malformed{msg: m, err: err})
+		} else {
+			c.log.Debug("Relation event",
+				slog.String("type", e.Type),
+				slog.Int64("follower_id", e.FollowerID),
+				slog.Int64("followee_id", e.FolloweeID),
+				slog.Int64("offset", e.Offset),
+			)
+			c.events = append(c.events, e)
+		}
+		c.notify()
+		c.mu.Unlock()
+	}
+}
+
+// notify wakes every waiter. c.mu must be held.
+func (c *Consumer) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// Events returns the events seen so far that match f, in the order they were
+// read.
+func (c *Consumer) Events(f Filter) []RelationEvent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.matching(f)
+}
+
+func (c *Consumer) matching(f Filter) []RelationEvent {
+	var out []RelationEvent
+	for _, e := range c.events {
+		if f.Match(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// malformed is a record that did not decode as a relation event.
+type malformed struct {
+	msg Message
+	err error
+}
+
+// Malformed returns the decode errors of records that were not relation
+// events. With ids it returns only records whose key or value mentions one of
+// them, so a test can check its own events on a topic other tests write to
+// as well.
+func (c *Consumer) Malformed(ids ...int64) []error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var out []error
+	for _, m := range c.malformed {
+		if len(ids) == 0 || mentions(m.msg, ids) {
+			out = append(out, m.err)
+		}
+	}
+	return out
+}
+
+// mentions reports whether any of ids appears as a whole number in the key or
+// value of m.
+func mentions(m Message, ids []int64) bool {
+	for _, id := range ids {
+		re := regexp.MustCompile(`(^|[^0-9])` + strconv.FormatInt(id, 10) + `([^0-9]|$)`)
+		if re.Match(m.Key) || re.Match(m.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// WaitFor blocks until at least n events match f and returns all matching
+// events. It fails when ctx is done first or the subscription broke, and then
+// returns what it has.
+func (c *Consumer) WaitFor(ctx context.Context, f Filter, n int) ([]RelationEvent, error) {
+	for {
+		c.mu.Lock()
+		got, err, changed := c.matching(f), c.err, c.changed
+		c.mu.Unlock()
+
+		if len(got) >= n {
+			return got, nil
+		}
+		if err != nil {
+			return got, fmt.Errorf("relation events subscription: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return got, fmt.Errorf("waiting for %d relation events matching %+v, got %d: %w", n, f, len(got), ctx.Err())
+		case <-changed:
+		}
+	}
+}
+
+// Close stops reading and closes the source.
+func (c *Consumer) Close() error {
+	c.cancel()
+	err := c.src.Close()
+	<-c.done
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,131 @@
+// Package events reads the domain events the services publish to Kafka, so
+// tests can assert on what went over the wire and not only on the API state
+// it eventually produces.
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	TypeFollowCreated = "follow_created"
+	TypeFollowDeleted = "follow_deleted"
+)
+
+// Message is one record read from a topic.
+type Message struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+	Time      time.Time
+}
+
+// RelationEvent is a decoded relation-events record. Payload keeps the raw
+// message value for assertions on its exact shape.
+type RelationEvent struct {
+	Type       string
+	FollowerID int64
+	FolloweeID int64
+
+	Partition int
+	Offset    int64
+	Time      time.Time
+	Payload   json.RawMessage
+}
+
+// relationWire accepts the layouts the relation service has used: IDs at the
+// top level, or nested under "payload", either as an object or as a JSON
+// string written by the outbox. The type may also come from a header.
+type relationWire struct {
+	Type       string          `json:"type"`
+	EventType  string          `json:"event_type"`
+	FollowerID json.Number     `json:"follower_id"`
+	FolloweeID json.Number     `json:"followee_id"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+var errNotRelationEvent = errors.New("not a relation event")
+
+// DecodeRelationEvent decodes m. It fails if the type or either ID is missing.
+func DecodeRelationEvent(m Message) (RelationEvent, error) {
+	e := RelationEvent{
+		Partition: m.Partition,
+		Offset:    m.Offset,
+		Time:      m.Time,
+		Payload:   json.RawMessage(m.Value),
+	}
+
+	var w relationWire
+	if err := json.Unmarshal(m.Value, &w); err != nil {
+		return e, fmt.Errorf("decode relation event at offset %d: %w", m.Offset, err)
+	}
+
+	e.Type = firstNonEmpty(w.Type, w.EventType, m.Headers["event_type"], m.Headers["type"])
+
+	if w.FollowerID == "" && len(w.Payload) > 0 {
+		payload := []byte(w.Payload)
+		var s string
+		if json.Unmarshal(payload, &s) == nil {
+			payload = []byte(s)
+		}
+		var inner relationWire
+		if err := json.Unmarshal(payload, &inner); err != nil {
+			return e, fmt.Errorf("decode relation event payload at offset %d: %w", m.Offset, err)
+		}
+		w.FollowerID, w.FolloweeID = inner.FollowerID, inner.FolloweeID
+		e.Type = firstNonEmpty(e.Type, inner.Type, inner.EventType)
+	}
+
+	var err error
+	if e.FollowerID, err = parseID(w.FollowerID); err != nil {
+		return e, fmt.Errorf("follower_id at offset %d: %w", m.Offset, err)
+	}
+	if e.FolloweeID, err = parseID(w.FolloweeID); err != nil {
+		return e, fmt.Errorf("followee_id at offset %d: %w", m.Offset, err)
+	}
+	if e.Type == "" {
+		return e, fmt.Errorf("offset %d: %w: no event type", m.Offset, errNotRelationEvent)
+	}
+	return e, nil
+}
+
+func parseID(n json.Number) (int64, error) {
+	if n == "" {
+		return 0, fmt.Errorf("%w: missing", errNotRelationEvent)
+	}
+	return strconv.ParseInt(n.String(), 10, 64)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Filter selects relation events. Zero fields match anything.
+type Filter struct {
+	Type       string
+	FollowerID int64
+	FolloweeID int64
+}
+
+func (f Filter) Match(e RelationEvent) bool {
+	return (f.Type == "" || f.Type == e.Type) &&
+		(f.FollowerID == 0 || f.FollowerID == e.FollowerID) &&
+		(f.FolloweeID == 0 || f.FolloweeID == e.FolloweeID)
+}
+
+// Between matches events about the follow of followee by follower.
+func Between(follower, followee int64) Filter {
+	return Filter{FollowerID: follower, FolloweeID: followee}
+}
@@ -0,0 +1,125 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const topic = "relation-events"
+
+func TestDecodeRelationEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		headers map[string]string
+		want    RelationEvent
+		wantErr bool
+	}{
+		{
+			name:  "flat",
+			value: `{"type":"follow_created","follower_id":1,"followee_id":2}`,
+			want:  RelationEvent{Type: TypeFollowCreated, FollowerID: 1, FolloweeID: 2},
+		},
+		{
+			name:  "nested payload",
+			value: `{"event_type":"follow_deleted","payload":{"follower_id":3,"followee_id":4}}`,
+			want:  RelationEvent{Type: TypeFollowDeleted, FollowerID: 3, FolloweeID: 4},
+		},
+		{
+			name:  "payload as string",
+			value: `{"event_type":"follow_created","payload":"{\"follower_id\":5,\"followee_id\":6}"}`,
+			want:  RelationEvent{Type: TypeFollowCreated, FollowerID: 5, FolloweeID: 6},
+		},
+		{
+			name:    "type from header",
+			value:   `{"follower_id":7,"followee_id":8}`,
+			headers: map[string]string{"event_type": TypeFollowCreated},
+			want:    RelationEvent{Type: TypeFollowCreated, FollowerID: 7, FolloweeID: 8},
+		},
+		{name: "not json", value: `nope`, wantErr: true},
+		{name: "missing followee", value: `{"type":"follow_created","follower_id":1}`, wantErr: true},
+		{name: "missing type", value: `{"follower_id":1,"followee_id":2}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeRelationEvent(Message{Value: []byte(tt.value), Headers: tt.headers})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Type, got.Type)
+			assert.Equal(t, tt.want.FollowerID, got.FollowerID)
+			assert.Equal(t, tt.want.FolloweeID, got.FolloweeID)
+			assert.JSONEq(t, tt.value, string(got.Payload))
+		})
+	}
+}
+
+func TestConsumerStartsAtSubscription(t *testing.T) {
+	broker := NewMemoryBroker()
+	broker.Publish(topic, nil, []byte(`{"type":"follow_created","follower_id":1,"followee_id":2}`), nil)
+
+	c := NewConsumer(broker.Subscribe(topic), logger.ForTest(t))
+	defer c.Close()
+
+	broker.Publish(topic, nil, []byte(`{"type":"follow_created","follower_id":1,"followee_id":2}`), nil)
+	broker.Publish(topic, nil, []byte(`{"type":"follow_created","follower_id":9,"followee_id":2}`), nil)
+	broker.Publish(topic, nil, []byte(`garbage`), nil)
+	broker.Publish(topic, []byte("12"), []byte(`{"follower_id":12}`), nil)
+	broker.Publish(topic, nil, []byte(`{"type":"follow_deleted","follower_id":1,"followee_id":2}`), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	got, err := c.WaitFor(ctx, Between(1, 2), 2)
+	require.NoError(t, err)
+	require.Len(t, got, 2, "the record published before subscribing is not seen")
+	assert.Equal(t, TypeFollowCreated, got[0].Type)
+	assert.Equal(t, TypeFollowDeleted, got[1].Type)
+	assert.Less(t, got[0].Offset, got[1].Offset)
+
+	assert.Len(t, c.Events(Filter{Type: TypeFollowCreated, FolloweeID: 2}), 2)
+	assert.Len(t, c.Malformed(), 2)
+	assert.Len(t, c.Malformed(12), 1, "only records that mention the IDs")
+	assert.Empty(t, c.Malformed(1, 2))
+}
+
+func TestConsumerWaitForTimesOut(t *testing.T) {
+	broker := NewMemoryBroker()
+	c := NewConsumer(broker.Subscribe(topic), logger.ForTest(t))
+	defer c.Close()
+
+	broker.Publish(topic, nil, []byte(`{"type":"follow_created","follower_id":1,"followee_id":2}`), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	got, err := c.WaitFor(ctx, Between(1, 2), 2)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Len(t, got, 1)
+}
+
+func TestConsumerWakesWaiter(t *testing.T) {
+	broker := NewMemoryBroker()
+	c := NewConsumer(broker.Subscribe(topic), logger.ForTest(t))
+	defer c.Close()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		broker.Publish(topic, nil, []byte(`{"type":"follow_created","follower_id":3,"followee_id":4}`), nil)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	got, err := c.WaitFor(ctx, Filter{Type: TypeFollowCreated, FollowerID: 3}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), got[0].FolloweeID)
+}
@@ -0,0 +1,164 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+)
+
+// SubscribeRelationEvents starts a consumer on the relation-events topic from
+// its current end, so it sees exactly what happens after the call.
+func SubscribeRelationEvents(ctx context.Context, cfg *config.Config, log *logger.Logger) (*Consumer, error) {
+	src, err := Subscribe(ctx, cfg.Kafka.Brokers, cfg.Kafka.RelationEventsTopic)
+	if err != nil {
+		return nil, err
+	}
+	return NewConsumer(src, log), nil
+}
+
+// Subscribe reads every partition of topic starting at the offsets that are
+// current when it is called. The offsets are resolved before it returns,
+// unlike a consumer group whose start position is only fixed once it joins,
+// which could miss events a test triggers right after subscribing.
+func Subscribe(ctx context.Context, brokers []string, topic string) (Source, error) {
+	if len(brokers) == 0 {
+		return nil, errors.New("kafka: no brokers configured")
+	}
+
+	conn, err := kafka.DialContext(ctx, "tcp", brokers[0])
+	if err != nil {
+		return nil, fmt.Errorf("kafka: dial %s: %w", brokers[0], err)
+	}
+	partitions, err := conn.ReadPartitions(topic)
+	_ = conn.Close()
+	if err != nil {
+		return nil, fmt.Errorf("kafka: read partitions of %s: %w", topic, err)
+	}
+	if len(partitions) == 0 {
+		return nil, fmt.Errorf("kafka: topic %s has no partitions", topic)
+	}
+
+	readCtx, cancel := context.WithCancel(context.Background())
+	s := &kafkaSource{
+		cancel: cancel,
+		msgs:   make(chan Message),
+		errs:   make(chan error, len(partitions)),
+	}
+
+	for _, p := range partitions {
+		offset, err := lastOffset(ctx, brokers[0], topic, p.ID)
+		if err != nil {
+			_ = s.Close()
+			return nil, err
+		}
+
+		r := kafka.NewReader(kafka.ReaderConfig{
+			Brokers:   brokers,
+			Topic:     topic,
+			Partition: p.ID,
+			MinBytes:  1,
+			MaxBytes:  10e6,
+			MaxWait:   100 * time.Millisecond,
+		})
+		if err := r.SetOffset(offset); err != nil {
+			_ = r.Close()
+			_ = s.Close()
+			return nil, fmt.Errorf("kafka: set offset of %s/%d: %w", topic, p.ID, err)
+		}
+		s.readers = append(s.readers, r)
+
+		s.wg.Add(1)
+		go s.pump(readCtx, r)
+	}
+
+	return s, nil
+}
+
+func lastOffset(ctx context.Context, broker, topic string, partition int) (int64, error) {
+	conn, err := kafka.DialLeader(ctx, "tcp", broker, topic, partition)
+	if err != nil {
+		return 0, fmt.Errorf("kafka: dial leader of %s/%d: %w", topic, partition, err)
+	}
+	defer conn.Close()
+
+	offset, err := conn.ReadLastOffset()
+	if err != nil {
+		return 0, fmt.Errorf("kafka: read last offset of %s/%d: %w", topic, partition, err)
+	}
+	return offset, nil
+}
+
+// kafkaSource merges one reader per partition into a single stream. Order is
+// preserved within a partition, which is all Kafka guarantees anyway.
+type kafkaSource struct {
+	readers []*kafka.Reader
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	msgs    chan Message
+	errs    chan error
+	once    sync.Once
+}
+
+func (s *kafkaSource) pump(ctx context.Context, r *kafka.Reader) {
+	defer s.wg.Done()
+	for {
+		km, err := r.ReadMessage(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.errs <- err
+			}
+			return
+		}
+
+		m := Message{
+			Topic:     km.Topic,
+			Partition: km.Partition,
+			Offset:    km.Offset,
+			Key:       km.Key,
+			Value:     km.Value,
+			Time:      km.Time,
+		}
+		if len(km.Headers) > 0 {
+			m.Headers = make(map[string]string, len(km.Headers))
+			for _, h := range km.Headers {
+				m.Headers[h.Key] = string(h.Value)
+			}
+		}
+
+		select {
+		case s.msgs <- m:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *kafkaSource) ReadMessage(ctx context.Context) (Message, error) {
+	select {
+	case m := <-s.msgs:
+		return m, nil
+	case err := <-s.errs:
+		return Message{}, err
+	case <-ctx.Done():
+		return Message{}, ctx.Err()
+	}
+}
+
+func (s *kafkaSource) Close() error {
+	var errs []error
+	s.once.Do(func() {
+		s.cancel()
+		s.wg.Wait()
+		for _, r := range s.readers {
+			errs = append(errs, r.Close())
+		}
+	})
+	return errors.Join(errs...)
+}
@@ -0,0 +1,88 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// MemoryBroker is an in-process stand-in for Kafka: topics are append-only
+// slices and subscriptions start at the end of the topic, like Subscribe.
+type MemoryBroker struct {
+	mu      sync.Mutex
+	topics  map[string][]Message
+	changed chan struct{}
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		topics:  make(map[string][]Message),
+		changed: make(chan struct{}),
+	}
+}
+
+// Publish appends a record to topic and returns its offset.
+func (b *MemoryBroker) Publish(topic string, key, value []byte, headers map[string]string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	offset := int64(len(b.topics[topic]))
+	b.topics[topic] = append(b.topics[topic], Message{
+		Topic:   topic,
+		Offset:  offset,
+		Key:     key,
+		Value:   value,
+		Headers: headers,
+		Time:    time.Now(),
+	})
+
+	close(b.changed)
+	b.changed = make(chan struct{})
+	return offset
+}
+
+// Subscribe returns a source that reads records published to topic from now
+// on.
+func (b *MemoryBroker) Subscribe(topic string) Source {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return &memorySource{broker: b, topic: topic, next: len(b.topics[topic]), closed: make(chan struct{})}
+}
+
+type memorySource struct {
+	broker    *MemoryBroker
+	topic     string
+	next      int
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+var errSourceClosed = errors.New("source closed")
+
+func (s *memorySource) ReadMessage(ctx context.Context) (Message, error) {
+	for {
+		s.broker.mu.Lock()
+		msgs, changed := s.broker.topics[s.topic], s.broker.changed
+		s.broker.mu.Unlock()
+
+		if s.next < len(msgs) {
+			m := msgs[s.next]
+			s.next++
+			return m, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return Message{}, ctx.Err()
+		case <-s.closed:
+			return Message{}, errSourceClosed
+		case <-changed:
+		}
+	}
+}
+
+func (s *memorySource) Close() error {
+	s.closeOnce.Do(func() { close(s.closed) })
+	return nil
+}
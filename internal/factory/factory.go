@@ -0,0 +1,249 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+)
+
+// Factory creates real entities through the gateway and deletes them when the
+// test finishes. Every user it creates gets its own set of clients, so a
+// factory can be used from parallel subtests. Factory methods never change
+// the context of a user's clients: requests go through clients bound to the
+// ctx passed in, and cleanups keep its values but not its cancellation.
+type Factory struct {
+	t   testing.TB
+	cfg *config.Config
+	log *logger.Logger
+	gen *fixtures.Generator
+
+	senderOnce sync.Once
+	sender     *User
+	senderErr  error
+}
+
+// New returns a factory for t. A nil log or gen is replaced with the per-test
+// logger and generator.
+func New(t testing.TB, cfg *config.Config, log *logger.Logger, gen *fixtures.Generator) *Factory {
+	t.Helper()
+
+	if log == nil {
+		log = logger.ForTest(t)
+	}
+	if gen == nil {
+		gen = fixtures.ForTest(t)
+	}
+
+	return &Factory{
+		t:   t,
+		cfg: cfg,
+		log: log,
+		gen: gen,
+	}
+}
+
+// Generator returns the generator the factory draws request data from.
+func (f *Factory) Generator() *fixtures.Generator {
+	return f.gen
+}
+
+// User is a registered user together with its credentials and clients
+// authenticated as that user.
+type User struct {
+	fixtures.User
+	Password     string
+	AccessToken  string
+	RefreshToken string
+
+	API           *client.Client
+	Auth          *client.AuthClient
+	Users         *client.UserClient
+	Posts         *client.PostClient
+	Relations     *client.RelationClient
+	Notifications *client.NotificationClient
+}
+
+// Attach wraps an existing account in a User with its own clients, for
+// callers that created the account outside a factory, such as a seeded
+// dataset. The clients authenticate with accessToken.
+func Attach(ctx context.Context, cfg *config.Config, log *logger.Logger, profile fixtures.User, password, accessToken, refreshToken string) *User {
+	u := newClients(ctx, cfg, log)
+	u.User = profile
+	u.Password = password
+	u.AccessToken = accessToken
+	u.RefreshToken = refreshToken
+	u.API.SetToken(accessToken)
+	return u
+}
+
+func (f *Factory) newClients(ctx context.Context) *User {
+	return newClients(ctx, f.cfg, f.log)
+}
+
+// as returns clients authenticated as u that make requests with ctx, leaving
+// u's own clients alone.
+func (f *Factory) as(ctx context.Context, u *User) *User {
+	c := f.newClients(ctx)
+	c.User = u.User
+	c.API.SetToken(u.API.GetToken())
+	return c
+}
+
+// detached is as with ctx stripped of its cancellation, for cleanups that run
+// after the caller's context is done.
+func (f *Factory) detached(ctx context.Context, u *User) *User {
+	return f.as(context.WithoutCancel(ctx), u)
+}
+
+func newClients(ctx context.Context, cfg *config.Config, log *logger.Logger) *User {
+	api := client.NewClient(cfg, log)
+	api.SetContext(ctx)
+	return &User{
+		API:           api,
+		Auth:          client.NewAuthClient(api),
+		Users:         client.NewUserClient(api),
+		Posts:         client.NewPostClient(api),
+		Relations:     client.NewRelationClient(api),
+		Notifications: client.NewNotificationClient(api),
+	}
+}
+
+// User registers a new user with generated data.
+func (f *Factory) User(ctx context.Context) (*User, error) {
+	return f.UserFrom(ctx, f.gen.NewRegister())
+}
+
+// UserFrom registers a user from a builder, for tests that need specific
+// profile data.
+func (f *Factory) UserFrom(ctx context.Context, b *fixtures.RegisterBuilder) (*User, error) {
+	req := b.Build()
+	u := f.newClients(ctx)
+
+	tokens, err := u.Auth.Register(*req)
+	if err != nil {
+		return nil, fmt.Errorf("register %s: %w", req.Username, err)
+	}
+
+	profile, err := u.Users.GetUserByUsername(req.Username)
+	if err != nil {
+		return nil, fmt.Errorf("get registered user %s: %w", req.Username, err)
+	}
+
+	u.User = *profile
+	u.Password = req.Password
+	u.AccessToken = tokens.AccessToken
+	u.RefreshToken = tokens.RefreshToken
+	u.API.SetToken(tokens.AccessToken)
+
+	f.cleanup("user", func() error {
+		return f.detached(ctx, u).Users.DeleteUser(u.ID)
+	}, slog.Int64("user_id", u.ID), slog.String("username", u.Username))
+
+	return u, nil
+}
+
+// Users registers n users.
+func (f *Factory) Users(ctx context.Context, n int) ([]*User, error) {
+	users := make([]*User, 0, n)
+	for i := 0; i < n; i++ {
+		u, err := f.User(ctx)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+// PostBy creates n posts authored by user.
+func (f *Factory) PostBy(ctx context.Context, user *User, n int) ([]*fixtures.CreatePostResponse, error) {
+	posts := make([]*fixtures.CreatePostResponse, 0, n)
+	for i := 0; i < n; i++ {
+		post, err := f.PostFrom(ctx, user, f.gen.NewPost())
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, post)
+	}
+	return posts, nil
+}
+
+// PostFrom creates one post by user from a builder.
+func (f *Factory) PostFrom(ctx context.Context, user *User, b *fixtures.PostBuilder) (*fixtures.CreatePostResponse, error) {
+	post, err := f.as(ctx, user).Posts.CreatePost(*b.Build())
+	if err != nil {
+		return nil, fmt.Errorf("create post by user %d: %w", user.ID, err)
+	}
+
+	f.cleanup("post", func() error {
+		return f.detached(ctx, user).Posts.DeletePost(post.ID)
+	}, slog.Int64("post_id", post.ID), slog.Int64("author_id", user.ID))
+
+	return post, nil
+}
+
+// Follow makes follower follow followee.
+func (f *Factory) Follow(ctx context.Context, follower, followee *User) error {
+	if _, err := f.as(ctx, follower).Relations.Follow(followee.ID); err != nil {
+		return fmt.Errorf("user %d follow %d: %w", follower.ID, followee.ID, err)
+	}
+
+	f.cleanup("relation", func() error {
+		_, err := f.detached(ctx, follower).Relations.Unfollow(followee.ID)
+		return err
+	}, slog.Int64("follower_id", follower.ID), slog.Int64("followee_id", followee.ID))
+
+	return nil
+}
+
+// Notification sends a notification of the given type to user. It is sent by
+// a sender user the factory creates on first use.
+func (f *Factory) Notification(ctx context.Context, to *User, notificationType string) (*fixtures.SendNotificationResponse, error) {
+	sender, err := f.notificationSender(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := f.gen.NewNotification(to.ID).WithType(notificationType).Build()
+	resp, err := f.as(ctx, sender).Notifications.SendNotification(*req)
+	if err != nil {
+		return nil, fmt.Errorf("send %s notification to user %d: %w", notificationType, to.ID, err)
+	}
+
+	f.cleanup("notification", func() error {
+		_, err := f.detached(ctx, to).Notifications.RemoveNotification(resp.NotificationID)
+		return err
+	}, slog.Int64("notification_id", resp.NotificationID), slog.Int64("user_id", to.ID))
+
+	return resp, nil
+}
+
+func (f *Factory) notificationSender(ctx context.Context) (*User, error) {
+	f.senderOnce.Do(func() {
+		f.sender, f.senderErr = f.User(ctx)
+	})
+	return f.sender, f.senderErr
+}
+
+// cleanup registers deletion of an entity with t.Cleanup, so entities are
+// removed in reverse creation order: notifications and relations before the
+// users they belong to. Failures are logged, not reported as test failures.
+func (f *Factory) cleanup(kind string, del func() error, attrs ...any) {
+	if !f.cfg.Test.Cleanup {
+		return
+	}
+
+	f.t.Cleanup(func() {
+		if err := del(); err != nil {
+			f.log.Warn("Failed to delete "+kind+" during cleanup", append(attrs, slog.String("error", err.Error()))...)
+			return
+		}
+		f.log.Debug("Deleted "+kind, attrs...)
+	})
+}
@@ -0,0 +1,146 @@
+package factory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGateway answers the handful of endpoints the factory calls and records
+// the order of requests.
+type fakeGateway struct {
+	mu     sync.Mutex
+	calls  []string
+	nextID int64
+	users  map[string]int64
+}
+
+func (g *fakeGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/api")
+	g.calls = append(g.calls, r.Method+" "+path)
+	g.nextID++
+
+	write := func(data interface{}) {
+		_ = json.NewEncoder(w).Encode(fixtures.BaseResponse{Status: http.StatusOK, Data: data})
+	}
+
+	switch {
+	case r.Method == http.MethodPost && path == "/v1/auth/register":
+		var req fixtures.RegisterRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		g.users[req.Username] = g.nextID
+		write(fixtures.RegisterResponse{AccessToken: "a", RefreshToken: "r"})
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/v1/users/username/"):
+		name := strings.TrimPrefix(path, "/v1/users/username/")
+		write(fixtures.User{ID: g.users[name], Username: name})
+	case r.Method == http.MethodPost && path == "/v1/posts":
+		write(fixtures.CreatePostResponse{ID: g.nextID})
+	case r.Method == http.MethodPost && path == "/v1/notification/send":
+		write(fixtures.SendNotificationResponse{NotificationID: g.nextID})
+	default:
+		write(map[string]string{"message": "ok"})
+	}
+}
+
+func (g *fakeGateway) callsWithPrefix(prefix string) []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var out []string
+	for _, c := range g.calls {
+		if strings.HasPrefix(c, prefix) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func TestFactoryCreatesAndCleansUp(t *testing.T) {
+	gw := &fakeGateway{users: map[string]int64{}}
+	srv := httptest.NewServer(gw)
+	defer srv.Close()
+
+	cfg := &config.Config{
+		API:  config.API{BaseURL: srv.URL + "/api", Timeout: time.Second},
+		Test: config.Test{Cleanup: true},
+	}
+
+	t.Run("create", func(t *testing.T) {
+		f := New(t, cfg, nil, nil)
+		ctx := context.Background()
+
+		alice, err := f.User(ctx)
+		require.NoError(t, err)
+		bob, err := f.User(ctx)
+		require.NoError(t, err)
+		assert.NotZero(t, alice.ID)
+		assert.Equal(t, "a", alice.AccessToken)
+
+		posts, err := f.PostBy(ctx, alice, 2)
+		require.NoError(t, err)
+		assert.Len(t, posts, 2)
+
+		require.NoError(t, f.Follow(ctx, bob, alice))
+
+		n, err := f.Notification(ctx, alice, fixtures.NotificationTypeSystem)
+		require.NoError(t, err)
+		assert.NotZero(t, n.NotificationID)
+	})
+
+	deletes := gw.callsWithPrefix("DELETE ")
+	unfollows := gw.callsWithPrefix("POST /v1/relation/unfollow")
+	require.Len(t, unfollows, 1)
+	require.Len(t, deletes, 6, "notification, 2 posts and 3 users (incl. sender)")
+
+	assert.True(t, strings.HasPrefix(deletes[0], "DELETE /v1/notification/"))
+	assert.True(t, strings.HasPrefix(deletes[1], "DELETE /v1/users/"), "sender is deleted right after its notification")
+	assert.True(t, strings.HasPrefix(deletes[2], "DELETE /v1/posts/"))
+	assert.True(t, strings.HasPrefix(deletes[3], "DELETE /v1/posts/"))
+	assert.True(t, strings.HasPrefix(deletes[5], "DELETE /v1/users/"))
+}
+
+func TestFactoryCleansUpAfterCallerContextIsCancelled(t *testing.T) {
+	gw := &fakeGateway{users: map[string]int64{}}
+	srv := httptest.NewServer(gw)
+	defer srv.Close()
+
+	cfg := &config.Config{
+		API:  config.API{BaseURL: srv.URL + "/api", Timeout: time.Second},
+		Test: config.Test{Cleanup: true},
+	}
+
+	t.Run("create", func(t *testing.T) {
+		f := New(t, cfg, nil, nil)
+		ctx := context.Background()
+
+		alice, err := f.User(ctx)
+		require.NoError(t, err)
+		bob, err := f.User(ctx)
+		require.NoError(t, err)
+
+		short, cancel := context.WithCancel(ctx)
+		require.NoError(t, f.Follow(short, bob, alice))
+		_, err = f.PostBy(short, alice, 1)
+		require.NoError(t, err)
+		cancel()
+
+		assert.Equal(t, ctx, bob.API.Context(), "Follow must not change the follower's context")
+		assert.Equal(t, ctx, alice.API.Context(), "PostBy must not change the author's context")
+	})
+
+	assert.Len(t, gw.callsWithPrefix("POST /v1/relation/unfollow"), 1)
+	assert.Len(t, gw.callsWithPrefix("DELETE /v1/posts/"), 1)
+	assert.Len(t, gw.callsWithPrefix("DELETE /v1/users/"), 2)
+}
@@ -0,0 +1,385 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Boundary case kinds.
+const (
+	BoundaryEmpty      = "empty"
+	BoundaryBelowMin   = "min-1"
+	BoundaryMin        = "min"
+	BoundaryMax        = "max"
+	BoundaryAboveMax   = "max+1"
+	BoundaryNegative   = "negative"
+	BoundaryUnicode    = "unicode"
+	BoundaryWhitespace = "whitespace"
+	BoundaryBadFormat  = "bad-format"
+	BoundaryOneOf      = "oneof"
+	BoundaryNotOneOf   = "not-oneof"
+	BoundaryWrongType  = "wrong-type"
+)
+
+const (
+	emailDomain      = "@example.com"
+	urlPrefix        = "https://example.com/"
+	unicodeLetters   = "äöüßжфыλπ名前"
+	defaultStringLen = 8
+
+	// markedSuffixLength is how many random characters a generated string
+	// needs after the run marker to be unique within the run.
+	markedSuffixLength = 6
+)
+
+// BoundaryCase is one request body in which a single field of a valid base
+// request was replaced by a boundary value.
+type BoundaryCase struct {
+	// Name is "<json field>/<kind>", usable as a subtest name.
+	Name  string
+	Field FieldConstraint
+	Kind  string
+	// Value is the value written into the field.
+	Value interface{}
+	// Valid is the outcome expected from the declared constraints: true if
+	// the gateway should accept the request.
+	Valid bool
+	// Body is the complete request body as raw JSON, built on the base the
+	// cases were generated from.
+	Body json.RawMessage
+}
+
+// BoundaryCases yields boundary and equivalence-class cases for every
+// constrained field of base, which should be a valid request such as one
+// returned by a builder. Fields of other requests are left untouched, so each
+// case isolates a single field.
+//
+// Every Body shares the other fields of base, so cases sent to the gateway
+// together collide on unique fields such as username and email. Tests that
+// create entities send Apply(fresh base) instead.
+//
+// Plain string values long enough to hold the run marker start with it, so
+// accepted values are unique and what they create can be found by the
+// sweeper. Shorter ones, such as a minimum-length username, and whitespace
+// cannot carry it and may already be taken by an earlier run: a test that
+// creates entities from them should count a uniqueness conflict as
+// acceptance, since the value got past validation.
+func BoundaryCases(base interface{}) []BoundaryCase {
+	return defaultGenerator.BoundaryCases(base)
+}
+
+func (g *Generator) BoundaryCases(base interface{}) []BoundaryCase {
+	raw, err := json.Marshal(base)
+	if err != nil {
+		panic("fixtures: marshal boundary base: " + err.Error())
+	}
+
+	var cases []BoundaryCase
+	for _, c := range Constraints(base) {
+		for _, v := range g.boundaryValues(c, base) {
+			valid := false
+			if v.kind != BoundaryWrongType {
+				valid = Accepts(c, v.value)
+			}
+
+			cases = append(cases, BoundaryCase{
+				Name:  c.JSONName + "/" + v.kind,
+				Field: c,
+				Kind:  v.kind,
+				Value: v.value,
+				Valid: valid,
+				Body:  withField(raw, c.JSONName, v.value),
+			})
+		}
+	}
+	return cases
+}
+
+// Apply returns base as a request body with the case's field set to its
+// boundary value. base should be a fresh valid request of the type the cases
+// were generated from, so that the case's other fields are its own.
+func (bc BoundaryCase) Apply(base interface{}) json.RawMessage {
+	raw, err := json.Marshal(base)
+	if err != nil {
+		panic("fixtures: marshal boundary base: " + err.Error())
+	}
+	return withField(raw, bc.Field.JSONName, bc.Value)
+}
+
+func withField(raw json.RawMessage, key string, value interface{}) json.RawMessage {
+	var body map[string]interface{}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		panic("fixtures: unmarshal boundary case: " + err.Error())
+	}
+	body[key] = value
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		panic("fixtures: marshal boundary case: " + err.Error())
+	}
+	return data
+}
+
+type boundaryValue struct {
+	kind  string
+	value interface{}
+}
+
+func (g *Generator) boundaryValues(c FieldConstraint, base interface{}) []boundaryValue {
+	switch c.Kind {
+	case reflect.String:
+		return g.stringBoundaries(c)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return intBoundaries(c)
+	case reflect.Slice:
+		return g.sliceBoundaries(c, base)
+	default:
+		return nil
+	}
+}
+
+func (g *Generator) stringBoundaries(c FieldConstraint) []boundaryValue {
+	values := []boundaryValue{{BoundaryEmpty, ""}}
+	add := func(kind string, n int) {
+		if s, ok := g.stringOfLength(c, n); ok {
+			values = append(values, boundaryValue{kind, s})
+		}
+	}
+
+	if c.HasMin() && c.Min-1 > 0 {
+		add(BoundaryBelowMin, c.Min-1)
+	}
+	if c.HasMin() && c.Min > 0 {
+		add(BoundaryMin, c.Min)
+	}
+	if c.HasMax() {
+		add(BoundaryMax, c.Max)
+		add(BoundaryAboveMax, c.Max+1)
+	}
+
+	// Long enough for the run marker and defaultStringLen characters after
+	// it, within the declared limits.
+	n := len(UsernamePrefix(g.runID)) + defaultStringLen
+	if c.HasMin() && n < c.Min {
+		n = c.Min
+	}
+	if c.HasMax() && n > c.Max {
+		n = c.Max
+	}
+	// Whitespace is checked against the declared rules as is: nothing
+	// says the gateway trims it first.
+	values = append(values,
+		boundaryValue{BoundaryUnicode, g.marked(n, g.unicodeString)},
+		boundaryValue{BoundaryWhitespace, strings.Repeat(" ", n)},
+	)
+
+	switch c.Format {
+	case FormatEmail:
+		values = append(values, boundaryValue{BoundaryBadFormat, "not-an-email"})
+	case FormatURL:
+		values = append(values, boundaryValue{BoundaryBadFormat, "not-a-url"})
+	}
+	for _, option := range c.OneOf {
+		values = append(values, boundaryValue{BoundaryOneOf + "=" + option, option})
+	}
+	if len(c.OneOf) > 0 {
+		values = append(values, boundaryValue{BoundaryNotOneOf, "invalid_" + g.String(defaultStringLen)})
+	}
+
+	return append(values, boundaryValue{BoundaryWrongType, 12345})
+}
+
+func intBoundaries(c FieldConstraint) []boundaryValue {
+	values := []boundaryValue{
+		{BoundaryEmpty, 0},
+		{BoundaryNegative, -1},
+	}
+	if c.HasMin() && c.Min-1 != 0 && c.Min-1 != -1 {
+		values = append(values, boundaryValue{BoundaryBelowMin, c.Min - 1})
+	}
+	if c.HasMin() && c.Min != 0 {
+		values = append(values, boundaryValue{BoundaryMin, c.Min})
+	}
+	if c.HasMax() {
+		values = append(values,
+			boundaryValue{BoundaryMax, c.Max},
+			boundaryValue{BoundaryAboveMax, c.Max + 1},
+		)
+	}
+	return append(values, boundaryValue{BoundaryWrongType, "not-a-number"})
+}
+
+func (g *Generator) sliceBoundaries(c FieldConstraint, base interface{}) []boundaryValue {
+	values := []boundaryValue{{BoundaryEmpty, []interface{}{}}}
+	add := func(kind string, n int) {
+		if items, ok := g.sliceOfLength(base, c, n); ok {
+			values = append(values, boundaryValue{kind, items})
+		}
+	}
+
+	if c.HasMin() && c.Min-1 > 0 {
+		add(BoundaryBelowMin, c.Min-1)
+	}
+	if c.HasMin() && c.Min > 0 {
+		add(BoundaryMin, c.Min)
+	}
+	if c.HasMax() {
+		add(BoundaryMax, c.Max)
+		add(BoundaryAboveMax, c.Max+1)
+	}
+	return append(values, boundaryValue{BoundaryWrongType, "not-an-array"})
+}
+
+// stringOfLength returns a string of n characters that satisfies the format
+// of c, or false if the format cannot be met at that length.
+func (g *Generator) stringOfLength(c FieldConstraint, n int) (string, bool) {
+	switch {
+	case c.Format == FormatEmail:
+		if n <= len(emailDomain) {
+			return "", false
+		}
+		return g.String(n-len(emailDomain)) + emailDomain, true
+	case c.Format == FormatURL:
+		if n <= len(urlPrefix) {
+			return "", false
+		}
+		return urlPrefix + g.String(n-len(urlPrefix)), true
+	case len(c.OneOf) > 0:
+		return "", false
+	default:
+		return g.marked(n, g.String), true
+	}
+}
+
+// marked returns n characters from fill, starting with the run marker when
+// that leaves room for markedSuffixLength characters after it.
+func (g *Generator) marked(n int, fill func(int) string) string {
+	prefix := UsernamePrefix(g.runID)
+	if n-len(prefix) < markedSuffixLength {
+		return fill(n)
+	}
+	return prefix + fill(n-len(prefix))
+}
+
+func (g *Generator) unicodeString(n int) string {
+	letters := []rune(unicodeLetters)
+	runes := make([]rune, n)
+	for i := range runes {
+		runes[i] = letters[g.intn(len(letters))]
+	}
+	return string(runes)
+}
+
+// sliceOfLength builds n valid elements for the slice field of base.
+func (g *Generator) sliceOfLength(base interface{}, c FieldConstraint, n int) ([]interface{}, bool) {
+	t := reflect.TypeOf(base)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	f, ok := t.FieldByName(c.Field)
+	if !ok {
+		return nil, false
+	}
+
+	items := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		switch f.Type.Elem() {
+		case reflect.TypeOf(""):
+			items = append(items, g.GenerateTag().Name)
+		case reflect.TypeOf(MediaItemInput{}):
+			item := g.GenerateMediaItemInput()
+			item.Position = i + 1
+			items = append(items, item)
+		default:
+			return nil, false
+		}
+	}
+	return items, true
+}
+
+var emailRe = regexp.MustCompile(`^[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}$`)
+
+// Accepts reports whether value satisfies c.
+func Accepts(c FieldConstraint, value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return acceptsString(c, v)
+	case int:
+		return acceptsInt(c, int64(v))
+	case int64:
+		return acceptsInt(c, v)
+	case []interface{}:
+		return acceptsLen(c, len(v))
+	default:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() == reflect.Slice {
+			return acceptsLen(c, rv.Len())
+		}
+		return false
+	}
+}
+
+func acceptsString(c FieldConstraint, s string) bool {
+	if s == "" {
+		return !c.Required
+	}
+	if c.Kind != reflect.String {
+		return false
+	}
+
+	n := utf8.RuneCountInString(s)
+	if c.HasMin() && n < c.Min || c.HasMax() && n > c.Max {
+		return false
+	}
+
+	switch c.Format {
+	case FormatEmail:
+		if !emailRe.MatchString(s) {
+			return false
+		}
+	case FormatURL:
+		u, err := url.Parse(s)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || n != len(s) {
+			return false
+		}
+	}
+
+	if len(c.OneOf) > 0 {
+		for _, option := range c.OneOf {
+			if s == option {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func acceptsInt(c FieldConstraint, n int64) bool {
+	switch c.Kind {
+	case reflect.Int, reflect.Int32, reflect.Int64:
+	default:
+		return false
+	}
+	if n == 0 && c.Required {
+		return false
+	}
+	if n == 0 && !c.Required {
+		return true
+	}
+	return (!c.HasMin() || n >= int64(c.Min)) && (!c.HasMax() || n <= int64(c.Max))
+}
+
+func acceptsLen(c FieldConstraint, n int) bool {
+	if c.Kind != reflect.Slice {
+		return false
+	}
+	if n == 0 {
+		return !c.Required
+	}
+	return (!c.HasMin() || n >= c.Min) && (!c.HasMax() || n <= c.Max)
+}
@@ -0,0 +1,95 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func casesByName(cases []BoundaryCase) map[string]BoundaryCase {
+	byName := make(map[string]BoundaryCase, len(cases))
+	for _, c := range cases {
+		byName[c.Name] = c
+	}
+	return byName
+}
+
+func TestBoundaryCasesForRegister(t *testing.T) {
+	g := NewGenerator(3)
+	base := g.NewRegister().Build()
+	cases := casesByName(g.BoundaryCases(base))
+
+	expected := map[string]bool{
+		"username/empty":        false,
+		"username/min-1":        false,
+		"username/min":          true,
+		"username/unicode":      true,
+		"username/whitespace":   true,
+		"email/whitespace":      false,
+		"username/wrong-type":   false,
+		"email/bad-format":      false,
+		"password/min-1":        false,
+		"password/min":          true,
+		"avatar_url/empty":      true,
+		"avatar_url/bad-format": false,
+	}
+	for name, valid := range expected {
+		c, ok := cases[name]
+		if assert.True(t, ok, "missing case %s", name) {
+			assert.Equal(t, valid, c.Valid, name)
+		}
+	}
+
+	minUser := cases["username/min"]
+	assert.Equal(t, UsernameMinLength, utf8.RuneCountInString(minUser.Value.(string)))
+	assert.NotContains(t, cases, "username/max", "no max is declared, so none is tested")
+
+	unicodeUser := cases["username/unicode"].Value.(string)
+	runID, ok := RunIDOf(User{Username: unicodeUser})
+	assert.True(t, ok && runID == g.RunID(), "accepted values that fit the marker carry it: %q", unicodeUser)
+	assert.NotEqual(t, len(unicodeUser), utf8.RuneCountInString(unicodeUser), "unicode case has non-ASCII letters")
+	assert.Empty(t, strings.TrimSpace(cases["username/whitespace"].Value.(string)))
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(cases["username/wrong-type"].Body, &body))
+	assert.EqualValues(t, 12345, body["username"])
+	assert.Equal(t, base.Email, body["email"], "other fields keep their valid values")
+}
+
+func TestBoundaryCasesForPost(t *testing.T) {
+	g := NewGenerator(3)
+	cases := casesByName(g.BoundaryCases(g.NewPost().Build()))
+
+	assert.True(t, cases["title/max"].Valid)
+	assert.False(t, cases["title/max+1"].Valid)
+	assert.Len(t, cases["title/max+1"].Value, PostTitleMaxLength+1)
+	assert.False(t, cases["title/empty"].Valid)
+	assert.NotContains(t, cases, "media_items/empty", "undeclared fields get no cases")
+}
+
+func TestBoundaryCasesForOneOf(t *testing.T) {
+	cases := casesByName(NewGenerator(3).BoundaryCases(NewNotification(1).Build()))
+
+	for _, notificationType := range NotificationTypes {
+		assert.True(t, cases["type/oneof="+notificationType].Valid)
+	}
+	assert.False(t, cases["type/not-oneof"].Valid)
+	assert.False(t, cases["user_id/negative"].Valid)
+	assert.True(t, cases["user_id/min"].Valid)
+}
+
+func TestBoundaryCaseApply(t *testing.T) {
+	g := NewGenerator(3)
+	cases := casesByName(g.BoundaryCases(g.NewRegister().Build()))
+
+	fresh := g.NewRegister().Build()
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(cases["password/min"].Apply(fresh), &body))
+	assert.Equal(t, cases["password/min"].Value, body["password"])
+	assert.Equal(t, fresh.Username, body["username"], "other fields come from the fresh base")
+	assert.Equal(t, fresh.Email, body["email"])
+}
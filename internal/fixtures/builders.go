@@ -0,0 +1,341 @@
+package fixtures
+
+import "strings"
+
+// Builders start from valid generated data and expose one named mutation per
+// field, so a validation test states only what it breaks:
+//
+//	req := fixtures.NewPost().WithTags(0).WithMedia(fixtures.MediaTypeVideo, 5).WithTitle("").Build()
+//
+// The package-level constructors use the shared generator; the Generator
+// methods of the same name give reproducible per-test data.
+
+const lowercaseLetters = "abcdefghijklmnopqrstuvwxyz"
+
+// String returns a random lowercase string of exactly n letters.
+func (g *Generator) String(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(n)
+	for i := 0; i < n; i++ {
+		sb.WriteByte(lowercaseLetters[g.intn(len(lowercaseLetters))])
+	}
+	return sb.String()
+}
+
+// Password returns a random password of exactly n characters.
+func (g *Generator) Password(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return g.faker.Password(true, true, true, false, false, n)
+}
+
+// ========= Auth Builders =========
+
+type RegisterBuilder struct {
+	g   *Generator
+	req RegisterRequest
+}
+
+func NewRegister() *RegisterBuilder {
+	return defaultGenerator.NewRegister()
+}
+
+func (g *Generator) NewRegister() *RegisterBuilder {
+	return &RegisterBuilder{g: g, req: *g.GenerateRegisterRequest()}
+}
+
+func (b *RegisterBuilder) WithUsername(username string) *RegisterBuilder {
+	b.req.Username = username
+	return b
+}
+
+func (b *RegisterBuilder) WithUsernameLength(n int) *RegisterBuilder {
+	b.req.Username = b.g.String(n)
+	return b
+}
+
+func (b *RegisterBuilder) WithEmail(email string) *RegisterBuilder {
+	b.req.Email = email
+	return b
+}
+
+func (b *RegisterBuilder) WithPassword(password string) *RegisterBuilder {
+	b.req.Password = password
+	return b
+}
+
+func (b *RegisterBuilder) WithPasswordLength(n int) *RegisterBuilder {
+	b.req.Password = b.g.Password(n)
+	return b
+}
+
+func (b *RegisterBuilder) WithFullName(fullName string) *RegisterBuilder {
+	b.req.FullName = fullName
+	return b
+}
+
+func (b *RegisterBuilder) WithBio(bio string) *RegisterBuilder {
+	b.req.Bio = bio
+	return b
+}
+
+func (b *RegisterBuilder) WithAvatarURL(avatarURL string) *RegisterBuilder {
+	b.req.AvatarURL = avatarURL
+	return b
+}
+
+func (b *RegisterBuilder) Constraints() []FieldConstraint {
+	return Constraints(b.req)
+}
+
+func (b *RegisterBuilder) Build() *RegisterRequest {
+	req := b.req
+	return &req
+}
+
+// ========= User Builders =========
+
+type CreateUserBuilder struct {
+	g   *Generator
+	req CreateUserRequest
+}
+
+func NewCreateUser() *CreateUserBuilder {
+	return defaultGenerator.NewCreateUser()
+}
+
+func (g *Generator) NewCreateUser() *CreateUserBuilder {
+	return &CreateUserBuilder{g: g, req: *g.GenerateCreateUserRequest()}
+}
+
+func (b *CreateUserBuilder) WithUsername(username string) *CreateUserBuilder {
+	b.req.Username = username
+	return b
+}
+
+func (b *CreateUserBuilder) WithUsernameLength(n int) *CreateUserBuilder {
+	b.req.Username = b.g.String(n)
+	return b
+}
+
+func (b *CreateUserBuilder) WithEmail(email string) *CreateUserBuilder {
+	b.req.Email = email
+	return b
+}
+
+func (b *CreateUserBuilder) WithPassword(password string) *CreateUserBuilder {
+	b.req.Password = password
+	return b
+}
+
+func (b *CreateUserBuilder) WithPasswordLength(n int) *CreateUserBuilder {
+	b.req.Password = b.g.Password(n)
+	return b
+}
+
+func (b *CreateUserBuilder) WithFullName(fullName string) *CreateUserBuilder {
+	b.req.FullName = fullName
+	return b
+}
+
+func (b *CreateUserBuilder) WithBio(bio string) *CreateUserBuilder {
+	b.req.Bio = bio
+	return b
+}
+
+func (b *CreateUserBuilder) WithAvatarURL(avatarURL string) *CreateUserBuilder {
+	b.req.AvatarURL = avatarURL
+	return b
+}
+
+func (b *CreateUserBuilder) Constraints() []FieldConstraint {
+	return Constraints(b.req)
+}
+
+func (b *CreateUserBuilder) Build() *CreateUserRequest {
+	req := b.req
+	return &req
+}
+
+type UpdateUserBuilder struct {
+	g   *Generator
+	req UpdateUserRequest
+}
+
+func NewUserUpdate(id int64) *UpdateUserBuilder {
+	return defaultGenerator.NewUserUpdate(id)
+}
+
+func (g *Generator) NewUserUpdate(id int64) *UpdateUserBuilder {
+	return &UpdateUserBuilder{g: g, req: *g.GenerateUpdateUserRequest(id, "", "", "", "")}
+}
+
+func (b *UpdateUserBuilder) WithID(id int64) *UpdateUserBuilder {
+	b.req.ID = id
+	return b
+}
+
+func (b *UpdateUserBuilder) WithUsername(username string) *UpdateUserBuilder {
+	b.req.Username = username
+	return b
+}
+
+func (b *UpdateUserBuilder) WithUsernameLength(n int) *UpdateUserBuilder {
+	b.req.Username = b.g.String(n)
+	return b
+}
+
+func (b *UpdateUserBuilder) WithEmail(email string) *UpdateUserBuilder {
+	b.req.Email = email
+	return b
+}
+
+func (b *UpdateUserBuilder) WithFullName(fullName string) *UpdateUserBuilder {
+	b.req.FullName = fullName
+	return b
+}
+
+func (b *UpdateUserBuilder) WithBio(bio string) *UpdateUserBuilder {
+	b.req.Bio = bio
+	return b
+}
+
+func (b *UpdateUserBuilder) Constraints() []FieldConstraint {
+	return Constraints(b.req)
+}
+
+func (b *UpdateUserBuilder) Build() *UpdateUserRequest {
+	req := b.req
+	return &req
+}
+
+// ========= Post Builders =========
+
+type PostBuilder struct {
+	g   *Generator
+	req CreatePostRequest
+}
+
+func NewPost() *PostBuilder {
+	return defaultGenerator.NewPost()
+}
+
+func (g *Generator) NewPost() *PostBuilder {
+	return &PostBuilder{g: g, req: *g.GenerateCreatePostRequest()}
+}
+
+func (b *PostBuilder) WithTitle(title string) *PostBuilder {
+	b.req.Title = title
+	return b
+}
+
+func (b *PostBuilder) WithTitleLength(n int) *PostBuilder {
+	b.req.Title = b.g.String(n)
+	return b
+}
+
+func (b *PostBuilder) WithContent(content string) *PostBuilder {
+	b.req.Content = content
+	return b
+}
+
+func (b *PostBuilder) WithContentLength(n int) *PostBuilder {
+	b.req.Content = b.g.String(n)
+	return b
+}
+
+// WithTags replaces the tags with n freshly generated ones; zero removes them.
+func (b *PostBuilder) WithTags(n int) *PostBuilder {
+	b.req.Tags = nil
+	for i := 0; i < n; i++ {
+		b.req.Tags = append(b.req.Tags, b.g.GenerateTag().Name)
+	}
+	return b
+}
+
+func (b *PostBuilder) WithTagNames(names ...string) *PostBuilder {
+	b.req.Tags = names
+	return b
+}
+
+// WithMedia replaces the media with n items of mediaType at positions 1..n;
+// zero removes them.
+func (b *PostBuilder) WithMedia(mediaType string, n int) *PostBuilder {
+	b.req.MediaItems = nil
+	for i := 0; i < n; i++ {
+		item := b.g.GenerateMediaItemInput()
+		item.Type = mediaType
+		item.Position = i + 1
+		b.req.MediaItems = append(b.req.MediaItems, item)
+	}
+	return b
+}
+
+func (b *PostBuilder) WithMediaItems(items ...MediaItemInput) *PostBuilder {
+	b.req.MediaItems = items
+	return b
+}
+
+func (b *PostBuilder) Constraints() []FieldConstraint {
+	return Constraints(b.req)
+}
+
+func (b *PostBuilder) Build() *CreatePostRequest {
+	req := b.req
+	req.Tags = append([]string(nil), b.req.Tags...)
+	req.MediaItems = append([]MediaItemInput(nil), b.req.MediaItems...)
+	return &req
+}
+
+// BuildUpdate returns the same data as an update request.
+func (b *PostBuilder) BuildUpdate() *UpdatePostRequest {
+	req := b.Build()
+	return &UpdatePostRequest{
+		Title:      req.Title,
+		Content:    req.Content,
+		MediaItems: req.MediaItems,
+		Tags:       req.Tags,
+	}
+}
+
+// ========= Notification Builders =========
+
+type NotificationBuilder struct {
+	req SendNotificationRequest
+}
+
+func NewNotification(userID int64) *NotificationBuilder {
+	return defaultGenerator.NewNotification(userID)
+}
+
+func (g *Generator) NewNotification(userID int64) *NotificationBuilder {
+	return &NotificationBuilder{req: *g.GenerateSendNotificationRequest(userID)}
+}
+
+func (b *NotificationBuilder) WithUserID(userID int64) *NotificationBuilder {
+	b.req.UserID = userID
+	return b
+}
+
+func (b *NotificationBuilder) WithType(notificationType string) *NotificationBuilder {
+	b.req.Type = notificationType
+	return b
+}
+
+func (b *NotificationBuilder) WithPayload(payload interface{}) *NotificationBuilder {
+	b.req.Payload = payload
+	return b
+}
+
+func (b *NotificationBuilder) Constraints() []FieldConstraint {
+	return Constraints(b.req)
+}
+
+func (b *NotificationBuilder) Build() *SendNotificationRequest {
+	req := b.req
+	return &req
+}
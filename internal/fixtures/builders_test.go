@@ -0,0 +1,58 @@
+package fixtures
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostBuilder(t *testing.T) {
+	g := NewGenerator(7)
+
+	req := g.NewPost().WithTags(0).WithMedia(MediaTypeVideo, 5).WithTitle("").Build()
+
+	assert.Empty(t, req.Title)
+	assert.Empty(t, req.Tags)
+	require.Len(t, req.MediaItems, 5)
+	for i, item := range req.MediaItems {
+		assert.Equal(t, MediaTypeVideo, item.Type)
+		assert.Equal(t, i+1, item.Position)
+	}
+	assert.NotEmpty(t, req.Content, "untouched fields stay valid")
+}
+
+func TestBuilderLengthHelpers(t *testing.T) {
+	g := NewGenerator(7)
+
+	reg := g.NewRegister().
+		WithUsernameLength(UsernameMinLength - 1).
+		WithPasswordLength(PasswordMinLength - 1).
+		Build()
+
+	assert.Equal(t, UsernameMinLength-1, utf8.RuneCountInString(reg.Username))
+	assert.Len(t, reg.Password, PasswordMinLength-1)
+}
+
+func TestBuildReturnsIndependentCopies(t *testing.T) {
+	b := NewPost().WithTagNames("a", "b")
+	first := b.Build()
+	first.Tags[0] = "changed"
+
+	assert.Equal(t, "a", b.Build().Tags[0])
+}
+
+func TestConstraints(t *testing.T) {
+	c := MustConstraintOf(RegisterRequest{}, "password")
+	assert.True(t, c.Required)
+	assert.Equal(t, 6, c.Min)
+	assert.Equal(t, "Password", c.Field)
+
+	media := MustConstraintOf(MediaItemInput{}, "Type")
+	assert.Equal(t, MediaTypes, media.OneOf)
+
+	_, ok := ConstraintOf(CreatePostRequest{}, "nope")
+	assert.False(t, ok)
+	assert.Equal(t, 255, PostTitleMaxLength)
+}
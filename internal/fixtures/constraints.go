@@ -0,0 +1,143 @@
+package fixtures
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ConstraintTag is the struct tag declaring the gateway's validation rules for
+// a request field. Supported rules: required, min=N, max=N (string length in
+// characters, slice length or integer value), format=email|url and
+// oneof=a b c.
+//
+// The tags are the only place these limits are written down, and they declare
+// only what the gateway is known to enforce: a limit goes in once a
+// validation scenario pins it, not before.
+const ConstraintTag = "constraint"
+
+const (
+	FormatEmail = "email"
+	FormatURL   = "url"
+
+	// NoLimit marks an absent min or max rule.
+	NoLimit = -1
+)
+
+// FieldConstraint is the parsed constraint tag of one request field.
+type FieldConstraint struct {
+	// Field is the Go field name, JSONName the key it is sent under.
+	Field    string
+	JSONName string
+	Kind     reflect.Kind
+	Required bool
+	Min      int
+	Max      int
+	Format   string
+	OneOf    []string
+}
+
+// HasMin reports whether the field has a min rule.
+func (c FieldConstraint) HasMin() bool {
+	return c.Min != NoLimit
+}
+
+// HasMax reports whether the field has a max rule.
+func (c FieldConstraint) HasMax() bool {
+	return c.Max != NoLimit
+}
+
+// Constraints returns the constraints declared on the fields of v, which must
+// be a struct or a pointer to one, in field order. Fields without a
+// constraint tag are skipped.
+func Constraints(v interface{}) []FieldConstraint {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var constraints []FieldConstraint
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup(ConstraintTag)
+		if !ok {
+			continue
+		}
+		constraints = append(constraints, parseConstraint(f, tag))
+	}
+	return constraints
+}
+
+// ConstraintOf returns the constraint of the field of v with the given Go or
+// JSON name. ok is false if the field has no constraint.
+func ConstraintOf(v interface{}, field string) (FieldConstraint, bool) {
+	for _, c := range Constraints(v) {
+		if c.Field == field || c.JSONName == field {
+			return c, true
+		}
+	}
+	return FieldConstraint{}, false
+}
+
+// MustConstraintOf is ConstraintOf for fields known to be constrained; it
+// panics otherwise, which in tests points at a typo in the field name.
+func MustConstraintOf(v interface{}, field string) FieldConstraint {
+	c, ok := ConstraintOf(v, field)
+	if !ok {
+		panic("fixtures: no constraint for field " + field)
+	}
+	return c
+}
+
+func parseConstraint(f reflect.StructField, tag string) FieldConstraint {
+	c := FieldConstraint{
+		Field:    f.Name,
+		JSONName: strings.Split(f.Tag.Get("json"), ",")[0],
+		Kind:     f.Type.Kind(),
+		Min:      NoLimit,
+		Max:      NoLimit,
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch key {
+		case "required":
+			c.Required = true
+		case "min":
+			c.Min = mustAtoi(f.Name, value)
+		case "max":
+			c.Max = mustAtoi(f.Name, value)
+		case "format":
+			c.Format = value
+		case "oneof":
+			c.OneOf = strings.Fields(value)
+		default:
+			panic("fixtures: unknown constraint rule " + strconv.Quote(rule) + " on " + f.Name)
+		}
+	}
+	return c
+}
+
+func mustAtoi(field, value string) int {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		panic("fixtures: invalid constraint number " + strconv.Quote(value) + " on " + field)
+	}
+	return n
+}
+
+// Limits read from the constraint tags so validation tests can derive
+// boundary values instead of hard-coding them.
+//
+// Two limits the gateway enforces are deliberately left out of the tags
+// because no scenario pins their value, so BoundaryCases yields no max or
+// max+1 case for them:
+//   - the username maximum length: LongUsername in gateway_auth shows 36
+//     characters are rejected, not where the limit lies below that;
+//   - the maximum number of post media items: MaxMediaItems only bounds what
+//     the generator produces and is not known to match the gateway.
+var (
+	UsernameMinLength  = MustConstraintOf(RegisterRequest{}, "Username").Min
+	PasswordMinLength  = MustConstraintOf(RegisterRequest{}, "Password").Min
+	PostTitleMaxLength = MustConstraintOf(CreatePostRequest{}, "Title").Max
+)
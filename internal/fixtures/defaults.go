@@ -0,0 +1,120 @@
+package fixtures
+
+import "math/rand"
+
+// defaultGenerator backs the package-level Generate* functions. It is seeded
+// with the root seed, so their output depends on call order across tests;
+// use ForTest for reproducible per-test data.
+var defaultGenerator = NewGenerator(RootSeed())
+
+func GetSafeRandom() *rand.Rand {
+	return defaultGenerator.Rand()
+}
+
+func GenerateRegisterRequest() *RegisterRequest {
+	return defaultGenerator.GenerateRegisterRequest()
+}
+
+func GenerateLoginRequest(username, password string) *LoginRequest {
+	return defaultGenerator.GenerateLoginRequest(username, password)
+}
+
+func GenerateRefreshTokenRequest(token string) *RefreshTokenRequest {
+	return defaultGenerator.GenerateRefreshTokenRequest(token)
+}
+
+func GenerateLogoutRequest(token string) *LogoutRequest {
+	return defaultGenerator.GenerateLogoutRequest(token)
+}
+
+func GenerateUpdatePasswordRequest() *UpdatePasswordRequest {
+	return defaultGenerator.GenerateUpdatePasswordRequest()
+}
+
+func GenerateUser() *User {
+	return defaultGenerator.GenerateUser()
+}
+
+func GenerateCreateUserRequest() *CreateUserRequest {
+	return defaultGenerator.GenerateCreateUserRequest()
+}
+
+func GenerateUpdateUserRequest(id int64, username, email, fullName, bio string) *UpdateUserRequest {
+	return defaultGenerator.GenerateUpdateUserRequest(id, username, email, fullName, bio)
+}
+
+func GenerateUpdateAvatarRequest() *UpdateAvatarRequest {
+	return defaultGenerator.GenerateUpdateAvatarRequest()
+}
+
+func GenerateMediaItemInput() MediaItemInput {
+	return defaultGenerator.GenerateMediaItemInput()
+}
+
+func GeneratePostMedia(id int64) PostMedia {
+	return defaultGenerator.GeneratePostMedia(id)
+}
+
+func GenerateTag() Tag {
+	return defaultGenerator.GenerateTag()
+}
+
+func GeneratePostAuthor() PostAuthor {
+	return defaultGenerator.GeneratePostAuthor()
+}
+
+func GeneratePost() *Post {
+	return defaultGenerator.GeneratePost()
+}
+
+func GenerateCreatePostRequest() *CreatePostRequest {
+	return defaultGenerator.GenerateCreatePostRequest()
+}
+
+func GenerateUpdatePostRequest() *UpdatePostRequest {
+	return defaultGenerator.GenerateUpdatePostRequest()
+}
+
+func GenerateFollowRequest(followeeId int64) *FollowRequest {
+	return defaultGenerator.GenerateFollowRequest(followeeId)
+}
+
+func GenerateUnfollowRequest(followeeId int64) *UnfollowRequest {
+	return defaultGenerator.GenerateUnfollowRequest(followeeId)
+}
+
+func GenerateGetFollowersResponse(page, limit int) *GetFollowersResponse {
+	return defaultGenerator.GenerateGetFollowersResponse(page, limit)
+}
+
+func GenerateGetFolloweesResponse(page, limit int) *GetFolloweesResponse {
+	return defaultGenerator.GenerateGetFolloweesResponse(page, limit)
+}
+
+func GenerateRelationUser() *RelationUser {
+	return defaultGenerator.GenerateRelationUser()
+}
+
+func GenerateNotification(userId int64) *Notification {
+	return defaultGenerator.GenerateNotification(userId)
+}
+
+func GenerateSendNotificationRequest(userId int64) *SendNotificationRequest {
+	return defaultGenerator.GenerateSendNotificationRequest(userId)
+}
+
+func GenerateTestUsers(count int) []*User {
+	return defaultGenerator.GenerateTestUsers(count)
+}
+
+func GenerateTestPosts(count int) []*Post {
+	return defaultGenerator.GenerateTestPosts(count)
+}
+
+func GenerateTestNotifications(userID int64, count int) []*Notification {
+	return defaultGenerator.GenerateTestNotifications(userID, count)
+}
+
+func CreateUserJourney() *UserJourney {
+	return defaultGenerator.CreateUserJourney()
+}
@@ -1,13 +1,8 @@
 package fixtures
 
 import (
-	"math/rand"
-	"os"
 	"strconv"
-	"sync"
 	"time"
-
-	"github.com/brianvoe/gofakeit/v6"
 )
 
 const (
@@ -15,7 +10,6 @@ const (
 	MaxTestID = 10000
 
 	// Password constants
-	MinPasswordLength        = 6
 	DefaultNewPasswordLength = 10
 	UpdatedPasswordLength    = 12
 
@@ -24,7 +18,10 @@ const (
 	PostImageWidth  = 800
 	PostImageHeight = 600
 
-	// Collection sizes
+	// Tag names are "test" + a number in [0;TagNumberRange) + letters
+	TagNumberRange = 1_000_000_000
+
+	// Collection sizes the generator produces, not gateway limits
 	MaxMediaItems    = 5
 	MaxTagItems      = 4
 	MinTagItems      = 2
@@ -44,71 +41,26 @@ const (
 	DefaultOtherUsersCount              = 5
 )
 
-// Thread-safe random number generator
-var (
-	globalRand     *rand.Rand
-	globalRandLock sync.Mutex
-	seedOnce       sync.Once
-)
-
-func GetSafeRandom() *rand.Rand {
-	globalRandLock.Lock()
-	defer globalRandLock.Unlock()
-	return globalRand
-}
-
-// random int in [0;n)
-func safeRandIntn(n int) int {
-	globalRandLock.Lock()
-	defer globalRandLock.Unlock()
-	return globalRand.Intn(n)
-}
-
-// random int64
-func safeRandInt64() int64 {
-	globalRandLock.Lock()
-	defer globalRandLock.Unlock()
-	return globalRand.Int63()
-}
-
-func initializeSeed() {
-	seedOnce.Do(func() {
-		seed := time.Now().UnixNano()
-		if envSeed, ok := os.LookupEnv("TEST_SEED"); ok {
-			if parsedSeed, err := strconv.ParseInt(envSeed, 10, 64); err == nil {
-				seed = parsedSeed
-			}
-		}
-		gofakeit.Seed(seed)
-
-		globalRand = rand.New(rand.NewSource(seed))
-	})
-}
-
-func init() {
-	initializeSeed()
-}
-
 // ========= Auth Data Generators =========
 
-func GenerateRegisterRequest() *RegisterRequest {
+func (g *Generator) GenerateRegisterRequest() *RegisterRequest {
 	return &RegisterRequest{
-		Username:  gofakeit.Username(),
-		Email:     gofakeit.Email(),
-		Password:  gofakeit.Password(true, true, true, true, false, DefaultNewPasswordLength),
-		FullName:  gofakeit.Name(),
-		Bio:       gofakeit.HipsterSentence(BioSentences),
-		AvatarURL: gofakeit.ImageURL(AvatarSize, AvatarSize),
+		Username:  MarkUsername(g.runID, g.faker.Username()),
+		Email:     g.faker.Email(),
+		Password:  RunPassword(g.runID),
+		FullName:  g.faker.Name(),
+		Bio:       MarkBio(g.runID, g.faker.HipsterSentence(BioSentences)),
+		AvatarURL: g.faker.ImageURL(AvatarSize, AvatarSize),
 	}
 }
 
-func GenerateLoginRequest(username, password string) *LoginRequest {
+func (g *Generator) GenerateLoginRequest(username, password string) *LoginRequest {
 	if username == "" {
-		username = gofakeit.Username()
+		username = g.faker.Username()
 	}
 
 	if password == "" {
-		password = gofakeit.Password(true, true, true, true, false, DefaultNewPasswordLength)
+		password = g.faker.Password(true, true, true, true, false, DefaultNewPasswordLength)
 	}
 
 	return &LoginRequest{
@@ -117,9 +69,9 @@ func GenerateLoginRequest(username, password string) *LoginRequest {
 	}
 }
 
-func GenerateRefreshTokenRequest(token string) *RefreshTokenRequest {
+func (g *Generator) GenerateRefreshTokenRequest(token string) *RefreshTokenRequest {
 	if token == "" {
-		token = gofakeit.UUID()
+		token = g.faker.UUID()
 	}
 
 	return &RefreshTokenRequest{
@@ -127,9 +79,9 @@ func GenerateRefreshTokenRequest(token string) *RefreshTokenRequest {
 	}
 }
 
-func GenerateLogoutRequest(token string) *LogoutRequest {
+func (g *Generator) GenerateLogoutRequest(token string) *LogoutRequest {
 	if token == "" {
-		token = gofakeit.UUID()
+		token = g.faker.UUID()
 	}
 
 	return &LogoutRequest{
@@ -137,54 +89,54 @@ func GenerateLogoutRequest(token string) *LogoutRequest {
 	}
 }
 
-func GenerateUpdatePasswordRequest() *UpdatePasswordRequest {
+func (g *Generator) GenerateUpdatePasswordRequest() *UpdatePasswordRequest {
 	return &UpdatePasswordRequest{
-		OldPassword: gofakeit.Password(true, true, true, true, false, DefaultNewPasswordLength),
-		NewPassword: gofakeit.Password(true, true, true, true, false, UpdatedPasswordLength),
+		OldPassword: g.faker.Password(true, true, true, true, false, DefaultNewPasswordLength),
+		NewPassword: g.faker.Password(true, true, true, true, false, UpdatedPasswordLength),
 	}
 }
 
 // ========= User Data Generators =========
 
-func GenerateUser() *User {
+func (g *Generator) GenerateUser() *User {
 	return &User{
-		ID:        int64(safeRandIntn(MaxTestID) + 1),
-		Username:  gofakeit.Username(),
-		Email:     gofakeit.Email(),
-		FullName:  gofakeit.Name(),
-		Bio:       gofakeit.HipsterSentence(BioSentences),
-		AvatarURL: gofakeit.ImageURL(AvatarSize, AvatarSize),
-		CreatedAt: time.Now().Add(-time.Duration(safeRandIntn(MaxDaysAgo)) * 24 * time.Hour),
+		ID:        int64(g.intn(MaxTestID) + 1),
+		Username:  g.faker.Username(),
+		Email:     g.faker.Email(),
+		FullName:  g.faker.Name(),
+		Bio:       g.faker.HipsterSentence(BioSentences),
+		AvatarURL: g.faker.ImageURL(AvatarSize, AvatarSize),
+		CreatedAt: time.Now().Add(-time.Duration(g.intn(MaxDaysAgo)) * 24 * time.Hour),
 		UpdatedAt: time.Now(),
 	}
 }
 
-func GenerateCreateUserRequest() *CreateUserRequest {
+func (g *Generator) GenerateCreateUserRequest() *CreateUserRequest {
 	return &CreateUserRequest{
-		Username:  gofakeit.Username(),
-		Email:     gofakeit.Email(),
-		Password:  gofakeit.Password(true, true, true, true, false, DefaultNewPasswordLength),
-		FullName:  gofakeit.Name(),
-		Bio:       gofakeit.HipsterSentence(BioSentences),
-		AvatarURL: gofakeit.ImageURL(AvatarSize, AvatarSize),
+		Username:  MarkUsername(g.runID, g.faker.Username()),
+		Email:     g.faker.Email(),
+		Password:  RunPassword(g.runID),
+		FullName:  g.faker.Name(),
+		Bio:       MarkBio(g.runID, g.faker.HipsterSentence(BioSentences)),
+		AvatarURL: g.faker.ImageURL(AvatarSize, AvatarSize),
 	}
 }
 
-func GenerateUpdateUserRequest(id int64, username, email, fullName, bio string) *UpdateUserRequest {
+func (g *Generator) GenerateUpdateUserRequest(id int64, username, email, fullName, bio string) *UpdateUserRequest {
 	if username == "" {
-		username = gofakeit.Username()
+		username = MarkUsername(g.runID, g.faker.Username())
 	}
 
 	if email == "" {
-		email = gofakeit.Email()
+		email = g.faker.Email()
 	}
 
 	if fullName == "" {
-		fullName = gofakeit.Name()
+		fullName = g.faker.Name()
 	}
 
 	if bio == "" {
-		bio = gofakeit.HipsterSentence(BioSentences)
+		bio = MarkBio(g.runID, g.faker.HipsterSentence(BioSentences))
 	}
 
 	return &UpdateUserRequest{
@@ -196,9 +148,9 @@ func GenerateUpdateUserRequest(id int64, username, email, fullName, bio string)
 	}
 }
 
-func GenerateUpdateAvatarRequest() *UpdateAvatarRequest {
+func (g *Generator) GenerateUpdateAvatarRequest() *UpdateAvatarRequest {
 	return &UpdateAvatarRequest{
-		AvatarURL: gofakeit.ImageURL(AvatarSize, AvatarSize),
+		AvatarURL: g.faker.ImageURL(AvatarSize, AvatarSize),
 	}
 }
 
@@ -212,38 +164,37 @@ const (
 
 var MediaTypes = []string{MediaTypeImage, MediaTypeVideo}
 
-func GenerateMediaItemInput() MediaItemInput {
+func (g *Generator) GenerateMediaItemInput() MediaItemInput {
 	return MediaItemInput{
-		Type:     MediaTypes[safeRandIntn(len(MediaTypes))],
-		URL:      gofakeit.ImageURL(PostImageWidth, PostImageHeight),
-		Position: safeRandIntn(MaxMediaPosition) + 1,
+		Type:     MediaTypes[g.intn(len(MediaTypes))],
+		URL:      g.faker.ImageURL(PostImageWidth, PostImageHeight),
+		Position: g.intn(MaxMediaPosition) + 1,
 	}
 }
 
-func GeneratePostMedia(id int64) PostMedia {
+func (g *Generator) GeneratePostMedia(id int64) PostMedia {
 	return PostMedia{
 		ID:       id,
-		Type:     MediaTypes[safeRandIntn(len(MediaTypes))],
-		URL:      gofakeit.ImageURL(PostImageWidth, PostImageHeight),
-		Position: safeRandIntn(MaxMediaPosition) + 1,
+		Type:     MediaTypes[g.intn(len(MediaTypes))],
+		URL:      g.faker.ImageURL(PostImageWidth, PostImageHeight),
+		Position: g.intn(MaxMediaPosition) + 1,
 	}
 }
 
-func GenerateTag() Tag {
-	timestamp := strconv.Itoa(time.Now().Nanosecond())
-	prefix := "test" + timestamp
+func (g *Generator) GenerateTag() Tag {
+	prefix := "test" + strconv.Itoa(g.intn(TagNumberRange))
 	return Tag{
-		ID:   int64(safeRandIntn(MaxTestID) + 1),
-		Name: prefix + gofakeit.Generate("????????????????????"),
+		ID:   int64(g.intn(MaxTestID) + 1),
+		Name: prefix + g.faker.Generate("????????????????????"),
 	}
 }
 
-func GeneratePostAuthor() PostAuthor {
+func (g *Generator) GeneratePostAuthor() PostAuthor {
 	return PostAuthor{
-		ID:        int64(safeRandIntn(MaxTestID) + 1),
-		Username:  gofakeit.Username(),
-		FullName:  gofakeit.Name(),
-		AvatarURL: gofakeit.ImageURL(AvatarSize, AvatarSize),
+		ID:        int64(g.intn(MaxTestID) + 1),
+		Username:  g.faker.Username(),
+		FullName:  g.faker.Name(),
+		AvatarURL: g.faker.ImageURL(AvatarSize, AvatarSize),
 	}
 }
 
@@ -255,64 +206,64 @@ const (
 	ParagraphBreak               = "\n"
 )
 
-func GeneratePost() *Post {
+func (g *Generator) GeneratePost() *Post {
 	var medialist []PostMedia
-	for i := 0; i < safeRandIntn(MaxMediaItems); i++ {
-		medialist = append(medialist, GeneratePostMedia(int64(i+1)))
+	for i := 0; i < g.intn(MaxMediaItems); i++ {
+		medialist = append(medialist, g.GeneratePostMedia(int64(i+1)))
 	}
 
 	var tags []Tag
-	for i := 0; i < safeRandIntn(MaxTagItems)+MinTagItems; i++ {
-		tags = append(tags, GenerateTag())
+	for i := 0; i < g.intn(MaxTagItems)+MinTagItems; i++ {
+		tags = append(tags, g.GenerateTag())
 	}
 
-	createdAt := time.Now().Add(-time.Duration(safeRandIntn(MaxDaysAgo)) * 24 * time.Hour)
+	createdAt := time.Now().Add(-time.Duration(g.intn(MaxDaysAgo)) * 24 * time.Hour)
 
 	return &Post{
-		ID:        int64(safeRandIntn(MaxTestID) + 1),
-		Title:     gofakeit.Sentence(TitleSentences),
-		Content:   gofakeit.Paragraph(ParagraphMinSentences, ParagraphMaxSentences, ParagraphMaxWordsPerSentence, ParagraphBreak),
-		Author:    GeneratePostAuthor(),
+		ID:        int64(g.intn(MaxTestID) + 1),
+		Title:     g.faker.Sentence(TitleSentences),
+		Content:   g.faker.Paragraph(ParagraphMinSentences, ParagraphMaxSentences, ParagraphMaxWordsPerSentence, ParagraphBreak),
+		Author:    g.GeneratePostAuthor(),
 		Media:     medialist,
 		Tags:      tags,
 		CreatedAt: createdAt,
-		UpdatedAt: createdAt.Add(time.Duration(safeRandIntn(MaxHoursAfterCreation)) * time.Hour),
+		UpdatedAt: createdAt.Add(time.Duration(g.intn(MaxHoursAfterCreation)) * time.Hour),
 	}
 }
 
-func GenerateCreatePostRequest() *CreatePostRequest {
+func (g *Generator) GenerateCreatePostRequest() *CreatePostRequest {
 	var medialist []MediaItemInput
-	for i := 0; i < safeRandIntn(MaxMediaItems); i++ {
-		medialist = append(medialist, GenerateMediaItemInput())
+	for i := 0; i < g.intn(MaxMediaItems); i++ {
+		medialist = append(medialist, g.GenerateMediaItemInput())
 	}
 
 	var tags []string
-	for i := 0; i < safeRandIntn(MaxTagItems)+MinTagItems; i++ {
-		tags = append(tags, GenerateTag().Name)
+	for i := 0; i < g.intn(MaxTagItems)+MinTagItems; i++ {
+		tags = append(tags, g.GenerateTag().Name)
 	}
 
 	return &CreatePostRequest{
-		Title:      gofakeit.Sentence(TitleSentences),
-		Content:    gofakeit.Paragraph(ParagraphMinSentences, ParagraphMaxSentences, ParagraphMaxWordsPerSentence, ParagraphBreak),
+		Title:      g.faker.Sentence(TitleSentences),
+		Content:    g.faker.Paragraph(ParagraphMinSentences, ParagraphMaxSentences, ParagraphMaxWordsPerSentence, ParagraphBreak),
 		MediaItems: medialist,
 		Tags:       tags,
 	}
 }
 
-func GenerateUpdatePostRequest() *UpdatePostRequest {
+func (g *Generator) GenerateUpdatePostRequest() *UpdatePostRequest {
 	var medialist []MediaItemInput
-	for i := 0; i < safeRandIntn(MaxMediaItems); i++ {
-		medialist = append(medialist, GenerateMediaItemInput())
+	for i := 0; i < g.intn(MaxMediaItems); i++ {
+		medialist = append(medialist, g.GenerateMediaItemInput())
 	}
 
 	var tags []string
-	for i := 0; i < safeRandIntn(MaxTagItems)+MinTagItems; i++ {
-		tags = append(tags, GenerateTag().Name)
+	for i := 0; i < g.intn(MaxTagItems)+MinTagItems; i++ {
+		tags = append(tags, g.GenerateTag().Name)
 	}
 
 	return &UpdatePostRequest{
-		Title:      gofakeit.Sentence(TitleSentences),
-		Content:    gofakeit.Paragraph(ParagraphMinSentences, ParagraphMaxSentences, ParagraphMaxWordsPerSentence, ParagraphBreak),
+		Title:      g.faker.Sentence(TitleSentences),
+		Content:    g.faker.Paragraph(ParagraphMinSentences, ParagraphMaxSentences, ParagraphMaxWordsPerSentence, ParagraphBreak),
 		MediaItems: medialist,
 		Tags:       tags,
 	}
@@ -320,29 +271,29 @@ func GenerateUpdatePostRequest() *UpdatePostRequest {
 
 // ========= Relation Data Generators =========
 
-func GenerateFollowRequest(followeeId int64) *FollowRequest {
+func (g *Generator) GenerateFollowRequest(followeeId int64) *FollowRequest {
 	if followeeId == 0 {
-		followeeId = int64(safeRandIntn(MaxTestID) + 1)
+		followeeId = int64(g.intn(MaxTestID) + 1)
 	}
 	return &FollowRequest{
 		FolloweeID: followeeId,
 	}
 }
 
-func GenerateUnfollowRequest(followeeId int64) *UnfollowRequest {
+func (g *Generator) GenerateUnfollowRequest(followeeId int64) *UnfollowRequest {
 	if followeeId == 0 {
-		followeeId = int64(safeRandIntn(MaxTestID) + 1)
+		followeeId = int64(g.intn(MaxTestID) + 1)
 	}
 	return &UnfollowRequest{
 		FolloweeID: followeeId,
 	}
 }
 
-func GenerateGetFollowersResponse(page, limit int) *GetFollowersResponse {
-	count := safeRandIntn(limit) + 1
+func (g *Generator) GenerateGetFollowersResponse(page, limit int) *GetFollowersResponse {
+	count := g.intn(limit) + 1
 	var followers []*RelationUser
 	for i := 0; i < count; i++ {
-		user := GenerateRelationUser()
+		user := g.GenerateRelationUser()
 		followers = append(followers, user)
 	}
 
@@ -354,11 +305,11 @@ func GenerateGetFollowersResponse(page, limit int) *GetFollowersResponse {
 	}
 }
 
-func GenerateGetFolloweesResponse(page, limit int) *GetFolloweesResponse {
-	count := safeRandIntn(limit) + 1
+func (g *Generator) GenerateGetFolloweesResponse(page, limit int) *GetFolloweesResponse {
+	count := g.intn(limit) + 1
 	var followees []*RelationUser
 	for i := 0; i < count; i++ {
-		user := GenerateRelationUser()
+		user := g.GenerateRelationUser()
 		followees = append(followees, user)
 	}
 
@@ -371,15 +322,15 @@ func GenerateGetFolloweesResponse(page, limit int) *GetFolloweesResponse {
 }
 
 // GenerateRelationUser creates a test RelationUser
-func GenerateRelationUser() *RelationUser {
+func (g *Generator) GenerateRelationUser() *RelationUser {
 	user := &RelationUser{
-		ID:       safeRandInt64(),
-		Username: gofakeit.Username(),
+		ID:       g.int63(),
+		Username: g.faker.Username(),
 	}
 
 	// 50% chance to have avatar URL
-	if safeRandIntn(2) == 1 {
-		avatarURL := gofakeit.ImageURL(100, 100)
+	if g.intn(2) == 1 {
+		avatarURL := g.faker.ImageURL(100, 100)
 		user.AvatarURL = &avatarURL
 	}
 
@@ -411,53 +362,53 @@ var NotificationTypes = []string{
 	NotificationTypeSystem,
 }
 
-func GenerateNotification(userId int64) *Notification {
+func (g *Generator) GenerateNotification(userId int64) *Notification {
 	if userId == 0 {
-		userId = int64(safeRandIntn(MaxTestID) + 1)
+		userId = int64(g.intn(MaxTestID) + 1)
 	}
 	return &Notification{
-		ID:        int64(safeRandIntn(MaxTestID) + 1),
+		ID:        int64(g.intn(MaxTestID) + 1),
 		UserID:    userId,
-		Type:      NotificationTypes[safeRandIntn(len(NotificationTypes))],
-		Payload:   map[string]interface{}{PayloadDataKey: gofakeit.Sentence(TitleSentences)},
-		IsRead:    safeRandIntn(RandomBoolModulo) == 1,
-		CreatedAt: time.Now().Add(-time.Duration(safeRandIntn(MaxHoursAfterCreation)) * time.Hour),
+		Type:      NotificationTypes[g.intn(len(NotificationTypes))],
+		Payload:   map[string]interface{}{PayloadDataKey: g.faker.Sentence(TitleSentences)},
+		IsRead:    g.intn(RandomBoolModulo) == 1,
+		CreatedAt: time.Now().Add(-time.Duration(g.intn(MaxHoursAfterCreation)) * time.Hour),
 	}
 }
 
-func GenerateSendNotificationRequest(userId int64) *SendNotificationRequest {
+func (g *Generator) GenerateSendNotificationRequest(userId int64) *SendNotificationRequest {
 	if userId == 0 {
-		userId = int64(safeRandIntn(MaxTestID) + 1)
+		userId = int64(g.intn(MaxTestID) + 1)
 	}
 	return &SendNotificationRequest{
 		UserID:  userId,
-		Type:    NotificationTypes[safeRandIntn(len(NotificationTypes))],
-		Payload: map[string]interface{}{PayloadDataKey: gofakeit.Sentence(TitleSentences)},
+		Type:    NotificationTypes[g.intn(len(NotificationTypes))],
+		Payload: map[string]interface{}{PayloadDataKey: g.faker.Sentence(TitleSentences)},
 	}
 }
 
 // ========= Test Fixtures Sets =========
 
-func GenerateTestUsers(count int) []*User {
+func (g *Generator) GenerateTestUsers(count int) []*User {
 	var users []*User
 	for i := 0; i < count; i++ {
-		users = append(users, GenerateUser())
+		users = append(users, g.GenerateUser())
 	}
 	return users
 }
 
-func GenerateTestPosts(count int) []*Post {
+func (g *Generator) GenerateTestPosts(count int) []*Post {
 	var posts []*Post
 	for i := 0; i < count; i++ {
-		posts = append(posts, GeneratePost())
+		posts = append(posts, g.GeneratePost())
 	}
 	return posts
 }
 
-func GenerateTestNotifications(userID int64, count int) []*Notification {
+func (g *Generator) GenerateTestNotifications(userID int64, count int) []*Notification {
 	var notifications []*Notification
 	for i := 0; i < count; i++ {
-		notification := GenerateNotification(userID)
+		notification := g.GenerateNotification(userID)
 		notification.UserID = userID
 		notifications = append(notifications, notification)
 	}
@@ -471,8 +422,8 @@ const (
 
 // ========= User Journey Generators =========
 
-func CreateUserJourney() *UserJourney {
-	registerData := GenerateRegisterRequest()
+func (g *Generator) CreateUserJourney() *UserJourney {
+	registerData := g.GenerateRegisterRequest()
 
 	user := &User{
 		ID:        DefaultUserID,
@@ -487,7 +438,7 @@ func CreateUserJourney() *UserJourney {
 
 	var posts []*Post
 	for i := 0; i < DefaultUserJourneyPostCount; i++ {
-		post := GeneratePost()
+		post := g.GeneratePost()
 		post.Author.ID = user.ID
 		post.Author.Username = user.Username
 		post.Author.FullName = user.FullName
@@ -495,9 +446,9 @@ func CreateUserJourney() *UserJourney {
 		posts = append(posts, post)
 	}
 
-	notifications := GenerateTestNotifications(user.ID, DefaultUserJourneyNotificationCount)
+	notifications := g.GenerateTestNotifications(user.ID, DefaultUserJourneyNotificationCount)
 
-	otherUsers := GenerateTestUsers(DefaultOtherUsersCount)
+	otherUsers := g.GenerateTestUsers(DefaultOtherUsersCount)
 
 	return &UserJourney{
 		RegisterRequest: registerData,
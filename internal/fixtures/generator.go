@@ -0,0 +1,124 @@
+package fixtures
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+// SeedEnv is the environment variable holding the root seed of a run.
+const SeedEnv = "TEST_SEED"
+
+// Generator produces test data from its own seeded source, so the data a
+// test gets does not depend on what other tests generated before it.
+// It is safe for concurrent use.
+type Generator struct {
+	seed  int64
+	runID string
+	faker *gofakeit.Faker
+}
+
+// NewGenerator returns a generator seeded with seed. A zero seed is replaced
+// with a fixed non-zero value because gofakeit treats zero as "random".
+func NewGenerator(seed int64) *Generator {
+	if seed == 0 {
+		seed = 1
+	}
+	return &Generator{
+		seed:  seed,
+		runID: RunID(),
+		faker: gofakeit.New(seed),
+	}
+}
+
+// Seed returns the seed the generator was created with.
+func (g *Generator) Seed() int64 {
+	return g.seed
+}
+
+// RunID returns the run ID the generator marks users with.
+func (g *Generator) RunID() string {
+	return g.runID
+}
+
+// Rand returns the generator's random source.
+func (g *Generator) Rand() *rand.Rand {
+	return g.faker.Rand
+}
+
+// Faker returns the generator's gofakeit instance.
+func (g *Generator) Faker() *gofakeit.Faker {
+	return g.faker
+}
+
+// random int in [0;n)
+func (g *Generator) intn(n int) int {
+	return g.faker.Rand.Intn(n)
+}
+
+// random int64
+func (g *Generator) int63() int64 {
+	return g.faker.Rand.Int63()
+}
+
+var (
+	rootSeed     int64
+	rootSeedOnce sync.Once
+)
+
+// RootSeed returns the seed of the whole run: TEST_SEED if it is set and
+// valid, otherwise the current time.
+func RootSeed() int64 {
+	rootSeedOnce.Do(func() {
+		rootSeed = time.Now().UnixNano()
+		if envSeed, ok := os.LookupEnv(SeedEnv); ok {
+			if parsedSeed, err := strconv.ParseInt(envSeed, 10, 64); err == nil {
+				rootSeed = parsedSeed
+			}
+		}
+	})
+	return rootSeed
+}
+
+// DeriveSeed combines the root seed with a test name.
+func DeriveSeed(root int64, name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return root ^ int64(h.Sum64())
+}
+
+// ForTest returns a generator seeded from the root seed and t.Name(). The
+// same TEST_SEED and test name always give the same data regardless of which
+// tests run in parallel. When the test fails the command to reproduce it is
+// logged.
+func ForTest(t testing.TB) *Generator {
+	t.Helper()
+
+	root := RootSeed()
+	g := NewGenerator(DeriveSeed(root, t.Name()))
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("fixtures: reproduce with %s=%d go test -run '%s' (test seed %d)", SeedEnv, root, runPattern(t.Name()), g.Seed())
+		}
+	})
+
+	return g
+}
+
+// runPattern turns a test name into a -run pattern matching only that test.
+func runPattern(name string) string {
+	parts := strings.Split(name, "/")
+	for i, part := range parts {
+		parts[i] = "^" + regexp.QuoteMeta(part) + "$"
+	}
+	return strings.Join(parts, "/")
+}
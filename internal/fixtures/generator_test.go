@@ -0,0 +1,36 @@
+package fixtures
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratorIsDeterministic(t *testing.T) {
+	a := NewGenerator(42)
+	b := NewGenerator(42)
+
+	assert.Equal(t, a.GenerateRegisterRequest(), b.GenerateRegisterRequest())
+	assert.Equal(t, a.GenerateCreatePostRequest(), b.GenerateCreatePostRequest())
+	assert.Equal(t, a.GenerateSendNotificationRequest(7), b.GenerateSendNotificationRequest(7))
+}
+
+func TestDeriveSeedDependsOnTestName(t *testing.T) {
+	assert.Equal(t, DeriveSeed(1, "TestA"), DeriveSeed(1, "TestA"))
+	assert.NotEqual(t, DeriveSeed(1, "TestA"), DeriveSeed(1, "TestB"))
+	assert.NotEqual(t, DeriveSeed(1, "TestA"), DeriveSeed(2, "TestA"))
+}
+
+func TestForTestIgnoresOtherGenerators(t *testing.T) {
+	first := ForTest(t).GenerateRegisterRequest()
+
+	// Data drawn elsewhere must not shift what this test gets.
+	GenerateTestUsers(10)
+	NewGenerator(RootSeed()).GenerateTestPosts(3)
+
+	assert.Equal(t, first, ForTest(t).GenerateRegisterRequest())
+}
+
+func TestRunPattern(t *testing.T) {
+	assert.Equal(t, "^TestX$/^Case_1\\.a$", runPattern("TestX/Case_1.a"))
+}
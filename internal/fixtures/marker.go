@@ -0,0 +1,170 @@
+package fixtures
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Every user the generators create carries the ID of the run that created it,
+// twice: as a username prefix, which SearchUsers can find, and as a bio tag,
+// which survives tests that rename the user. When a run dies before its
+// cleanup, the sweeper finds the leftovers by this marker.
+//
+// The run ID is random per process, not derived from the seed, so replaying a
+// seed never collides with the users an earlier run left behind.
+
+// RunIDEnv sets the run ID explicitly, for example to tie leftovers to a CI
+// job.
+const RunIDEnv = "TEST_RUN_ID"
+
+// RunSecretEnv holds the key run passwords are derived with. The run ID is
+// public, in every marked username, so the key is what keeps others from
+// logging in as test users. Runs and the sweeper that cleans up after them
+// must share it; without it each process uses a random key, and its users can
+// only be removed by its own cleanup.
+const RunSecretEnv = "PINSTACK_E2E_RUN_SECRET"
+
+const (
+	// MarkerPrefix starts every marked username: e2e<run id>_<name>.
+	MarkerPrefix = "e2e"
+	runIDLength  = 6
+	runIDChars   = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+	markedNameLength = 16
+
+	// keepTag marks users of a persistent dataset, such as the one
+	// cmd/e2e seed creates, which the sweeper leaves to the teardown of
+	// its manifest.
+	keepTag = "[e2e-keep]"
+)
+
+var (
+	runID     string
+	runIDOnce sync.Once
+
+	runSecret     []byte
+	runSecretOnce sync.Once
+
+	bioMarker = regexp.MustCompile(`\[e2e-run:([a-z0-9]+)\]`)
+)
+
+// RunID returns the ID of this run: TEST_RUN_ID if it is a valid ID,
+// otherwise a random one.
+func RunID() string {
+	runIDOnce.Do(func() {
+		if env, ok := os.LookupEnv(RunIDEnv); ok && ValidRunID(env) {
+			runID = env
+			return
+		}
+		b := make([]byte, runIDLength)
+		_, _ = rand.Read(b)
+		for i := range b {
+			b[i] = runIDChars[int(b[i])%len(runIDChars)]
+		}
+		runID = string(b)
+	})
+	return runID
+}
+
+// ValidRunID reports whether id can be used as a run ID.
+func ValidRunID(id string) bool {
+	if id == "" || len(id) > runIDLength {
+		return false
+	}
+	for _, r := range id {
+		if !strings.ContainsRune(runIDChars, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// UsernamePrefix is the username prefix of users created by run id. An empty
+// id gives the prefix shared by all runs.
+func UsernamePrefix(id string) string {
+	if id == "" {
+		return MarkerPrefix
+	}
+	return MarkerPrefix + id + "_"
+}
+
+// MarkUsername prefixes username with the run marker. The name is cut to
+// markedNameLength so marked usernames stay about as long as the unmarked
+// generated ones were; the marker never is, or the sweeper could no longer
+// tell which run the user belongs to.
+func MarkUsername(id, username string) string {
+	if len(username) > markedNameLength {
+		username = username[:markedNameLength]
+	}
+	return UsernamePrefix(id) + username
+}
+
+// RunPassword is the password of every user the generators register for run
+// id. It is derived from the run ID and the run secret so the sweeper can log
+// in as the users a dead run left behind, through the same login endpoint the
+// tests use.
+func RunPassword(id string) string {
+	mac := hmac.New(sha256.New, secret())
+	mac.Write([]byte("e2e-run:" + id))
+	return "E2e!" + hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// RunSecretSet reports whether RunSecretEnv is set, that is whether users of
+// other processes can be logged in as.
+func RunSecretSet() bool {
+	return os.Getenv(RunSecretEnv) != ""
+}
+
+func secret() []byte {
+	runSecretOnce.Do(func() {
+		if env := os.Getenv(RunSecretEnv); env != "" {
+			runSecret = []byte(env)
+			return
+		}
+		runSecret = make([]byte, sha256.Size)
+		_, _ = rand.Read(runSecret)
+	})
+	return runSecret
+}
+
+// MarkBio appends the run tag to bio.
+func MarkBio(id, bio string) string {
+	tag := "[e2e-run:" + id + "]"
+	if bio == "" {
+		return tag
+	}
+	return bio + " " + tag
+}
+
+// MarkKept appends the keep tag to bio.
+func MarkKept(bio string) string {
+	if bio == "" {
+		return keepTag
+	}
+	return bio + " " + keepTag
+}
+
+// Kept reports whether u belongs to a persistent dataset.
+func Kept(u User) bool {
+	return strings.Contains(u.Bio, keepTag)
+}
+
+// RunIDOf returns the run that created u, read from the username prefix or,
+// failing that, from the bio tag.
+func RunIDOf(u User) (string, bool) {
+	if rest, ok := strings.CutPrefix(u.Username, MarkerPrefix); ok {
+		if id, _, ok := strings.Cut(rest, "_"); ok && ValidRunID(id) {
+			return id, true
+		}
+	}
+	if m := bioMarker.FindStringSubmatch(u.Bio); m != nil && ValidRunID(m[1]) {
+		return m[1], true
+	}
+	return "", false
+}
@@ -0,0 +1,67 @@
+package fixtures
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunMarker(t *testing.T) {
+	id := RunID()
+	assert.True(t, ValidRunID(id))
+	assert.Equal(t, id, RunID(), "run ID is fixed for the process")
+
+	req := NewGenerator(1).GenerateRegisterRequest()
+	assert.True(t, strings.HasPrefix(req.Username, UsernamePrefix(id)))
+	assert.LessOrEqual(t, len(req.Username), len(UsernamePrefix(id))+markedNameLength)
+	assert.True(t, Accepts(MustConstraintOf(RegisterRequest{}, "Username"), req.Username))
+
+	got, ok := RunIDOf(User{Username: req.Username})
+	assert.True(t, ok)
+	assert.Equal(t, id, got)
+
+	got, ok = RunIDOf(User{Username: "renamed", Bio: req.Bio})
+	assert.True(t, ok, "bio tag is the fallback")
+	assert.Equal(t, id, got)
+
+	_, ok = RunIDOf(User{Username: "e2eplain", Bio: "no tag"})
+	assert.False(t, ok)
+	_, ok = RunIDOf(User{Username: "e2eTOOLONGID_x"})
+	assert.False(t, ok)
+}
+
+func TestMarkUsernameTruncates(t *testing.T) {
+	long := strings.Repeat("x", 2*markedNameLength)
+	marked := MarkUsername("abc123", long)
+	assert.Len(t, marked, len(UsernamePrefix("abc123"))+markedNameLength)
+
+	id, ok := RunIDOf(User{Username: marked})
+	assert.True(t, ok, "the name is cut, not the marker")
+	assert.Equal(t, "abc123", id)
+}
+
+func TestMarkKept(t *testing.T) {
+	bio := MarkKept(MarkBio("abc123", "bio"))
+	assert.True(t, Kept(User{Bio: bio}))
+	assert.False(t, Kept(User{Bio: MarkBio("abc123", "bio")}))
+
+	id, ok := RunIDOf(User{Username: "renamed", Bio: bio})
+	assert.True(t, ok, "kept users still carry their run")
+	assert.Equal(t, "abc123", id)
+}
+
+func TestRunPassword(t *testing.T) {
+	assert.Equal(t, RunPassword("abc123"), RunPassword("abc123"))
+	assert.NotEqual(t, RunPassword("abc123"), RunPassword("abc124"))
+	assert.True(t, Accepts(MustConstraintOf(RegisterRequest{}, "Password"), RunPassword("abc123")))
+
+	unkeyed := sha256.Sum256([]byte("e2e-run:abc123"))
+	assert.NotEqual(t, "E2e!"+hex.EncodeToString(unkeyed[:8]), RunPassword("abc123"),
+		"the run ID alone must not give the password away")
+
+	req := NewGenerator(1).GenerateRegisterRequest()
+	assert.Equal(t, RunPassword(RunID()), req.Password)
+}
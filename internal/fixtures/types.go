@@ -4,13 +4,15 @@ import "time"
 
 // ========= Auth Types =========
 
+// RegisterRequest declares no username maximum length: it is unconfirmed, see
+// the limits in constraints.go.
 type RegisterRequest struct {
-	Username  string `json:"username"`
-	Email     string `json:"email"`
-	Password  string `json:"password"`
+	Username  string `json:"username" constraint:"required,min=3"`
+	Email     string `json:"email" constraint:"required,format=email"`
+	Password  string `json:"password" constraint:"required,min=6"`
 	FullName  string `json:"full_name,omitempty"`
 	Bio       string `json:"bio,omitempty"`
-	AvatarURL string `json:"avatar_url,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty" constraint:"format=url"`
 }
 
 type RegisterResponse struct {
@@ -42,8 +44,8 @@ type LogoutRequest struct {
 }
 
 type UpdatePasswordRequest struct {
-	OldPassword string `json:"old_password"`
-	NewPassword string `json:"new_password"`
+	OldPassword string `json:"old_password" constraint:"required"`
+	NewPassword string `json:"new_password" constraint:"required,min=6"`
 }
 
 type UpdatePasswordResponse struct {
@@ -64,20 +66,20 @@ type User struct {
 }
 
 type CreateUserRequest struct {
-	Username  string `json:"username"`
-	Email     string `json:"email"`
-	Password  string `json:"password"`
+	Username  string `json:"username" constraint:"required,min=3"`
+	Email     string `json:"email" constraint:"required,format=email"`
+	Password  string `json:"password" constraint:"required,min=6"`
 	FullName  string `json:"full_name,omitempty"`
 	Bio       string `json:"bio,omitempty"`
-	AvatarURL string `json:"avatar_url,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty" constraint:"format=url"`
 }
 
 type CreateUserResponse User
 
 type UpdateUserRequest struct {
-	ID       int64  `json:"id"`
-	Username string `json:"username,omitempty"`
-	Email    string `json:"email,omitempty"`
+	ID       int64  `json:"id" constraint:"required,min=1"`
+	Username string `json:"username,omitempty" constraint:"min=3"`
+	Email    string `json:"email,omitempty" constraint:"format=email"`
 	FullName string `json:"full_name,omitempty"`
 	Bio      string `json:"bio,omitempty"`
 }
@@ -85,7 +87,7 @@ type UpdateUserRequest struct {
 type UpdateUserResponse User
 
 type UpdateAvatarRequest struct {
-	AvatarURL string `json:"avatar_url"`
+	AvatarURL string `json:"avatar_url" constraint:"required,format=url"`
 }
 
 type SearchUsersResponse struct {
@@ -96,8 +98,8 @@ type SearchUsersResponse struct {
 // ========= Post Types =========
 
 type MediaItemInput struct {
-	Type     string `json:"type"`
-	URL      string `json:"url"`
+	Type     string `json:"type" constraint:"required,oneof=image video"`
+	URL      string `json:"url" constraint:"required,format=url"`
 	Position int    `json:"position,omitempty"`
 }
 
@@ -131,8 +133,10 @@ type Post struct {
 	UpdatedAt time.Time   `json:"updated_at"`
 }
 
+// CreatePostRequest declares no maximum number of media items: it is
+// unconfirmed, see the limits in constraints.go.
 type CreatePostRequest struct {
-	Title      string           `json:"title"`
+	Title      string           `json:"title" constraint:"required,max=255"`
 	Content    string           `json:"content,omitempty"`
 	MediaItems []MediaItemInput `json:"media_items,omitempty"`
 	Tags       []string         `json:"tags,omitempty"`
@@ -153,7 +157,7 @@ type CreatePostResponse struct {
 }
 
 type UpdatePostRequest struct {
-	Title      string           `json:"title,omitempty"`
+	Title      string           `json:"title,omitempty" constraint:"max=255"`
 	Content    string           `json:"content,omitempty"`
 	MediaItems []MediaItemInput `json:"media_items,omitempty"`
 	Tags       []string         `json:"tags,omitempty"`
@@ -217,8 +221,8 @@ type Notification struct {
 }
 
 type SendNotificationRequest struct {
-	UserID  int64       `json:"user_id"`
-	Type    string      `json:"type"`
+	UserID  int64       `json:"user_id" constraint:"required,min=1"`
+	Type    string      `json:"type" constraint:"required,oneof=follow_created like comment mention system"`
 	Payload interface{} `json:"payload,omitempty"`
 }
 
@@ -0,0 +1,21 @@
+package histogram
+
+import (
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+)
+
+// RecordClients records the latency of every client request of the process
+// and returns a function that saves them as source into cfg.Dir. Without a
+// Dir nothing is recorded and save does nothing.
+func RecordClients(cfg config.Perf, source string) (save func() error) {
+	if cfg.Dir == "" {
+		return func() error { return nil }
+	}
+	set := NewSet()
+	client.SetObserver(set.Record)
+	return func() error {
+		client.SetObserver(nil)
+		return set.Save(cfg.Dir, source, cfg.Label)
+	}
+}
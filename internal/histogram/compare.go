@@ -0,0 +1,163 @@
+package histogram
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+)
+
+// Thresholds decide when an endpoint counts as regressed; see config.Perf.
+type Thresholds struct {
+	P95Growth  float64
+	P99Growth  float64
+	Alpha      float64
+	MinSamples int
+}
+
+func ThresholdsFrom(p config.Perf) Thresholds {
+	return Thresholds{P95Growth: p.P95Growth, P99Growth: p.P99Growth, Alpha: p.Alpha, MinSamples: p.MinSamples}
+}
+
+// Status is the outcome of comparing one endpoint.
+type Status string
+
+const (
+	StatusOK        Status = "ok"
+	StatusRegressed Status = "REGRESSED"
+	// StatusTooFew marks endpoints with fewer than MinSamples values on
+	// either side; they are reported but not judged.
+	StatusTooFew Status = "too few samples"
+	// StatusNew and StatusGone mark endpoints found on one side only.
+	StatusNew  Status = "new"
+	StatusGone Status = "gone"
+)
+
+// Comparison is the outcome for one endpoint.
+type Comparison struct {
+	Endpoint            string
+	Status              Status
+	BaseCount, CurCount uint64
+	BaseP95, CurP95     time.Duration
+	BaseP99, CurP99     time.Duration
+	// PValue is the one-sided Mann-Whitney p-value for the current latencies
+	// being larger than the baseline ones.
+	PValue float64
+}
+
+// Report compares every endpoint found in either run.
+type Report struct {
+	Endpoints []Comparison
+}
+
+// Regressed returns the endpoints that regressed.
+func (r Report) Regressed() []Comparison {
+	var out []Comparison
+	for _, c := range r.Endpoints {
+		if c.Status == StatusRegressed {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func (r Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-40s %9s %9s %9s %9s %9s %9s %8s  %s\n",
+		"endpoint", "n base", "n cur", "p95 base", "p95 cur", "p99 base", "p99 cur", "p", "status")
+	for _, c := range r.Endpoints {
+		fmt.Fprintf(&b, "%-40s %9d %9d %9s %9s %9s %9s %8.4f  %s\n",
+			c.Endpoint, c.BaseCount, c.CurCount,
+			round(c.BaseP95), round(c.CurP95), round(c.BaseP99), round(c.CurP99), c.PValue, c.Status)
+	}
+	return b.String()
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(10 * time.Microsecond)
+}
+
+// Compare checks cur against base. An endpoint regresses when its p95 or p99
+// grew beyond the thresholds and the shift of the whole distribution is
+// significant at th.Alpha, so one unlucky tail sample does not fail a
+// release on its own.
+func Compare(base, cur *Set, th Thresholds) Report {
+	seen := make(map[string]bool)
+	var endpoints []string
+	for _, e := range append(base.Endpoints(), cur.Endpoints()...) {
+		if !seen[e] {
+			seen[e] = true
+			endpoints = append(endpoints, e)
+		}
+	}
+
+	var r Report
+	for _, e := range endpoints {
+		b, c := base.Get(e), cur.Get(e)
+		cmp := Comparison{Endpoint: e, PValue: 1}
+		if b != nil {
+			cmp.BaseCount, cmp.BaseP95, cmp.BaseP99 = b.Count(), b.Quantile(95), b.Quantile(99)
+		}
+		if c != nil {
+			cmp.CurCount, cmp.CurP95, cmp.CurP99 = c.Count(), c.Quantile(95), c.Quantile(99)
+		}
+
+		switch {
+		case b == nil:
+			cmp.Status = StatusNew
+		case c == nil:
+			cmp.Status = StatusGone
+		case cmp.BaseCount < uint64(th.MinSamples) || cmp.CurCount < uint64(th.MinSamples):
+			cmp.Status = StatusTooFew
+		default:
+			cmp.PValue = mannWhitneyGreater(b, c)
+			grew := exceeds(cmp.BaseP95, cmp.CurP95, th.P95Growth) || exceeds(cmp.BaseP99, cmp.CurP99, th.P99Growth)
+			cmp.Status = StatusOK
+			if grew && cmp.PValue < th.Alpha {
+				cmp.Status = StatusRegressed
+			}
+		}
+		r.Endpoints = append(r.Endpoints, cmp)
+	}
+	sort.Slice(r.Endpoints, func(i, j int) bool { return r.Endpoints[i].Endpoint < r.Endpoints[j].Endpoint })
+	return r
+}
+
+func exceeds(base, cur time.Duration, factor float64) bool {
+	return float64(cur) > float64(base)*factor
+}
+
+// mannWhitneyGreater returns the one-sided p-value of the Mann-Whitney U test
+// for values of b tending to be larger than those of a, using the normal
+// approximation with tie correction. Values in one bucket count as ties.
+func mannWhitneyGreater(a, b *Histogram) float64 {
+	n1, n2 := float64(a.total), float64(b.total)
+	n := n1 + n2
+
+	// u counts the pairs in which the b value is larger, ties as halves.
+	var u, aBelow, ties float64
+	for i := 0; i < max(len(a.counts), len(b.counts)); i++ {
+		ai, bi := bucket(a, i), bucket(b, i)
+		u += bi*aBelow + ai*bi/2
+		aBelow += ai
+		t := ai + bi
+		ties += t*t*t - t
+	}
+
+	variance := n1 * n2 / 12 * ((n + 1) - ties/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+	z := (u - n1*n2/2 - 0.5) / math.Sqrt(variance)
+	return math.Erfc(z/math.Sqrt2) / 2
+}
+
+func bucket(h *Histogram, i int) float64 {
+	if i >= len(h.counts) {
+		return 0
+	}
+	return float64(h.counts[i])
+}
@@ -0,0 +1,162 @@
+package histogram
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Ext is the extension of histogram files: gzip-compressed JSON holding only
+// the non-empty buckets of every endpoint.
+const Ext = ".hist.json.gz"
+
+const fileVersion = 1
+
+// Meta describes the run a histogram file came from.
+type Meta struct {
+	// Source is the suite package or load profile that recorded the file.
+	Source string `json:"source"`
+	// Label names what was measured, such as a gateway release.
+	Label     string    `json:"label,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type fileJSON struct {
+	Version int `json:"version"`
+	Meta
+	Unit      string                   `json:"unit"`
+	Endpoints map[string]histogramJSON `json:"endpoints"`
+}
+
+type histogramJSON struct {
+	Count uint64 `json:"count"`
+	Min   int64  `json:"min"`
+	Max   int64  `json:"max"`
+	Sum   int64  `json:"sum"`
+	// Buckets are [index, count] pairs of the non-empty buckets.
+	Buckets [][2]uint64 `json:"buckets"`
+}
+
+// WriteFile stores every histogram of s in path.
+func (s *Set) WriteFile(path string, meta Meta) error {
+	doc := fileJSON{Version: fileVersion, Meta: meta, Unit: "us", Endpoints: make(map[string]histogramJSON)}
+
+	s.mu.Lock()
+	for endpoint, h := range s.hists {
+		hj := histogramJSON{Count: h.total, Min: h.min, Max: h.max, Sum: h.sum}
+		for i, c := range h.counts {
+			if c > 0 {
+				hj.Buckets = append(hj.Buckets, [2]uint64{uint64(i), c})
+			}
+		}
+		doc.Endpoints[endpoint] = hj
+	}
+	s.mu.Unlock()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create histogram file: %w", err)
+	}
+	defer f.Close()
+
+	zw := gzip.NewWriter(f)
+	if err := json.NewEncoder(zw).Encode(doc); err != nil {
+		return fmt.Errorf("encode histogram file %s: %w", path, err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("write histogram file %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// Save writes s to dir as the file of source, creating dir if needed.
+func (s *Set) Save(dir, source, label string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create histogram dir: %w", err)
+	}
+	return s.WriteFile(filepath.Join(dir, source+Ext), Meta{Source: source, Label: label, CreatedAt: time.Now().UTC()})
+}
+
+// ReadFile reads a file written by WriteFile.
+func ReadFile(path string) (*Set, Meta, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, Meta{}, fmt.Errorf("open histogram file: %w", err)
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, Meta{}, fmt.Errorf("read histogram file %s: %w", path, err)
+	}
+	var doc fileJSON
+	if err := json.NewDecoder(zr).Decode(&doc); err != nil {
+		return nil, Meta{}, fmt.Errorf("decode histogram file %s: %w", path, err)
+	}
+	if doc.Version != fileVersion {
+		return nil, Meta{}, fmt.Errorf("histogram file %s: unsupported version %d", path, doc.Version)
+	}
+
+	s := NewSet()
+	for endpoint, hj := range doc.Endpoints {
+		h := &Histogram{total: hj.Count, min: hj.Min, max: hj.Max, sum: hj.Sum}
+		var total uint64
+		for _, b := range hj.Buckets {
+			i := int(b[0])
+			if i >= len(h.counts) {
+				h.counts = append(h.counts, make([]uint64, i+1-len(h.counts))...)
+			}
+			h.counts[i] += b[1]
+			total += b[1]
+		}
+		if total != hj.Count {
+			return nil, Meta{}, fmt.Errorf("histogram file %s: %s has %d values in buckets, count says %d", path, endpoint, total, hj.Count)
+		}
+		s.hists[endpoint] = h
+	}
+	return s, doc.Meta, nil
+}
+
+// Load reads histogram files and merges them into one set. A directory
+// contributes every file with Ext in it.
+func Load(paths ...string) (*Set, []Meta, error) {
+	var files []string
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, p)
+			continue
+		}
+		entries, err := os.ReadDir(p)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, e := range entries {
+			if !e.IsDir() && strings.HasSuffix(e.Name(), Ext) {
+				files = append(files, filepath.Join(p, e.Name()))
+			}
+		}
+	}
+	if len(files) == 0 {
+		return nil, nil, fmt.Errorf("no histogram files in %v", paths)
+	}
+
+	merged := NewSet()
+	metas := make([]Meta, 0, len(files))
+	for _, f := range files {
+		s, meta, err := ReadFile(f)
+		if err != nil {
+			return nil, nil, err
+		}
+		merged.Merge(s)
+		metas = append(metas, meta)
+	}
+	return merged, metas, nil
+}
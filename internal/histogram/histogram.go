@@ -0,0 +1,182 @@
+// Package histogram records request latencies per endpoint in HDR-style
+// histograms, stores them in compact files and compares a run against a
+// baseline to catch latency regressions.
+package histogram
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+	"sync"
+	"time"
+)
+
+// subBits sets the precision: every power of two is split into 2^subBits
+// buckets, so a recorded value is off by at most 1/128 of itself.
+const subBits = 7
+
+const subCount = 1 << subBits
+
+// Histogram counts latencies in microseconds in log-linear buckets. Values
+// below subCount microseconds are exact. The zero value is ready to use; it is
+// not safe for concurrent use.
+type Histogram struct {
+	counts []uint64
+	total  uint64
+	min    int64
+	max    int64
+	sum    int64
+}
+
+func index(v int64) int {
+	if v < subCount {
+		return int(v)
+	}
+	exp := bits.Len64(uint64(v)) - subBits - 1
+	return (exp+1)<<subBits + int(v>>exp-subCount)
+}
+
+// lowest and highest return the range of values that land in bucket i.
+func lowest(i int) int64 {
+	if i < subCount {
+		return int64(i)
+	}
+	exp := i>>subBits - 1
+	return (int64(i&(subCount-1)) + subCount) << exp
+}
+
+func highest(i int) int64 {
+	return lowest(i+1) - 1
+}
+
+// Record adds one latency. Negative latencies are recorded as zero.
+func (h *Histogram) Record(d time.Duration) {
+	v := max(d.Microseconds(), 0)
+	i := index(v)
+	if i >= len(h.counts) {
+		h.counts = append(h.counts, make([]uint64, i+1-len(h.counts))...)
+	}
+	if h.total == 0 || v < h.min {
+		h.min = v
+	}
+	if v > h.max {
+		h.max = v
+	}
+	h.counts[i]++
+	h.total++
+	h.sum += v
+}
+
+// Merge adds every value of o to h.
+func (h *Histogram) Merge(o *Histogram) {
+	if o.total == 0 {
+		return
+	}
+	if len(o.counts) > len(h.counts) {
+		h.counts = append(h.counts, make([]uint64, len(o.counts)-len(h.counts))...)
+	}
+	for i, c := range o.counts {
+		h.counts[i] += c
+	}
+	if h.total == 0 || o.min < h.min {
+		h.min = o.min
+	}
+	h.max = max(h.max, o.max)
+	h.total += o.total
+	h.sum += o.sum
+}
+
+// Count is the number of recorded values.
+func (h *Histogram) Count() uint64 {
+	return h.total
+}
+
+func (h *Histogram) Min() time.Duration { return us(h.min) }
+func (h *Histogram) Max() time.Duration { return us(h.max) }
+
+func (h *Histogram) Mean() time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+	return us(h.sum / int64(h.total))
+}
+
+// Quantile returns the nearest-rank p-th percentile, as the highest value of
+// its bucket so it never understates the latency.
+func (h *Histogram) Quantile(p float64) time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+	rank := max(uint64(math.Ceil(p/100*float64(h.total))), 1)
+
+	var seen uint64
+	for i, c := range h.counts {
+		seen += c
+		if seen >= rank {
+			return us(min(highest(i), h.max))
+		}
+	}
+	return us(h.max)
+}
+
+func us(v int64) time.Duration {
+	return time.Duration(v) * time.Microsecond
+}
+
+// Set holds one histogram per endpoint. It is safe for concurrent use.
+type Set struct {
+	mu    sync.Mutex
+	hists map[string]*Histogram
+}
+
+func NewSet() *Set {
+	return &Set{hists: make(map[string]*Histogram)}
+}
+
+// Record adds a latency of endpoint. Its signature matches client.Observer.
+func (s *Set) Record(endpoint string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.hists[endpoint]
+	if !ok {
+		h = &Histogram{}
+		s.hists[endpoint] = h
+	}
+	h.Record(d)
+}
+
+// Merge adds every histogram of o to s.
+func (s *Set) Merge(o *Set) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for endpoint, h := range o.hists {
+		mine, ok := s.hists[endpoint]
+		if !ok {
+			mine = &Histogram{}
+			s.hists[endpoint] = mine
+		}
+		mine.Merge(h)
+	}
+}
+
+// Endpoints returns the recorded endpoints in order.
+func (s *Set) Endpoints() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	endpoints := make([]string, 0, len(s.hists))
+	for e := range s.hists {
+		endpoints = append(endpoints, e)
+	}
+	sort.Strings(endpoints)
+	return endpoints
+}
+
+// Get returns the histogram of endpoint, or nil. It must not be modified
+// while the set is still recording.
+func (s *Set) Get(endpoint string) *Histogram {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hists[endpoint]
+}
@@ -0,0 +1,137 @@
+package histogram
+
+import (
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/latency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketsCoverValuesContiguously(t *testing.T) {
+	for i := 0; i < 40*subCount; i++ {
+		lo, hi := lowest(i), highest(i)
+		require.LessOrEqual(t, lo, hi)
+		require.Equal(t, i, index(lo), "bucket %d", i)
+		require.Equal(t, i, index(hi), "bucket %d", i)
+		require.Equal(t, i+1, index(hi+1), "bucket %d", i)
+		if lo > 0 {
+			require.LessOrEqual(t, float64(hi-lo)/float64(lo), 1.0/subCount)
+		}
+	}
+}
+
+func TestQuantileMatchesExactPercentiles(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var (
+		h       Histogram
+		samples []time.Duration
+	)
+	for i := 0; i < 20000; i++ {
+		d := time.Duration(r.ExpFloat64()*float64(30*time.Millisecond)) + time.Millisecond
+		h.Record(d)
+		samples = append(samples, d)
+	}
+	exact := latency.Summarize(samples)
+
+	for p, want := range map[float64]time.Duration{50: exact.P50, 95: exact.P95, 99: exact.P99} {
+		got := h.Quantile(p)
+		assert.GreaterOrEqual(t, got, want.Truncate(time.Microsecond), "p%g", p)
+		assert.InEpsilon(t, float64(want), float64(got), 1.0/subCount, "p%g", p)
+	}
+	assert.Equal(t, exact.Max.Truncate(time.Microsecond), h.Max())
+	assert.EqualValues(t, 20000, h.Count())
+}
+
+func TestFileRoundTrip(t *testing.T) {
+	s := NewSet()
+	for i := 1; i <= 500; i++ {
+		s.Record("GET /v1/users/{id}", time.Duration(i)*time.Millisecond)
+	}
+	s.Record("POST /v1/posts", 3*time.Second)
+
+	path := filepath.Join(t.TempDir(), "run"+Ext)
+	require.NoError(t, s.WriteFile(path, Meta{Source: "gateway_user", Label: "v1.2.0"}))
+
+	got, meta, err := ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.0", meta.Label)
+	assert.Equal(t, s.Endpoints(), got.Endpoints())
+	for _, e := range s.Endpoints() {
+		want, h := s.Get(e), got.Get(e)
+		assert.Equal(t, want.Count(), h.Count())
+		assert.Equal(t, want.Quantile(99), h.Quantile(99))
+		assert.Equal(t, want.Mean(), h.Mean())
+	}
+
+	// Files of one directory merge into one set.
+	require.NoError(t, s.Save(filepath.Dir(path), "second", ""))
+	merged, metas, err := Load(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, metas, 2)
+	assert.EqualValues(t, 1000, merged.Get("GET /v1/users/{id}").Count())
+}
+
+// sample records n latencies around median with a tail.
+func sample(s *Set, endpoint string, seed int64, n int, median time.Duration) {
+	r := rand.New(rand.NewSource(seed))
+	for i := 0; i < n; i++ {
+		s.Record(endpoint, time.Duration(float64(median)*(0.5+r.ExpFloat64()/1.4)))
+	}
+}
+
+func TestCompare(t *testing.T) {
+	th := Thresholds{P95Growth: 1.2, P99Growth: 1.3, Alpha: 0.01, MinSamples: 50}
+	base, cur := NewSet(), NewSet()
+
+	sample(base, "GET /steady", 1, 2000, 20*time.Millisecond)
+	sample(cur, "GET /steady", 2, 2000, 20*time.Millisecond)
+
+	sample(base, "GET /slower", 3, 2000, 20*time.Millisecond)
+	sample(cur, "GET /slower", 4, 2000, 30*time.Millisecond)
+
+	sample(base, "GET /faster", 5, 2000, 30*time.Millisecond)
+	sample(cur, "GET /faster", 6, 2000, 20*time.Millisecond)
+
+	// A few slow requests among few samples are not enough to judge.
+	sample(base, "GET /rare", 7, 20, 20*time.Millisecond)
+	sample(cur, "GET /rare", 8, 20, 60*time.Millisecond)
+
+	sample(base, "GET /removed", 9, 100, 20*time.Millisecond)
+	sample(cur, "GET /added", 10, 100, 20*time.Millisecond)
+
+	r := Compare(base, cur, th)
+	status := map[string]Status{}
+	for _, c := range r.Endpoints {
+		status[c.Endpoint] = c.Status
+	}
+	assert.Equal(t, map[string]Status{
+		"GET /steady":  StatusOK,
+		"GET /slower":  StatusRegressed,
+		"GET /faster":  StatusOK,
+		"GET /rare":    StatusTooFew,
+		"GET /removed": StatusGone,
+		"GET /added":   StatusNew,
+	}, status, r.String())
+
+	regressed := r.Regressed()
+	require.Len(t, regressed, 1)
+	assert.Less(t, regressed[0].PValue, 1e-6)
+	assert.Contains(t, r.String(), "REGRESSED")
+}
+
+func TestMannWhitneyNeedsAShift(t *testing.T) {
+	a, b := NewSet(), NewSet()
+	sample(a, "x", 1, 500, 20*time.Millisecond)
+	sample(b, "x", 2, 500, 20*time.Millisecond)
+	assert.Greater(t, mannWhitneyGreater(a.Get("x"), b.Get("x")), 0.01)
+
+	// A slower tail alone barely moves the ranks.
+	for i := 0; i < 5; i++ {
+		b.Record("x", time.Second)
+	}
+	assert.Greater(t, mannWhitneyGreater(a.Get("x"), b.Get("x")), 0.01)
+}
@@ -0,0 +1,69 @@
+// Package latency summarizes measured durations into the percentiles the
+// suites report and assert on.
+package latency
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Summary describes a set of latency samples.
+type Summary struct {
+	Count int
+	Min   time.Duration
+	Max   time.Duration
+	Mean  time.Duration
+	P50   time.Duration
+	P90   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+}
+
+// Summarize computes a Summary. It does not modify samples.
+func Summarize(samples []time.Duration) Summary {
+	if len(samples) == 0 {
+		return Summary{}
+	}
+
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	return Summary{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Max:   sorted[len(sorted)-1],
+		Mean:  total / time.Duration(len(sorted)),
+		P50:   Percentile(sorted, 50),
+		P90:   Percentile(sorted, 90),
+		P95:   Percentile(sorted, 95),
+		P99:   Percentile(sorted, 99),
+	}
+}
+
+// Percentile returns the nearest-rank p-th percentile of sorted, which must
+// be in ascending order.
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+func (s Summary) String() string {
+	return fmt.Sprintf("n=%d min=%s p50=%s p90=%s p95=%s p99=%s max=%s mean=%s",
+		s.Count, s.Min, s.P50, s.P90, s.P95, s.P99, s.Max, s.Mean)
+}
@@ -0,0 +1,33 @@
+package latency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarize(t *testing.T) {
+	var samples []time.Duration
+	for i := 100; i >= 1; i-- {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+
+	s := Summarize(samples)
+	assert.Equal(t, 100, s.Count)
+	assert.Equal(t, time.Millisecond, s.Min)
+	assert.Equal(t, 100*time.Millisecond, s.Max)
+	assert.Equal(t, 50500*time.Microsecond, s.Mean)
+	assert.Equal(t, 50*time.Millisecond, s.P50)
+	assert.Equal(t, 90*time.Millisecond, s.P90)
+	assert.Equal(t, 99*time.Millisecond, s.P99)
+	assert.Equal(t, 100*time.Millisecond, samples[0], "input is not reordered")
+}
+
+func TestSummarizeSmall(t *testing.T) {
+	assert.Equal(t, Summary{}, Summarize(nil))
+
+	s := Summarize([]time.Duration{3 * time.Second})
+	assert.Equal(t, 3*time.Second, s.P50)
+	assert.Equal(t, 3*time.Second, s.P99)
+}
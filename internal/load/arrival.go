@@ -0,0 +1,237 @@
+package load
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/latency"
+)
+
+// Stage is one stage of an open-model profile: the arrival rate moves
+// linearly from FromRPS to ToRPS over Duration.
+type Stage struct {
+	Name     string
+	Duration time.Duration
+	FromRPS  float64
+	ToRPS    float64
+}
+
+// TargetRPS is the mean arrival rate the stage asks for.
+func (s Stage) TargetRPS() float64 {
+	return (s.FromRPS + s.ToRPS) / 2
+}
+
+// Arrivals is the number of operations the stage schedules.
+func (s Stage) Arrivals() int {
+	return int(s.TargetRPS() * s.Duration.Seconds())
+}
+
+// Offset returns when arrival k of the stage, counted from zero, is due
+// relative to the start of the stage. Arrivals are evenly spaced along the
+// cumulative rate, so they get denser as a ramp climbs.
+func (s Stage) Offset(k int) time.Duration {
+	a, n := s.FromRPS, float64(k)
+	// The cumulative count is a*t + c*t², solved for t.
+	c := (s.ToRPS - s.FromRPS) / (2 * s.Duration.Seconds())
+	var t float64
+	if c == 0 {
+		t = n / a
+	} else {
+		t = (-a + math.Sqrt(a*a+4*c*n)) / (2 * c)
+	}
+	return time.Duration(t * float64(time.Second))
+}
+
+// Profile is an open-model run: stages played back to back.
+type Profile struct {
+	Name   string
+	Stages []Stage
+	// MaxBacklog is how many arrivals may wait for a free VU before further
+	// ones are dropped.
+	MaxBacklog int
+	// MinAchievedRatio is the share of a stage's target rate it must complete
+	// not to count as saturated.
+	MinAchievedRatio float64
+}
+
+// ProfileFrom returns the profile called name in cfg.
+func ProfileFrom(cfg config.Arrival, name string) (Profile, error) {
+	stages, ok := cfg.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(cfg.Profiles))
+		for n := range cfg.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return Profile{}, fmt.Errorf("unknown arrival profile %q, have %v", name, names)
+	}
+	p := Profile{Name: name, MaxBacklog: cfg.MaxBacklog, MinAchievedRatio: cfg.MinAchievedRatio}
+	for _, st := range stages {
+		p.Stages = append(p.Stages, Stage{Name: st.Name, Duration: st.Duration, FromRPS: st.FromRPS, ToRPS: st.ToRPS})
+	}
+	return p, nil
+}
+
+// StageResult is what one stage of an open-model run achieved.
+type StageResult struct {
+	Stage
+	// Scheduled counts the arrivals due in the stage and Dropped those that
+	// found the backlog full.
+	Scheduled int
+	Dropped   int
+	// Completed counts operations that finished within the stage, whenever
+	// they were scheduled; AchievedRPS is the rate they make.
+	Completed   int
+	AchievedRPS float64
+	// Errors counts failed operations among the stage's arrivals.
+	Errors int
+	// Latency summarizes the stage's operations from their scheduled start to
+	// their end, so time spent queueing behind a slow gateway is counted.
+	Latency latency.Summary
+	// Saturated is set when arrivals were dropped or the achieved rate fell
+	// below MinAchievedRatio of the target.
+	Saturated bool
+}
+
+func (s StageResult) String() string {
+	mark := ""
+	if s.Saturated {
+		mark = "  SATURATED"
+	}
+	return fmt.Sprintf("%-12s target %7.1f rps  achieved %7.1f rps  scheduled %d  dropped %d  errors %d  %s%s",
+		s.Name, s.TargetRPS(), s.AchievedRPS, s.Scheduled, s.Dropped, s.Errors, s.Latency, mark)
+}
+
+// SaturationPoint returns the first saturated stage.
+func SaturationPoint(results []StageResult) (StageResult, bool) {
+	for _, r := range results {
+		if r.Saturated {
+			return r, true
+		}
+	}
+	return StageResult{}, false
+}
+
+type arrival struct {
+	stage int
+	due   time.Time
+}
+
+type outcome struct {
+	stage int
+	due   time.Time
+	end   time.Time
+	err   error
+}
+
+// RunProfile drives the VUs as an open model: operations arrive on p's
+// schedule whatever the gateway's latency and go to the next free VU. Each
+// operation's first call is measured from its scheduled start rather than
+// from when a VU got to it, so a saturated gateway shows up as latency
+// instead of a silently lower rate. Arrivals that find p.MaxBacklog
+// operations waiting are dropped. If ctx ends early, the results cover what
+// ran and its error is returned.
+func (r *Runner) RunProfile(ctx context.Context, p Profile) ([]StageResult, error) {
+	if err := r.Mix.Validate(); err != nil {
+		return nil, err
+	}
+	if len(r.VUs) == 0 {
+		return nil, fmt.Errorf("profile %s: no VUs", p.Name)
+	}
+	backlog := p.MaxBacklog
+	if backlog <= 0 {
+		backlog = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		outcomes []outcome
+		queue    = make(chan arrival, backlog)
+	)
+	for _, v := range r.VUs {
+		wg.Add(1)
+		go func(v *VU) {
+			defer wg.Done()
+			v.User.API.SetContext(ctx)
+			v.observe = r.Observe
+			for a := range queue {
+				if ctx.Err() != nil {
+					continue
+				}
+				err := r.step(v, a.due)
+				o := outcome{stage: a.stage, due: a.due, end: time.Now(), err: err}
+				mu.Lock()
+				outcomes = append(outcomes, o)
+				mu.Unlock()
+			}
+		}(v)
+	}
+
+	results := make([]StageResult, len(p.Stages))
+	starts := make([]time.Time, len(p.Stages)+1)
+	starts[0] = time.Now()
+	for i, st := range p.Stages {
+		results[i].Stage = st
+		starts[i+1] = starts[i].Add(st.Duration)
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+schedule:
+	for i, st := range p.Stages {
+		for k, n := 0, st.Arrivals(); k < n; k++ {
+			due := starts[i].Add(st.Offset(k))
+			if d := time.Until(due); d > 0 {
+				timer.Reset(d)
+				select {
+				case <-ctx.Done():
+					break schedule
+				case <-timer.C:
+				}
+			}
+			results[i].Scheduled++
+			select {
+			case queue <- arrival{stage: i, due: due}:
+			default:
+				results[i].Dropped++
+			}
+		}
+		// Let a stage that schedules nothing, or stops short of its end,
+		// still take its full duration.
+		if d := time.Until(starts[i+1]); d > 0 {
+			timer.Reset(d)
+			select {
+			case <-ctx.Done():
+				break schedule
+			case <-timer.C:
+			}
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	latencies := make([][]time.Duration, len(p.Stages))
+	for _, o := range outcomes {
+		latencies[o.stage] = append(latencies[o.stage], o.end.Sub(o.due))
+		if o.err != nil {
+			results[o.stage].Errors++
+		}
+		// Completions are credited to the stage they finished in.
+		if i := sort.Search(len(p.Stages), func(i int) bool { return o.end.Before(starts[i+1]) }); i < len(p.Stages) {
+			results[i].Completed++
+		}
+	}
+	for i := range results {
+		res := &results[i]
+		res.Latency = latency.Summarize(latencies[i])
+		res.AchievedRPS = float64(res.Completed) / res.Duration.Seconds()
+		res.Saturated = res.Dropped > 0 || res.AchievedRPS < p.MinAchievedRatio*res.TargetRPS()
+	}
+	return results, ctx.Err()
+}
@@ -0,0 +1,324 @@
+package load
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnreadModel(t *testing.T) {
+	now := time.Unix(0, 0)
+	m := NewUnreadModel(time.Second)
+	m.now = func() time.Time { return now }
+	const u = 7
+
+	bounds := func() [2]int {
+		lo, hi := m.Bounds(u)
+		return [2]int{lo, hi}
+	}
+
+	token := m.BeginSend(u)
+	assert.Equal(t, [2]int{0, 1}, bounds(), "a send in flight may or may not have landed")
+	m.EndSend(u, token, true)
+	assert.Equal(t, [2]int{1, 1}, bounds())
+
+	m.Followed(u)
+	assert.Equal(t, [2]int{1, 2}, bounds(), "follow notification not yet due")
+	now = now.Add(2 * time.Second)
+	assert.Equal(t, [2]int{2, 2}, bounds(), "follow notification past due must have arrived")
+
+	token = m.BeginSend(u)
+	m.EndSend(u, token, false)
+	assert.Equal(t, [2]int{2, 3}, bounds(), "a failed send may still have been stored")
+
+	// A send that overlaps a read-all may land on either side of it.
+	m.BeginReadAll(u)
+	token = m.BeginSend(u)
+	m.EndSend(u, token, true)
+	m.EndReadAll(u, true)
+	assert.Equal(t, [2]int{0, 1}, bounds())
+
+	// A send begun before the read-all and finished after it is already
+	// accounted for.
+	token = m.BeginSend(u)
+	m.BeginReadAll(u)
+	m.EndReadAll(u, true)
+	m.EndSend(u, token, true)
+	assert.Equal(t, [2]int{0, 1}, bounds())
+
+	m.BeginReadAll(u)
+	m.EndReadAll(u, true)
+	token = m.BeginSend(u)
+	m.EndSend(u, token, true)
+	m.BeginReadAll(u)
+	m.EndReadAll(u, false)
+	assert.Equal(t, [2]int{0, 1}, bounds(), "a failed read-all leaves everything uncertain")
+
+	ok, off := Check(3, 0, 1)
+	assert.False(t, ok)
+	assert.Equal(t, 2, off)
+	ok, _ = Check(1, 0, 1)
+	assert.True(t, ok)
+}
+
+func TestMixPick(t *testing.T) {
+	mix := Mix{{Name: "a", Weight: 3}, {Name: "b", Weight: 1}, {Name: "never", Weight: 0}}
+	require.NoError(t, mix.Validate())
+
+	r := rand.New(rand.NewSource(1))
+	counts := map[string]int{}
+	for i := 0; i < 4000; i++ {
+		counts[mix.Pick(r).Name]++
+	}
+	assert.Zero(t, counts["never"])
+	assert.InDelta(t, 3000, counts["a"], 150)
+	assert.InDelta(t, 1000, counts["b"], 150)
+
+	assert.Error(t, Mix{{Name: "z", Weight: 0}}.Validate())
+	assert.Error(t, Mix{{Name: "n", Weight: -1}, {Name: "p", Weight: 2}}.Validate())
+}
+
+// fakeGateway answers the calls the default mix makes and keeps one unread
+// counter per token, which is the user ID.
+type fakeGateway struct {
+	mu     sync.Mutex
+	unread map[string]int
+	calls  map[string]int
+}
+
+func (g *fakeGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/api")
+	g.calls[r.Method+" "+path]++
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	write := func(data interface{}) {
+		_ = json.NewEncoder(w).Encode(fixtures.BaseResponse{Status: http.StatusOK, Data: data})
+	}
+
+	switch {
+	case path == "/v1/auth/login":
+		var req fixtures.LoginRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		write(fixtures.LoginResponse{AccessToken: req.Login, RefreshToken: req.Login})
+	case path == "/v1/auth/refresh":
+		var req fixtures.RefreshTokenRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		write(fixtures.RefreshTokenResponse{AccessToken: req.RefreshToken, RefreshToken: req.RefreshToken})
+	case strings.HasSuffix(path, "/followees"):
+		write(fixtures.GetFolloweesResponse{})
+	case path == "/v1/notification/read-all":
+		g.unread[token] = 0
+		write(fixtures.ReadAllUserNotificationsResponse{Success: true})
+	case path == "/v1/notification/unread-count":
+		write(fixtures.GetUnreadCountResponse{Count: g.unread[token]})
+	case path == "/v1/notification/send":
+		var req fixtures.SendNotificationRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		g.unread[usernameOf(req.UserID)]++
+		write(fixtures.SendNotificationResponse{NotificationID: 1})
+	case path == "/v1/posts" && r.Method == http.MethodPost:
+		write(fixtures.CreatePostResponse{ID: int64(g.calls["POST /v1/posts"])})
+	default:
+		write(map[string]string{"message": "ok"})
+	}
+}
+
+func usernameOf(id int64) string {
+	return "user" + strconv.FormatInt(id, 10)
+}
+
+func TestRunnerDrivesVUsAgainstModel(t *testing.T) {
+	gw := &fakeGateway{unread: map[string]int{}, calls: map[string]int{}}
+	srv := httptest.NewServer(gw)
+	defer srv.Close()
+
+	cfg := &config.Config{API: config.API{BaseURL: srv.URL + "/api", Timeout: time.Second}}
+	log := logger.ForTest(t)
+	ctx := context.Background()
+	gen := fixtures.NewGenerator(3)
+
+	var users []*factory.User
+	for id := int64(1); id <= 3; id++ {
+		users = append(users, factory.Attach(ctx, cfg, log, fixtures.User{ID: id, Username: usernameOf(id)}, "pw", "", ""))
+	}
+
+	// The fake does not notify on follows, so they must never come due.
+	model := NewUnreadModel(time.Hour)
+	var (
+		mu      sync.Mutex
+		samples []Sample
+	)
+	r := &Runner{
+		Mix:     DefaultMix(),
+		Refresh: time.Hour,
+		Observe: func(s Sample) {
+			mu.Lock()
+			samples = append(samples, s)
+			mu.Unlock()
+		},
+	}
+	for i, u := range users {
+		r.VUs = append(r.VUs, NewVU(i, u, users, gen, model))
+	}
+
+	require.NoError(t, r.Start(ctx, 2))
+	for i := 0; i < 200; i++ {
+		r.Step(r.VUs[i%len(r.VUs)])
+	}
+	require.NoError(t, r.Cleanup(ctx, 2))
+
+	var checks int
+	endpoints := map[string]bool{}
+	for _, s := range samples {
+		require.NoError(t, s.Err, "%s %s", s.Operation, s.Endpoint)
+		endpoints[s.Endpoint] = true
+		if s.Endpoint == "GET /v1/notification/unread-count" {
+			checks++
+			assert.False(t, s.Diverged, "unread count off by %d", s.Off)
+		}
+	}
+	assert.Positive(t, checks)
+	assert.True(t, endpoints["POST /v1/auth/login"])
+	assert.True(t, endpoints["POST /v1/notification/send"])
+
+	gw.mu.Lock()
+	defer gw.mu.Unlock()
+	created := gw.calls["POST /v1/posts"]
+	var deleted int
+	for call, n := range gw.calls {
+		if strings.HasPrefix(call, "DELETE /v1/posts/") {
+			deleted += n
+		}
+	}
+	assert.Equal(t, created, deleted, "every post a VU created is deleted by the end")
+}
+
+func TestStageOffsets(t *testing.T) {
+	hold := Stage{Duration: time.Minute, FromRPS: 100, ToRPS: 100}
+	assert.Equal(t, 6000, hold.Arrivals())
+	assert.Equal(t, 10*time.Millisecond, hold.Offset(1))
+	assert.Equal(t, 30*time.Second, hold.Offset(3000))
+
+	for _, ramp := range []Stage{
+		{Duration: 5 * time.Minute, FromRPS: 0, ToRPS: 200},
+		{Duration: 5 * time.Minute, FromRPS: 200, ToRPS: 0},
+	} {
+		n := ramp.Arrivals()
+		assert.Equal(t, 30000, n)
+		assert.Zero(t, ramp.Offset(0))
+		prev := time.Duration(-1)
+		for k := 0; k < n; k++ {
+			off := ramp.Offset(k)
+			require.Greater(t, off, prev, "arrival %d of %+v", k, ramp)
+			prev = off
+		}
+		assert.LessOrEqual(t, prev, ramp.Duration)
+	}
+
+	// Half the arrivals of a ramp from zero are due after 1/√2 of it.
+	up := Stage{Duration: 5 * time.Minute, FromRPS: 0, ToRPS: 200}
+	assert.InDelta(t, 212.13, up.Offset(15000).Seconds(), 0.01)
+}
+
+// sleepVUs returns n VUs whose only operation is one call taking service.
+func sleepVUs(t *testing.T, n int, service time.Duration) *Runner {
+	cfg := &config.Config{API: config.API{BaseURL: "http://127.0.0.1:0/api", Timeout: time.Second}}
+	gen := fixtures.NewGenerator(1)
+	r := &Runner{Mix: Mix{{Name: "sleep", Weight: 1, Do: func(v *VU) error {
+		return v.call("GET /sleep", func() error {
+			time.Sleep(service)
+			return nil
+		})
+	}}}}
+	for i := 0; i < n; i++ {
+		u := factory.Attach(context.Background(), cfg, logger.ForTest(t), fixtures.User{ID: int64(i + 1)}, "pw", "", "")
+		r.VUs = append(r.VUs, NewVU(i, u, nil, gen, nil))
+	}
+	return r
+}
+
+func TestRunProfileKeepsUp(t *testing.T) {
+	r := sleepVUs(t, 4, time.Millisecond)
+	results, err := r.RunProfile(context.Background(), Profile{
+		Name: "steady",
+		Stages: []Stage{
+			{Name: "ramp", Duration: 300 * time.Millisecond, FromRPS: 50, ToRPS: 150},
+			{Name: "hold", Duration: 300 * time.Millisecond, FromRPS: 100, ToRPS: 100},
+		},
+		MaxBacklog:       100,
+		MinAchievedRatio: 0.8,
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, res := range results {
+		assert.Equal(t, 30, res.Scheduled, res.Name)
+		assert.Zero(t, res.Dropped, res.Name)
+		assert.False(t, res.Saturated, res.String())
+	}
+	_, saturated := SaturationPoint(results)
+	assert.False(t, saturated)
+}
+
+func TestRunProfileCountsQueueing(t *testing.T) {
+	// One VU serving 20ms operations can complete 50 per second; asking for
+	// 100 must show up as latency growing with the backlog, not as 20ms.
+	const service = 20 * time.Millisecond
+	r := sleepVUs(t, 1, service)
+	var (
+		mu     sync.Mutex
+		queued time.Duration
+	)
+	r.Observe = func(s Sample) {
+		mu.Lock()
+		queued = max(queued, s.Queued)
+		mu.Unlock()
+	}
+
+	results, err := r.RunProfile(context.Background(), Profile{
+		Stages:           []Stage{{Name: "overload", Duration: 500 * time.Millisecond, FromRPS: 100, ToRPS: 100}},
+		MaxBacklog:       1000,
+		MinAchievedRatio: 0.95,
+	})
+	require.NoError(t, err)
+
+	res := results[0]
+	assert.Equal(t, 50, res.Scheduled)
+	assert.Zero(t, res.Dropped)
+	assert.True(t, res.Saturated, res.String())
+	assert.Less(t, res.AchievedRPS, 70.0)
+	assert.Greater(t, res.Latency.Max, 10*service, "latency must include time spent waiting for the VU")
+	assert.Greater(t, queued, 5*service)
+
+	point, ok := SaturationPoint(results)
+	assert.True(t, ok)
+	assert.Equal(t, "overload", point.Name)
+}
+
+func TestRunProfileDropsBeyondBacklog(t *testing.T) {
+	r := sleepVUs(t, 1, 50*time.Millisecond)
+	results, err := r.RunProfile(context.Background(), Profile{
+		Stages:           []Stage{{Duration: 200 * time.Millisecond, FromRPS: 200, ToRPS: 200}},
+		MaxBacklog:       2,
+		MinAchievedRatio: 0.5,
+	})
+	require.NoError(t, err)
+	assert.Positive(t, results[0].Dropped)
+	assert.True(t, results[0].Saturated)
+}
@@ -0,0 +1,152 @@
+package load
+
+import (
+	"sync"
+	"time"
+)
+
+// UnreadModel predicts each user's unread notification count from what the
+// virtual users did. Exact counts are impossible while other users send
+// concurrently and follows notify through the outbox, so it keeps a range:
+// notifications known to be unread, sends in flight, follow notifications
+// not yet due, and notifications that may or may not be unread (failed sends,
+// notifications racing a read-all).
+//
+// Only the user itself reads its count or marks everything read, so Bounds
+// and the read-all calls of one user never overlap.
+type UnreadModel struct {
+	mu         sync.Mutex
+	asyncBound time.Duration
+	users      map[int64]*unreadState
+	now        func() time.Time
+}
+
+type unreadState struct {
+	known     int
+	inFlight  int
+	uncertain int
+	// due holds the deadlines of follow notifications still on their way.
+	due []time.Time
+
+	// epoch changes at both ends of a read-all; sends begun in an earlier
+	// epoch were already accounted for as uncertain.
+	epoch   int
+	reading bool
+	// before is what the user had when the read-all started, in case it
+	// fails.
+	beforeKnown, beforeUncertain, beforeInFlight int
+}
+
+// NewUnreadModel returns a model in which asynchronous notifications must
+// arrive within asyncBound of the write that caused them.
+func NewUnreadModel(asyncBound time.Duration) *UnreadModel {
+	return &UnreadModel{asyncBound: asyncBound, users: make(map[int64]*unreadState), now: time.Now}
+}
+
+func (m *UnreadModel) state(userID int64) *unreadState {
+	s, ok := m.users[userID]
+	if !ok {
+		s = &unreadState{}
+		m.users[userID] = s
+	}
+	return s
+}
+
+// settle moves follow notifications that are past due into the known count.
+func (s *unreadState) settle(now time.Time) {
+	kept := s.due[:0]
+	for _, d := range s.due {
+		if now.After(d) {
+			s.known++
+		} else {
+			kept = append(kept, d)
+		}
+	}
+	s.due = kept
+}
+
+// BeginSend records a notification about to be sent to userID and returns a
+// token to pass to EndSend with the outcome.
+func (m *UnreadModel) BeginSend(userID int64) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.state(userID)
+	s.inFlight++
+	return s.epoch
+}
+
+// EndSend records the outcome of a send begun with BeginSend. A failed send
+// may still have been stored, and one that overlapped a read-all may or may
+// not have been read, so both become uncertain.
+func (m *UnreadModel) EndSend(userID int64, token int, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.state(userID)
+	if token != s.epoch {
+		return
+	}
+	s.inFlight--
+	if ok && !s.reading {
+		s.known++
+	} else {
+		s.uncertain++
+	}
+}
+
+// Followed records a follow of userID, which notifies it through the outbox.
+func (m *UnreadModel) Followed(userID int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.state(userID)
+	s.due = append(s.due, m.now().Add(m.asyncBound))
+}
+
+// BeginReadAll records that userID is about to mark everything read.
+func (m *UnreadModel) BeginReadAll(userID int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.state(userID)
+	s.settle(m.now())
+	s.beforeKnown, s.beforeUncertain, s.beforeInFlight = s.known, s.uncertain, s.inFlight
+	s.known, s.uncertain, s.inFlight = 0, 0, 0
+	s.reading = true
+	s.epoch++
+}
+
+// EndReadAll records the outcome of the read-all begun with BeginReadAll.
+// Whatever was stored before it started is read if it succeeded; anything
+// that overlapped it, or anything at all if it failed, may or may not be.
+func (m *UnreadModel) EndReadAll(userID int64, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.state(userID)
+	maybe := s.beforeInFlight + s.uncertain + s.inFlight + len(s.due)
+	if !ok {
+		maybe += s.beforeKnown + s.beforeUncertain
+	}
+	s.known, s.uncertain, s.inFlight, s.due = 0, maybe, 0, nil
+	s.reading = false
+	s.epoch++
+}
+
+// Bounds returns the lowest and highest unread count userID may have now.
+func (m *UnreadModel) Bounds(userID int64) (lo, hi int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.state(userID)
+	s.settle(m.now())
+	return s.known, s.known + s.inFlight + s.uncertain + len(s.due)
+}
+
+// Check reports whether count, read between the two Bounds calls that
+// produced lo (before the read) and hi (after it), fits the model, and by how
+// much it misses otherwise.
+func Check(count, lo, hi int) (ok bool, off int) {
+	switch {
+	case count < lo:
+		return false, lo - count
+	case count > hi:
+		return false, count - hi
+	}
+	return true, 0
+}
@@ -0,0 +1,253 @@
+package load
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+)
+
+// maxOwnPosts caps the posts a VU keeps; creating another deletes the
+// oldest, so long runs do not grow the dataset.
+const maxOwnPosts = 5
+
+// Operation is one entry of a mix: a short sequence of gateway calls a user
+// would make together.
+type Operation struct {
+	Name   string
+	Weight int
+	Do     func(v *VU) error
+}
+
+// Mix is a weighted set of operations.
+type Mix []Operation
+
+// Validate checks that the mix can be picked from.
+func (m Mix) Validate() error {
+	total := 0
+	for _, op := range m {
+		if op.Weight < 0 {
+			return fmt.Errorf("operation %s: negative weight %d", op.Name, op.Weight)
+		}
+		total += op.Weight
+	}
+	if total == 0 {
+		return errors.New("mix has no operation with a positive weight")
+	}
+	return nil
+}
+
+// Pick returns an operation with probability proportional to its weight.
+func (m Mix) Pick(r *rand.Rand) Operation {
+	total := 0
+	for _, op := range m {
+		total += op.Weight
+	}
+	n := r.Intn(total)
+	for _, op := range m {
+		if n < op.Weight {
+			return op
+		}
+		n -= op.Weight
+	}
+	return m[len(m)-1]
+}
+
+// DefaultMix is a read-heavy mix over every service. Writes clean up after
+// themselves: posts are capped per VU and follows toggle.
+func DefaultMix() Mix {
+	return Mix{
+		{Name: "view_profile", Weight: 15, Do: viewProfile},
+		{Name: "search_users", Weight: 5, Do: searchUsers},
+		{Name: "browse_posts", Weight: 15, Do: browsePosts},
+		{Name: "view_own_post", Weight: 8, Do: viewOwnPost},
+		{Name: "create_post", Weight: 5, Do: createPost},
+		{Name: "edit_post", Weight: 4, Do: editPost},
+		{Name: "view_followers", Weight: 8, Do: viewFollowers},
+		{Name: "toggle_follow", Weight: 8, Do: toggleFollow},
+		{Name: "send_notification", Weight: 8, Do: sendNotification},
+		{Name: "check_unread", Weight: 10, Do: checkUnread},
+		{Name: "read_feed", Weight: 8, Do: readFeed},
+		{Name: "read_all", Weight: 3, Do: func(v *VU) error { return v.readAll() }},
+	}
+}
+
+// errNoPeers is returned by operations that need another user.
+var errNoPeers = errors.New("no peers to act on")
+
+func viewProfile(v *VU) error {
+	p := v.peer()
+	if p == nil {
+		return errNoPeers
+	}
+	return v.call("GET /v1/users/{id}", func() error {
+		_, err := v.User.Users.GetUserByID(p.ID)
+		return err
+	})
+}
+
+func searchUsers(v *VU) error {
+	p := v.peer()
+	if p == nil {
+		return errNoPeers
+	}
+	query := p.Username
+	if len(query) > 6 {
+		query = query[:6]
+	}
+	return v.call("GET /v1/users/search", func() error {
+		_, err := v.User.Users.SearchUsers(query, 1, 20)
+		return err
+	})
+}
+
+func browsePosts(v *VU) error {
+	p := v.peer()
+	if p == nil {
+		return errNoPeers
+	}
+	return v.call("GET /v1/posts/list", func() error {
+		_, err := v.User.Posts.ListPosts(p.ID, time.Time{}, time.Time{}, 0, 20)
+		return err
+	})
+}
+
+func viewOwnPost(v *VU) error {
+	if len(v.posts) == 0 {
+		return createPost(v)
+	}
+	id := v.posts[v.Rand.Intn(len(v.posts))]
+	return v.call("GET /v1/posts/{id}", func() error {
+		_, err := v.User.Posts.GetPostByID(id)
+		return err
+	})
+}
+
+func createPost(v *VU) error {
+	req := v.gen.NewPost().Build()
+	err := v.call("POST /v1/posts", func() error {
+		post, err := v.User.Posts.CreatePost(*req)
+		if err != nil {
+			return err
+		}
+		v.posts = append(v.posts, post.ID)
+		return nil
+	})
+	if err != nil || len(v.posts) <= maxOwnPosts {
+		return err
+	}
+
+	oldest := v.posts[0]
+	return v.call("DELETE /v1/posts/{id}", func() error {
+		err := v.User.Posts.DeletePost(oldest)
+		if err == nil || client.StatusCode(err) == http.StatusNotFound {
+			v.posts = v.posts[1:]
+		}
+		return err
+	})
+}
+
+func editPost(v *VU) error {
+	if len(v.posts) == 0 {
+		return createPost(v)
+	}
+	id := v.posts[v.Rand.Intn(len(v.posts))]
+	req := v.gen.NewPost().BuildUpdate()
+	return v.call("PUT /v1/posts/{id}", func() error {
+		_, err := v.User.Posts.UpdatePost(id, *req)
+		return err
+	})
+}
+
+func viewFollowers(v *VU) error {
+	p := v.peer()
+	if p == nil {
+		return errNoPeers
+	}
+	return v.call("GET /v1/relation/{id}/followers", func() error {
+		_, err := v.User.Relations.GetFollowers(p.ID, 1, 20)
+		return err
+	})
+}
+
+func toggleFollow(v *VU) error {
+	p := v.peer()
+	if p == nil {
+		return errNoPeers
+	}
+	if v.seeded[p.ID] {
+		return viewFollowers(v)
+	}
+	if v.following[p.ID] {
+		return v.call("POST /v1/relation/unfollow", func() error {
+			_, err := v.User.Relations.Unfollow(p.ID)
+			if err == nil {
+				v.following[p.ID] = false
+			}
+			return err
+		})
+	}
+	return v.call("POST /v1/relation/follow", func() error {
+		_, err := v.User.Relations.Follow(p.ID)
+		if err == nil {
+			v.following[p.ID] = true
+			if v.model != nil {
+				v.model.Followed(p.ID)
+			}
+		}
+		return err
+	})
+}
+
+func sendNotification(v *VU) error {
+	p := v.peer()
+	if p == nil {
+		return errNoPeers
+	}
+	req := v.gen.NewNotification(p.ID).WithType(fixtures.NotificationTypeSystem).Build()
+	token := 0
+	if v.model != nil {
+		token = v.model.BeginSend(p.ID)
+	}
+	err := v.call("POST /v1/notification/send", func() error {
+		_, err := v.User.Notifications.SendNotification(*req)
+		return err
+	})
+	if v.model != nil {
+		v.model.EndSend(p.ID, token, err == nil)
+	}
+	return err
+}
+
+// checkUnread reads the unread count and compares it with the model.
+func checkUnread(v *VU) error {
+	if v.model == nil {
+		return v.call("GET /v1/notification/unread-count", func() error {
+			_, err := v.User.Notifications.GetUnreadCount(v.User.ID)
+			return err
+		})
+	}
+
+	lo, _ := v.model.Bounds(v.User.ID)
+	start, queued := v.begin()
+	resp, err := v.User.Notifications.GetUnreadCount(v.User.ID)
+	s := Sample{Endpoint: "GET /v1/notification/unread-count", Start: start, Latency: time.Since(start), Queued: queued, Err: err}
+	if err == nil {
+		_, hi := v.model.Bounds(v.User.ID)
+		ok, off := Check(resp.Count, lo, hi)
+		s.Diverged, s.Off = !ok, off
+	}
+	v.emit(s)
+	return err
+}
+
+func readFeed(v *VU) error {
+	return v.call("GET /v1/notification/feed", func() error {
+		_, err := v.User.Notifications.GetUserNotificationFeed(v.User.ID, 1, 20)
+		return err
+	})
+}
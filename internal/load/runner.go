@@ -0,0 +1,143 @@
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Runner drives every VU in its own goroutine through the mix. Run is a
+// closed model, where load follows from the number of VUs and their think
+// time; RunProfile is an open model, where it follows a scheduled arrival
+// rate.
+type Runner struct {
+	Mix Mix
+	VUs []*VU
+	// Think is the pause between operations of one VU.
+	Think time.Duration
+	// Refresh is how often a VU refreshes its tokens; zero never refreshes.
+	Refresh time.Duration
+	// Observe receives every sample. It is called from all VU goroutines at
+	// once.
+	Observe func(Sample)
+}
+
+// Start logs every VU in and prepares it, at most concurrency at a time.
+func (r *Runner) Start(ctx context.Context, concurrency int) error {
+	return forEachVU(ctx, r.VUs, concurrency, func(v *VU) error {
+		v.observe = r.Observe
+		if err := v.Start(ctx); err != nil {
+			return fmt.Errorf("start vu %d (user %d): %w", v.ID, v.User.ID, err)
+		}
+		return nil
+	})
+}
+
+// Run drives the VUs until ctx ends. Operation errors are reported as
+// samples, not returned.
+func (r *Runner) Run(ctx context.Context) error {
+	if err := r.Mix.Validate(); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, v := range r.VUs {
+		wg.Add(1)
+		go func(v *VU) {
+			defer wg.Done()
+			r.drive(ctx, v)
+		}(v)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (r *Runner) drive(ctx context.Context, v *VU) {
+	v.User.API.SetContext(ctx)
+	v.observe = r.Observe
+
+	for ctx.Err() == nil {
+		r.Step(v)
+		if r.Think > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(r.Think):
+			}
+		}
+	}
+}
+
+// Step runs one operation picked from the mix on v, refreshing its tokens
+// first when they are due.
+func (r *Runner) Step(v *VU) {
+	_ = r.step(v, time.Time{})
+}
+
+// step is Step for an operation scheduled at intended; a zero intended
+// measures from when its first call starts.
+func (r *Runner) step(v *VU, intended time.Time) error {
+	if r.Refresh > 0 && time.Since(v.refreshed) >= r.Refresh {
+		v.operation = "refresh"
+		_ = v.Refresh()
+	}
+	op := r.Mix.Pick(v.Rand)
+	v.operation = op.Name
+	v.intended = intended
+	err := op.Do(v)
+	v.intended = time.Time{}
+	return err
+}
+
+// Cleanup undoes what the VUs created, at most concurrency at a time.
+func (r *Runner) Cleanup(ctx context.Context, concurrency int) error {
+	return forEachVU(ctx, r.VUs, concurrency, func(v *VU) error {
+		if err := v.Cleanup(ctx); err != nil {
+			return fmt.Errorf("clean up vu %d (user %d): %w", v.ID, v.User.ID, err)
+		}
+		return nil
+	})
+}
+
+// forEachVU calls fn for every VU with at most concurrency calls in flight
+// and joins their errors. VUs not yet started when ctx is cancelled are
+// skipped.
+func forEachVU(ctx context.Context, vus []*VU, concurrency int, fn func(v *VU) error) error {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sem  = make(chan struct{}, concurrency)
+	)
+
+loop:
+	for _, v := range vus {
+		select {
+		case <-ctx.Done():
+			mu.Lock()
+			errs = append(errs, ctx.Err())
+			mu.Unlock()
+			break loop
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(v *VU) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(v); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(v)
+	}
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,222 @@
+// Package load drives virtual users through a weighted mix of client
+// operations and reports every gateway call as a Sample, for load, soak and
+// latency-regression runs outside `go test`.
+package load
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+)
+
+const followeesPageSize = 100
+
+// Sample is one gateway call made by a virtual user.
+type Sample struct {
+	// Operation is the mix entry the call belongs to, Endpoint the call.
+	Operation string
+	Endpoint  string
+	VU        int
+	Start     time.Time
+	Latency   time.Duration
+	// Queued is how long the call waited past its scheduled start in an
+	// open-model run. Start and Latency then count from the scheduled start,
+	// so time spent queueing behind a slow gateway is not omitted.
+	Queued time.Duration
+	Err    error
+	// Diverged is set on unread-count reads that fall outside the model;
+	// Off is by how much.
+	Diverged bool
+	Off      int
+}
+
+// VU is a virtual user: an existing account with its own clients. A VU is
+// driven by one goroutine at a time.
+type VU struct {
+	ID    int
+	User  *factory.User
+	Peers []*factory.User
+	Rand  *rand.Rand
+
+	gen   *fixtures.Generator
+	model *UnreadModel
+
+	operation string
+	observe   func(Sample)
+	// intended is the scheduled start of the operation in progress in an
+	// open-model run; the operation's first call measures from it.
+	intended time.Time
+
+	posts     []int64
+	following map[int64]bool
+	// seeded are follows that existed before the run; they are left alone.
+	seeded    map[int64]bool
+	refreshed time.Time
+}
+
+// NewVU returns virtual user id acting as user. Peers are the other users it
+// reads, follows and notifies; user itself may be among them and is skipped.
+// Its random choices are derived from gen's seed and id.
+func NewVU(id int, user *factory.User, peers []*factory.User, gen *fixtures.Generator, model *UnreadModel) *VU {
+	others := make([]*factory.User, 0, len(peers))
+	for _, p := range peers {
+		if p.ID != user.ID {
+			others = append(others, p)
+		}
+	}
+	return &VU{
+		ID:        id,
+		User:      user,
+		Peers:     others,
+		Rand:      rand.New(rand.NewSource(fixtures.DeriveSeed(gen.Seed(), "vu-"+strconv.Itoa(id)))),
+		gen:       gen,
+		model:     model,
+		following: make(map[int64]bool),
+		seeded:    make(map[int64]bool),
+	}
+}
+
+// call runs one gateway call and reports it.
+func (v *VU) call(endpoint string, fn func() error) error {
+	start, queued := v.begin()
+	err := fn()
+	v.emit(Sample{Endpoint: endpoint, Start: start, Latency: time.Since(start), Queued: queued, Err: err})
+	return err
+}
+
+// begin returns the time a call's latency counts from: the scheduled start
+// of the operation for its first call in an open-model run, now otherwise.
+func (v *VU) begin() (start time.Time, queued time.Duration) {
+	now := time.Now()
+	if v.intended.IsZero() {
+		return now, 0
+	}
+	start, v.intended = v.intended, time.Time{}
+	return start, now.Sub(start)
+}
+
+func (v *VU) emit(s Sample) {
+	if v.observe == nil {
+		return
+	}
+	s.Operation = v.operation
+	s.VU = v.ID
+	v.observe(s)
+}
+
+// peer returns a random peer, or nil if there are none.
+func (v *VU) peer() *factory.User {
+	if len(v.Peers) == 0 {
+		return nil
+	}
+	return v.Peers[v.Rand.Intn(len(v.Peers))]
+}
+
+// Start logs the user in, since stored tokens may have expired, records the
+// follows it already has and marks its notifications read so the unread
+// model starts from zero.
+func (v *VU) Start(ctx context.Context) error {
+	v.User.API.SetContext(ctx)
+	v.operation = "start"
+
+	if err := v.login(); err != nil {
+		return err
+	}
+	for page := 1; ; page++ {
+		var resp *fixtures.GetFolloweesResponse
+		err := v.call("GET /v1/relation/{id}/followees", func() error {
+			var err error
+			resp, err = v.User.Relations.GetFollowees(v.User.ID, page, followeesPageSize)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		for _, f := range resp.Followees {
+			v.seeded[f.ID] = true
+		}
+		if len(resp.Followees) < followeesPageSize {
+			break
+		}
+	}
+	return v.readAll()
+}
+
+func (v *VU) login() error {
+	return v.call("POST /v1/auth/login", func() error {
+		resp, err := v.User.Auth.Login(fixtures.LoginRequest{Login: v.User.Username, Password: v.User.Password})
+		if err != nil {
+			return err
+		}
+		v.setTokens(resp.AccessToken, resp.RefreshToken)
+		return nil
+	})
+}
+
+// Refresh exchanges the refresh token for new tokens, falling back to a
+// fresh login if the refresh token was rejected.
+func (v *VU) Refresh() error {
+	err := v.call("POST /v1/auth/refresh", func() error {
+		resp, err := v.User.Auth.RefreshToken(fixtures.RefreshTokenRequest{RefreshToken: v.User.RefreshToken})
+		if err != nil {
+			return err
+		}
+		v.setTokens(resp.AccessToken, resp.RefreshToken)
+		return nil
+	})
+	if err != nil {
+		return v.login()
+	}
+	return nil
+}
+
+func (v *VU) setTokens(access, refresh string) {
+	v.User.AccessToken = access
+	v.User.RefreshToken = refresh
+	v.User.API.SetToken(access)
+	v.refreshed = time.Now()
+}
+
+func (v *VU) readAll() error {
+	if v.model != nil {
+		v.model.BeginReadAll(v.User.ID)
+	}
+	err := v.call("PUT /v1/notification/read-all", func() error {
+		_, err := v.User.Notifications.ReadAllUserNotifications(v.User.ID)
+		return err
+	})
+	if v.model != nil {
+		v.model.EndReadAll(v.User.ID, err == nil)
+	}
+	return err
+}
+
+// Cleanup deletes the posts the VU created and undoes its follows. Calls are
+// not reported.
+func (v *VU) Cleanup(ctx context.Context) error {
+	v.User.API.SetContext(ctx)
+	v.observe = nil
+
+	var firstErr error
+	keep := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	for _, id := range v.posts {
+		keep(v.User.Posts.DeletePost(id))
+	}
+	v.posts = nil
+	for id, following := range v.following {
+		if following {
+			_, err := v.User.Relations.Unfollow(id)
+			keep(err)
+		}
+	}
+	v.following = make(map[int64]bool)
+	return firstErr
+}
@@ -3,6 +3,7 @@ package logger
 import (
 	"log/slog"
 	"os"
+	"strings"
 )
 
 const (
@@ -12,27 +13,48 @@ const (
 
 type Logger struct {
 	*slog.Logger
+
+	testName      string
+	correlationID string
+}
+
+// TestName returns the name of the test the logger was created for with
+// ForTest, or an empty string for the shared logger.
+func (l *Logger) TestName() string {
+	return l.testName
+}
+
+// CorrelationID returns the correlation ID attached by ForTest, or an empty
+// string for the shared logger.
+func (l *Logger) CorrelationID() string {
+	return l.correlationID
+}
+
+// New builds the shared stdout logger. level is one of debug, info, warn or
+// error (config test.log_level); when it is empty or unknown the level is
+// derived from env as before.
+func New(env, level string) *Logger {
+	opts := &slog.HandlerOptions{
+		Level:       levelFor(env, level),
+		AddSource:   true,
+		ReplaceAttr: ReplaceAttr,
+	}
+
+	return &Logger{Logger: slog.New(slog.NewJSONHandler(os.Stdout, opts))}
 }
 
-func New(env string) *Logger {
-	var log *slog.Logger
+func levelFor(env, level string) slog.Level {
+	var lvl slog.Level
+	if level != "" && lvl.UnmarshalText([]byte(strings.ToLower(level))) == nil {
+		return lvl
+	}
+
 	switch env {
-	case envDev:
-		log = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level:     slog.LevelDebug,
-			AddSource: true,
-		}))
 	case envProd:
-		log = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level:     slog.LevelInfo,
-			AddSource: true,
-		}))
+		return slog.LevelInfo
+	case envDev:
+		return slog.LevelDebug
 	default:
-		log = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level:     slog.LevelDebug,
-			AddSource: true,
-		}))
+		return slog.LevelDebug
 	}
-
-	return &Logger{log}
 }
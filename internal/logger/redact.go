@@ -0,0 +1,189 @@
+package logger
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+)
+
+// RedactedValue replaces every masked value.
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactedFields are JSON fields and log attribute keys that are always
+// masked. Matching is case-insensitive.
+var DefaultRedactedFields = []string{
+	"password",
+	"old_password",
+	"new_password",
+	"access_token",
+	"refresh_token",
+	"token",
+	"client_secret",
+	"secret",
+	"authorization",
+	"email",
+}
+
+// DefaultRedactedHeaders are HTTP headers whose values are always masked.
+var DefaultRedactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// Redactor masks secrets and PII in JSON documents, HTTP headers and log
+// attributes. The same redactor is used for logs and for every artifact that
+// ends up in CI, so they can be shared safely.
+type Redactor struct {
+	fields  map[string]struct{}
+	headers []string
+}
+
+// NewRedactor returns a redactor masking the default fields plus extra.
+func NewRedactor(extra ...string) *Redactor {
+	r := &Redactor{
+		fields:  make(map[string]struct{}, len(DefaultRedactedFields)+len(extra)),
+		headers: DefaultRedactedHeaders,
+	}
+	for _, f := range append(append([]string{}, DefaultRedactedFields...), extra...) {
+		if f = strings.TrimSpace(f); f != "" {
+			r.fields[strings.ToLower(f)] = struct{}{}
+		}
+	}
+	return r
+}
+
+var defaultRedactor atomic.Pointer[Redactor]
+
+func init() {
+	defaultRedactor.Store(NewRedactor())
+}
+
+// DefaultRedactor returns the process-wide redactor used by all loggers.
+func DefaultRedactor() *Redactor {
+	return defaultRedactor.Load()
+}
+
+// SetRedactedFields replaces the process-wide redactor with one that masks
+// the default fields plus extra (config redaction.fields).
+func SetRedactedFields(extra ...string) {
+	defaultRedactor.Store(NewRedactor(extra...))
+}
+
+// Field reports whether values under key must be masked.
+func (r *Redactor) Field(key string) bool {
+	_, ok := r.fields[strings.ToLower(key)]
+	return ok
+}
+
+// JSON returns data with every configured field masked at any depth. Input
+// that is not valid JSON is returned unchanged.
+func (r *Redactor) JSON(data []byte) []byte {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return data
+	}
+
+	redacted, err := json.Marshal(r.value(doc))
+	if err != nil {
+		return data
+	}
+	return redacted
+}
+
+// JSONString is JSON for string input and output.
+func (r *Redactor) JSONString(data string) string {
+	return string(r.JSON([]byte(data)))
+}
+
+func (r *Redactor) value(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, nested := range val {
+			if r.Field(k) {
+				val[k] = RedactedValue
+				continue
+			}
+			val[k] = r.value(nested)
+		}
+		return val
+	case []interface{}:
+		for i, nested := range val {
+			val[i] = r.value(nested)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// Header returns a copy of h with sensitive headers masked.
+func (r *Redactor) Header(h http.Header) http.Header {
+	redacted := h.Clone()
+	for _, name := range r.headers {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, RedactedValue)
+		}
+	}
+	return redacted
+}
+
+// ReplaceAttr is a slog.HandlerOptions.ReplaceAttr hook masking attributes
+// whose key is a redacted field, and redacted fields at any depth of map,
+// slice and struct values. It reads the process-wide redactor on every call
+// so SetRedactedFields applies to loggers created earlier.
+func ReplaceAttr(_ []string, a slog.Attr) slog.Attr {
+	r := DefaultRedactor()
+	if r.Field(a.Key) {
+		return slog.String(a.Key, RedactedValue)
+	}
+	if a.Value.Kind() == slog.KindAny {
+		if doc, ok := r.structured(a.Value.Any()); ok {
+			return slog.Any(a.Key, doc)
+		}
+	}
+	return a
+}
+
+// structured returns v as a JSON document with redacted fields masked if it
+// is a map, slice or struct, which the handler would otherwise write out
+// whole. Errors and byte slices are left alone.
+func (r *Redactor) structured(v interface{}) (interface{}, bool) {
+	if _, ok := v.(error); ok {
+		return nil, false
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, false
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Map, reflect.Struct, reflect.Array:
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, false
+	}
+	return r.value(doc), true
+}
+
+// Redactor returns the redactor the logger applies to its records.
+func (l *Logger) Redactor() *Redactor {
+	return DefaultRedactor()
+}
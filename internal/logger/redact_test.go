@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactorJSON(t *testing.T) {
+	r := NewRedactor("bio")
+
+	out := r.JSONString(`{"status":200,"data":{"access_token":"a.b.c","Refresh_Token":"r","user":{"email":"x@y.z","bio":"hi","username":"bob"}},"items":[{"password":"p"}]}`)
+
+	assert.NotContains(t, out, "a.b.c")
+	assert.NotContains(t, out, "x@y.z")
+	assert.NotContains(t, out, `"hi"`)
+	assert.NotContains(t, out, `"p"`)
+	assert.Contains(t, out, `"username":"bob"`)
+	assert.Contains(t, out, `"Refresh_Token":"[REDACTED]"`)
+
+	assert.Equal(t, "not json", r.JSONString("not json"))
+}
+
+func TestRedactorHeader(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer secret")
+	h.Set("Content-Type", "application/json")
+
+	redacted := NewRedactor().Header(h)
+
+	assert.Equal(t, RedactedValue, redacted.Get("Authorization"))
+	assert.Equal(t, "application/json", redacted.Get("Content-Type"))
+	assert.Equal(t, "Bearer secret", h.Get("Authorization"))
+}
+
+func TestReplaceAttrMasksLogKeys(t *testing.T) {
+	t.Cleanup(func() { SetRedactedFields() })
+	SetRedactedFields("full_name")
+
+	var buf bytes.Buffer
+	log := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: ReplaceAttr}))
+	log.Info("register", slog.String("username", "bob"), slog.String("email", "bob@example.com"), slog.String("full_name", "Bob B"))
+
+	assert.Contains(t, buf.String(), `"username":"bob"`)
+	assert.NotContains(t, buf.String(), "bob@example.com")
+	assert.NotContains(t, buf.String(), "Bob B")
+}
+
+func TestReplaceAttrMasksNestedFields(t *testing.T) {
+	type credentials struct {
+		Login    string `json:"login"`
+		Password string `json:"password"`
+	}
+
+	var buf bytes.Buffer
+	log := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: ReplaceAttr}))
+	log.Info("login",
+		slog.Any("request", credentials{Login: "bob", Password: "hunter2"}),
+		slog.Any("response", map[string]interface{}{"data": map[string]interface{}{"access_token": "a.b.c"}}),
+	)
+
+	assert.Contains(t, buf.String(), `"login":"bob"`)
+	assert.NotContains(t, buf.String(), "hunter2")
+	assert.NotContains(t, buf.String(), "a.b.c")
+}
@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+const (
+	TestKey          = "test"
+	CorrelationIDKey = "correlation_id"
+)
+
+// testBuffer collects JSON log lines of a single test.
+type testBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *testBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *testBuffer) lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var lines []string
+	for _, line := range bytes.Split(bytes.TrimRight(b.buf.Bytes(), "\n"), []byte("\n")) {
+		if len(line) > 0 {
+			lines = append(lines, string(line))
+		}
+	}
+	return lines
+}
+
+// testLevel is the level ForTest loggers capture at, debug until
+// SetTestLevel is called.
+var testLevel = func() *slog.LevelVar {
+	var lvl slog.LevelVar
+	lvl.Set(slog.LevelDebug)
+	return &lvl
+}()
+
+// SetTestLevel sets the level of ForTest loggers, including ones created
+// earlier, from env and level the way New does (config test.log_level).
+func SetTestLevel(env, level string) {
+	testLevel.Set(levelFor(env, level))
+}
+
+// ForTest returns a logger that tags every record with the test name and a
+// fresh correlation ID and keeps the records in memory. They are written
+// through t.Log only if the test fails, so parallel tests no longer interleave
+// on stdout. Records are captured at the level set with SetTestLevel.
+func ForTest(t testing.TB) *Logger {
+	t.Helper()
+
+	buf := &testBuffer{}
+	handler := slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		Level:       testLevel,
+		ReplaceAttr: ReplaceAttr,
+	})
+	correlationID := NewCorrelationID()
+	log := slog.New(handler).With(
+		slog.String(TestKey, t.Name()),
+		slog.String(CorrelationIDKey, correlationID),
+	)
+
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+		for _, line := range buf.lines() {
+			t.Log(line)
+		}
+	})
+
+	return &Logger{Logger: log, testName: t.Name(), correlationID: correlationID}
+}
+
+// NewCorrelationID returns a random 16 hex digit identifier.
+func NewCorrelationID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "0000000000000000"
+	}
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTB struct {
+	testing.TB
+	failed   bool
+	logged   []string
+	cleanups []func()
+}
+
+func (f *fakeTB) Helper()           {}
+func (f *fakeTB) Name() string      { return "TestFake/sub" }
+func (f *fakeTB) Failed() bool      { return f.failed }
+func (f *fakeTB) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+func (f *fakeTB) Log(args ...any)   { f.logged = append(f.logged, args[0].(string)) }
+func (f *fakeTB) runCleanups() {
+	for _, fn := range f.cleanups {
+		fn()
+	}
+}
+
+func TestForTestDumpsOnlyOnFailure(t *testing.T) {
+	passed := &fakeTB{TB: t}
+	l := ForTest(passed)
+	l.Debug("hidden")
+	passed.runCleanups()
+	assert.Empty(t, passed.logged)
+
+	failed := &fakeTB{TB: t, failed: true}
+	l = ForTest(failed)
+	l.Debug("first")
+	l.Info("second")
+	failed.runCleanups()
+
+	require.Len(t, failed.logged, 2)
+	assert.Contains(t, failed.logged[0], `"msg":"first"`)
+	assert.Contains(t, failed.logged[1], `"test":"TestFake/sub"`)
+	assert.Contains(t, failed.logged[1], `"correlation_id":"`+l.CorrelationID()+`"`)
+	assert.Equal(t, "TestFake/sub", l.TestName())
+}
+
+func TestNewHonoursLevel(t *testing.T) {
+	assert.Equal(t, "WARN", levelFor("dev", "warn").String())
+	assert.Equal(t, "INFO", levelFor("prod", "").String())
+	assert.Equal(t, "DEBUG", levelFor("test", "bogus").String())
+	assert.Len(t, NewCorrelationID(), 16)
+}
+
+func TestForTestHonoursTestLevel(t *testing.T) {
+	SetTestLevel("test", "info")
+	t.Cleanup(func() { SetTestLevel("test", "debug") })
+
+	failed := &fakeTB{TB: t, failed: true}
+	l := ForTest(failed)
+	l.Debug("hidden")
+	l.Info("shown")
+	failed.runCleanups()
+
+	require.Len(t, failed.logged, 1)
+	assert.Contains(t, failed.logged[0], `"msg":"shown"`)
+}
@@ -0,0 +1,24 @@
+// Package race releases concurrent requests at the same moment, for tests
+// that look for lost updates and torn writes.
+package race
+
+import "sync"
+
+// Together runs fn(0..n-1) in n goroutines released at the same moment and
+// returns their errors by index.
+func Together(n int, fn func(i int) error) []error {
+	errs := make([]error, n)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs[i] = fn(i)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,31 @@
+package race
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTogether(t *testing.T) {
+	var running atomic.Int32
+	release := make(chan struct{})
+
+	// Every call waits for the last one to start, so this only returns if
+	// all of them run at once.
+	errs := Together(4, func(i int) error {
+		if running.Add(1) == 4 {
+			close(release)
+		}
+		<-release
+		if i == 2 {
+			return errors.New("lost")
+		}
+		return nil
+	})
+
+	assert.Len(t, errs, 4)
+	assert.EqualError(t, errs[2], "lost")
+	assert.NoError(t, errs[0])
+}
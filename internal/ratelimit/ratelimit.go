@@ -0,0 +1,168 @@
+// Package ratelimit floods gateway endpoints with bursts of requests and
+// checks how the gateway throttles them: 429 responses, their Retry-After and
+// rate-limit headers, and how soon requests are accepted again.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+)
+
+// Result describes one burst.
+type Result struct {
+	Sent int
+	OK   int
+	// Throttled counts 429 responses, Rejected other 4xx responses and
+	// ServerErrors 5xx responses; Failed counts requests that got no response.
+	Throttled    int
+	Rejected     int
+	ServerErrors int
+	Failed       int
+	// Throttles holds every 429 response.
+	Throttles []*client.APIError
+	// Unexpected holds the 5xx and failed requests, for reporting.
+	Unexpected []error
+	Took       time.Duration
+}
+
+// Burst calls fn n times with at most concurrency calls in flight and
+// classifies the outcomes. Calls not yet started when ctx ends are skipped.
+func Burst(ctx context.Context, n, concurrency int, fn func() error) Result {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		res Result
+		sem = make(chan struct{}, concurrency)
+	)
+	start := time.Now()
+
+loop:
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			break loop
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			err := fn()
+
+			mu.Lock()
+			defer mu.Unlock()
+			res.Sent++
+			var apiErr *client.APIError
+			switch status := client.StatusCode(err); {
+			case err == nil:
+				res.OK++
+			case status == http.StatusTooManyRequests && errors.As(err, &apiErr):
+				res.Throttled++
+				res.Throttles = append(res.Throttles, apiErr)
+			case status >= 500:
+				res.ServerErrors++
+				res.Unexpected = append(res.Unexpected, err)
+			case status >= 400:
+				res.Rejected++
+			default:
+				res.Failed++
+				res.Unexpected = append(res.Unexpected, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+	res.Took = time.Since(start)
+	return res
+}
+
+// rateLimitPrefixes are the header families limiters commonly use.
+var rateLimitPrefixes = []string{"X-RateLimit-", "RateLimit-"}
+
+// epochThreshold tells Unix timestamps in reset headers from second counts.
+const epochThreshold = 1_000_000_000
+
+// CheckThrottle returns what is wrong with a 429 response: the body must be
+// the gateway's JSON error, Retry-After must be present and at most window,
+// and rate-limit headers, where sent, must be numbers consistent with the
+// request having been refused.
+func CheckThrottle(e *client.APIError, window time.Duration) []string {
+	var problems []string
+	if e.Err != nil {
+		problems = append(problems, fmt.Sprintf("body is not the gateway's JSON error: %v", e.Err))
+	}
+
+	if e.Header.Get("Retry-After") == "" {
+		problems = append(problems, "no Retry-After header")
+	} else if wait, ok := client.RetryAfter(e); !ok {
+		problems = append(problems, fmt.Sprintf("unparsable Retry-After %q", e.Header.Get("Retry-After")))
+	} else if wait > window {
+		problems = append(problems, fmt.Sprintf("Retry-After %s exceeds the window of %s", wait, window))
+	}
+
+	for _, prefix := range rateLimitPrefixes {
+		if v := e.Header.Get(prefix + "Limit"); v != "" {
+			if n, err := strconv.ParseInt(v, 10, 64); err != nil || n <= 0 {
+				problems = append(problems, fmt.Sprintf("%sLimit %q is not a positive number", prefix, v))
+			}
+		}
+		if v := e.Header.Get(prefix + "Remaining"); v != "" {
+			if n, err := strconv.ParseInt(v, 10, 64); err != nil || n != 0 {
+				problems = append(problems, fmt.Sprintf("%sRemaining is %q on a refused request", prefix, v))
+			}
+		}
+		if v := e.Header.Get(prefix + "Reset"); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n < 0 {
+				problems = append(problems, fmt.Sprintf("%sReset %q is not a number", prefix, v))
+				continue
+			}
+			reset := time.Duration(n) * time.Second
+			if n > epochThreshold {
+				reset = time.Until(time.Unix(n, 0))
+			}
+			if reset > window {
+				problems = append(problems, fmt.Sprintf("%sReset %s exceeds the window of %s", prefix, reset.Round(time.Second), window))
+			}
+		}
+	}
+	return problems
+}
+
+// Recover waits first, then calls fn every poll until it is no longer
+// throttled, and returns how long that took from the call to Recover. It
+// fails if fn is still throttled after within or ctx ends. Any outcome but a
+// 429 counts as recovered, since some endpoints refuse the probe anyway.
+func Recover(ctx context.Context, first, within, poll time.Duration, fn func() error) (time.Duration, error) {
+	start := time.Now()
+	deadline := start.Add(within)
+	wait := first
+	for {
+		select {
+		case <-ctx.Done():
+			return time.Since(start), ctx.Err()
+		case <-time.After(wait):
+		}
+
+		err := fn()
+		if client.StatusCode(err) != http.StatusTooManyRequests {
+			return time.Since(start), nil
+		}
+		if time.Now().After(deadline) {
+			return time.Since(start), fmt.Errorf("still throttled %s after the burst: %w", within, err)
+		}
+		wait = poll
+	}
+}
@@ -0,0 +1,129 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedWindow is a fake gateway allowing limit requests per token per window.
+type fixedWindow struct {
+	mu      sync.Mutex
+	limit   int
+	window  time.Duration
+	started map[string]time.Time
+	used    map[string]int
+	header  func(h http.Header)
+}
+
+func (f *fixedWindow) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := r.Header.Get("Authorization")
+	if time.Since(f.started[key]) > f.window {
+		f.started[key], f.used[key] = time.Now(), 0
+	}
+	f.used[key]++
+	if f.used[key] > f.limit {
+		f.header(w.Header())
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"status":429,"message":"too many requests"}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{"status":200,"data":{}}`))
+}
+
+func newLimitedClient(t *testing.T, f *fixedWindow, token string) *client.Client {
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	c := client.NewClient(&config.Config{API: config.API{BaseURL: srv.URL, Timeout: time.Second}}, logger.ForTest(t))
+	c.SetToken(token)
+	return c
+}
+
+func TestBurstThrottlesAndRecovers(t *testing.T) {
+	f := &fixedWindow{
+		limit: 10, window: 300 * time.Millisecond,
+		started: map[string]time.Time{}, used: map[string]int{},
+		header: func(h http.Header) {
+			h.Set("Retry-After", "1")
+			h.Set("X-RateLimit-Limit", "10")
+			h.Set("X-RateLimit-Remaining", "0")
+		},
+	}
+	flooder := newLimitedClient(t, f, "a")
+	call := func() error { return flooder.Get("/v1/users/1", nil, nil) }
+
+	res := Burst(context.Background(), 30, 5, call)
+	assert.Equal(t, 30, res.Sent)
+	assert.Equal(t, 10, res.OK)
+	assert.Equal(t, 20, res.Throttled)
+	assert.Zero(t, res.ServerErrors)
+	assert.Empty(t, res.Unexpected)
+	for _, e := range res.Throttles {
+		assert.Empty(t, CheckThrottle(e, time.Minute))
+	}
+
+	bystander := newLimitedClient(t, f, "b")
+	assert.NoError(t, bystander.Get("/v1/users/1", nil, nil), "another user keeps its own budget")
+
+	took, err := Recover(context.Background(), 0, time.Second, 50*time.Millisecond, call)
+	require.NoError(t, err)
+	assert.Less(t, took, time.Second)
+}
+
+func TestRecoverGivesUp(t *testing.T) {
+	f := &fixedWindow{
+		limit: 0, window: time.Hour,
+		started: map[string]time.Time{}, used: map[string]int{},
+		header: func(h http.Header) {},
+	}
+	c := newLimitedClient(t, f, "a")
+	_, err := Recover(context.Background(), 0, 100*time.Millisecond, 20*time.Millisecond, func() error {
+		return c.Get("/x", nil, nil)
+	})
+	assert.ErrorContains(t, err, "still throttled")
+}
+
+func TestCheckThrottle(t *testing.T) {
+	header := func(kv ...string) http.Header {
+		h := http.Header{}
+		for i := 0; i < len(kv); i += 2 {
+			h.Set(kv[i], kv[i+1])
+		}
+		return h
+	}
+
+	for _, tc := range []struct {
+		name   string
+		header http.Header
+		want   string
+	}{
+		{"missing Retry-After", header(), "no Retry-After"},
+		{"garbage Retry-After", header("Retry-After", "soon"), "unparsable Retry-After"},
+		{"Retry-After beyond window", header("Retry-After", "120"), "exceeds the window"},
+		{"remaining left", header("Retry-After", "1", "RateLimit-Remaining", "3"), "RateLimit-Remaining"},
+		{"bad limit", header("Retry-After", "1", "X-RateLimit-Limit", "x"), "X-RateLimit-Limit"},
+		{"epoch reset far away", header("Retry-After", "1", "X-RateLimit-Reset", "4102444800"), "X-RateLimit-Reset"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			problems := CheckThrottle(&client.APIError{StatusCode: http.StatusTooManyRequests, Header: tc.header}, time.Minute)
+			require.NotEmpty(t, problems)
+			assert.Contains(t, strings.Join(problems, "; "), tc.want)
+		})
+	}
+
+	ok := header("Retry-After", "30", "RateLimit-Limit", "100", "RateLimit-Remaining", "0", "RateLimit-Reset", "30")
+	assert.Empty(t, CheckThrottle(&client.APIError{StatusCode: http.StatusTooManyRequests, Header: ok}, time.Minute))
+}
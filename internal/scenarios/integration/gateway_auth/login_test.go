@@ -12,8 +12,8 @@ import (
 func setupLoginTest(t *testing.T, tc *TestContext) (*fixtures.LoginRequest, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up login test", "test", t.Name(), "username", registerReq.Username)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up login test", "test", t.Name(), "username", registerReq.Username)
 
 	registerResp, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register test user")
@@ -22,24 +22,24 @@ func setupLoginTest(t *testing.T, tc *TestContext) (*fixtures.LoginRequest, func
 
 	user, err := tc.UserClient.GetUserByUsername(registerReq.Username)
 	if err != nil {
-		log.Warn("Failed to get user info for cleanup tracking", "username", registerReq.Username, "error", err.Error())
+		tc.Log.Warn("Failed to get user info for cleanup tracking", "username", registerReq.Username, "error", err.Error())
 	} else {
 		tc.TrackUserForCleanup(user.ID, user.Username, registerResp.AccessToken)
 	}
 
 	tc.APIClient.SetToken("")
 
-	loginReq := fixtures.GenerateLoginRequest(registerReq.Username, registerReq.Password)
+	loginReq := tc.Fixtures.GenerateLoginRequest(registerReq.Username, registerReq.Password)
 
 	return loginReq, func() {
-		log.Info("Login test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Login test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestLoginSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	loginReq, teardown := setupLoginTest(t, tc)
@@ -55,7 +55,7 @@ func TestLoginSuccess(t *testing.T) {
 
 func TestLoginInvalidCredentials(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	loginReq, teardown := setupLoginTest(t, tc)
@@ -71,7 +71,7 @@ func TestLoginInvalidCredentials(t *testing.T) {
 	})
 
 	t.Run("NonexistentUser", func(t *testing.T) {
-		invalidReq := fixtures.GenerateLoginRequest("nonexistent_user_"+fixtures.GenerateRegisterRequest().Username, "password123")
+		invalidReq := tc.Fixtures.GenerateLoginRequest("nonexistent_user_"+tc.Fixtures.GenerateRegisterRequest().Username, "password123")
 
 		_, err := tc.AuthClient.Login(*invalidReq)
 		assert.Error(t, err)
@@ -81,14 +81,14 @@ func TestLoginInvalidCredentials(t *testing.T) {
 
 func TestLoginValidationErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, teardown := setupLoginTest(t, tc)
 	defer teardown()
 
 	t.Run("EmptyLogin", func(t *testing.T) {
-		invalidReq := fixtures.GenerateLoginRequest("", "password123")
+		invalidReq := tc.Fixtures.GenerateLoginRequest("", "password123")
 		invalidReq.Login = ""
 
 		_, err := tc.AuthClient.Login(*invalidReq)
@@ -97,7 +97,7 @@ func TestLoginValidationErrors(t *testing.T) {
 	})
 
 	t.Run("EmptyPassword", func(t *testing.T) {
-		invalidReq := fixtures.GenerateLoginRequest("usernameemptypassword", "")
+		invalidReq := tc.Fixtures.GenerateLoginRequest("usernameemptypassword", "")
 		invalidReq.Password = ""
 
 		_, err := tc.AuthClient.Login(*invalidReq)
@@ -106,7 +106,7 @@ func TestLoginValidationErrors(t *testing.T) {
 	})
 
 	t.Run("ShortPassword", func(t *testing.T) {
-		invalidReq := fixtures.GenerateLoginRequest("usernameshortpassword", "12345")
+		invalidReq := tc.Fixtures.GenerateLoginRequest("usernameshortpassword", "12345")
 
 		_, err := tc.AuthClient.Login(*invalidReq)
 		assert.Error(t, err)
@@ -12,8 +12,8 @@ import (
 func setupLogoutTest(t *testing.T, tc *TestContext) (*fixtures.LogoutRequest, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up logout test", "test", t.Name(), "username", registerReq.Username)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up logout test", "test", t.Name(), "username", registerReq.Username)
 
 	registerResp, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register test user")
@@ -22,24 +22,24 @@ func setupLogoutTest(t *testing.T, tc *TestContext) (*fixtures.LogoutRequest, fu
 
 	user, err := tc.UserClient.GetUserByUsername(registerReq.Username)
 	if err != nil {
-		log.Warn("Failed to get user info for cleanup tracking", "username", registerReq.Username, "error", err.Error())
+		tc.Log.Warn("Failed to get user info for cleanup tracking", "username", registerReq.Username, "error", err.Error())
 	} else {
 		tc.TrackUserForCleanup(user.ID, user.Username, registerResp.AccessToken)
 	}
 
-	logoutReq := fixtures.GenerateLogoutRequest(registerResp.RefreshToken)
+	logoutReq := tc.Fixtures.GenerateLogoutRequest(registerResp.RefreshToken)
 
 	tc.APIClient.SetToken("")
 
 	return logoutReq, func() {
-		log.Info("Logout test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Logout test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestLogoutSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	logoutReq, teardown := setupLogoutTest(t, tc)
@@ -52,14 +52,14 @@ func TestLogoutSuccess(t *testing.T) {
 
 func TestLogoutInvalidToken(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, teardown := setupLogoutTest(t, tc)
 	defer teardown()
 
 	t.Run("InvalidRefreshToken", func(t *testing.T) {
-		invalidReq := fixtures.GenerateLogoutRequest("invalid_refresh_token")
+		invalidReq := tc.Fixtures.GenerateLogoutRequest("invalid_refresh_token")
 
 		err := tc.AuthClient.Logout(*invalidReq)
 		assert.Error(t, err)
@@ -67,7 +67,7 @@ func TestLogoutInvalidToken(t *testing.T) {
 	})
 
 	t.Run("EmptyRefreshToken", func(t *testing.T) {
-		invalidReq := fixtures.GenerateLogoutRequest("")
+		invalidReq := tc.Fixtures.GenerateLogoutRequest("")
 		invalidReq.RefreshToken = ""
 
 		err := tc.AuthClient.Logout(*invalidReq)
@@ -78,7 +78,7 @@ func TestLogoutInvalidToken(t *testing.T) {
 
 func TestLogoutTwice(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	logoutReq, teardown := setupLogoutTest(t, tc)
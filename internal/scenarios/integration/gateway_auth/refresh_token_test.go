@@ -12,8 +12,8 @@ import (
 func setupRefreshTokenTest(t *testing.T, tc *TestContext) (*fixtures.RefreshTokenRequest, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up refresh token test", "test", t.Name(), "username", registerReq.Username)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up refresh token test", "test", t.Name(), "username", registerReq.Username)
 
 	registerResp, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register test user")
@@ -22,24 +22,24 @@ func setupRefreshTokenTest(t *testing.T, tc *TestContext) (*fixtures.RefreshToke
 
 	user, err := tc.UserClient.GetUserByUsername(registerReq.Username)
 	if err != nil {
-		log.Warn("Failed to get user info for cleanup tracking", "username", registerReq.Username, "error", err.Error())
+		tc.Log.Warn("Failed to get user info for cleanup tracking", "username", registerReq.Username, "error", err.Error())
 	} else {
 		tc.TrackUserForCleanup(user.ID, user.Username, registerResp.AccessToken)
 	}
 
 	tc.APIClient.SetToken("")
 
-	refreshReq := fixtures.GenerateRefreshTokenRequest(registerResp.RefreshToken)
+	refreshReq := tc.Fixtures.GenerateRefreshTokenRequest(registerResp.RefreshToken)
 
 	return refreshReq, func() {
-		log.Info("Refresh token test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Refresh token test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestRefreshTokenSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	refreshReq, teardown := setupRefreshTokenTest(t, tc)
@@ -55,14 +55,14 @@ func TestRefreshTokenSuccess(t *testing.T) {
 
 func TestRefreshTokenInvalidToken(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, teardown := setupRefreshTokenTest(t, tc)
 	defer teardown()
 
 	t.Run("InvalidRefreshToken", func(t *testing.T) {
-		invalidReq := fixtures.GenerateRefreshTokenRequest("invalid_refresh_token")
+		invalidReq := tc.Fixtures.GenerateRefreshTokenRequest("invalid_refresh_token")
 
 		_, err := tc.AuthClient.RefreshToken(*invalidReq)
 		assert.Error(t, err)
@@ -70,7 +70,7 @@ func TestRefreshTokenInvalidToken(t *testing.T) {
 	})
 
 	t.Run("EmptyRefreshToken", func(t *testing.T) {
-		invalidReq := fixtures.GenerateRefreshTokenRequest("")
+		invalidReq := tc.Fixtures.GenerateRefreshTokenRequest("")
 		invalidReq.RefreshToken = ""
 
 		_, err := tc.AuthClient.RefreshToken(*invalidReq)
@@ -81,13 +81,13 @@ func TestRefreshTokenInvalidToken(t *testing.T) {
 
 func TestRefreshTokenAfterLogout(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	refreshReq, teardown := setupRefreshTokenTest(t, tc)
 	defer teardown()
 
-	logoutReq := fixtures.GenerateLogoutRequest(refreshReq.RefreshToken)
+	logoutReq := tc.Fixtures.GenerateLogoutRequest(refreshReq.RefreshToken)
 	err := tc.AuthClient.Logout(*logoutReq)
 	require.NoError(t, err)
 
@@ -98,7 +98,7 @@ func TestRefreshTokenAfterLogout(t *testing.T) {
 
 func TestRefreshTokenReuse(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	refreshReq, teardown := setupRefreshTokenTest(t, tc)
@@ -0,0 +1,60 @@
+package gateway_auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterBoundaryValues(t *testing.T) {
+	t.Parallel()
+	tc := NewTestContext(t)
+
+	cases := tc.Fixtures.BoundaryCases(tc.Fixtures.NewRegister().Build())
+
+	for _, bc := range cases {
+		bc := bc
+		t.Run(bc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := NewTestContext(t)
+			defer ctx.Cleanup()
+
+			// Each case registers its own user, so it needs its own username
+			// and email.
+			body := bc.Apply(ctx.Fixtures.NewRegister().Build())
+
+			var response fixtures.RegisterResponse
+			err := ctx.APIClient.Post("/v1/auth/register", body, &response)
+
+			if !bc.Valid {
+				require.Error(t, err, "expected %s=%v to be rejected", bc.Field.JSONName, bc.Value)
+				assert.Equal(t, http.StatusBadRequest, client.StatusCode(err))
+				return
+			}
+
+			// Values too short for the run marker may be taken already;
+			// the conflict means they passed validation.
+			if err != nil && strings.Contains(err.Error(), custom_errors.ErrUsernameExists.Error()) {
+				t.Logf("%s=%q is taken, so it was accepted", bc.Field.JSONName, bc.Value)
+				return
+			}
+			require.NoError(t, err, "expected %s=%v to be accepted", bc.Field.JSONName, bc.Value)
+
+			var registered fixtures.RegisterRequest
+			require.NoError(t, json.Unmarshal(body, &registered))
+
+			ctx.APIClient.SetToken(response.AccessToken)
+			user, err := ctx.UserClient.GetUserByUsername(registered.Username)
+			require.NoError(t, err, "Failed to get registered user for cleanup")
+			ctx.TrackUserForCleanup(user.ID, user.Username, response.AccessToken)
+		})
+	}
+}
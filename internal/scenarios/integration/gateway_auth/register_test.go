@@ -12,19 +12,19 @@ import (
 func setupRegisterTest(t *testing.T, tc *TestContext) (*fixtures.RegisterRequest, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
 
-	log.Info("Setting up test", "test", t.Name(), "username", registerReq.Username)
+	tc.Log.Info("Setting up test", "test", t.Name(), "username", registerReq.Username)
 
 	return registerReq, func() {
-		log.Info("Test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestRegisterSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	registerReq, teardown := setupRegisterTest(t, tc)
@@ -41,7 +41,7 @@ func TestRegisterSuccess(t *testing.T) {
 
 	user, err := tc.UserClient.GetUserByUsername(registerReq.Username)
 	if err != nil {
-		log.Warn("Failed to get user info for cleanup tracking", "username", registerReq.Username, "error", err.Error())
+		tc.Log.Warn("Failed to get user info for cleanup tracking", "username", registerReq.Username, "error", err.Error())
 	} else {
 		tc.TrackUserForCleanup(user.ID, user.Username, resp.AccessToken)
 	}
@@ -49,30 +49,28 @@ func TestRegisterSuccess(t *testing.T) {
 
 func TestRegisterInvalidInput(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, teardown := setupRegisterTest(t, tc)
 	defer teardown()
 
 	t.Run("EmptyUsername", func(t *testing.T) {
-		invalidReq := fixtures.GenerateRegisterRequest()
-		invalidReq.Username = ""
+		invalidReq := tc.Fixtures.NewRegister().WithUsername("").Build()
 		_, err := tc.AuthClient.Register(*invalidReq)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), custom_errors.ErrValidationFailed.Error())
 	})
 
 	t.Run("ShortUsername", func(t *testing.T) {
-		invalidReq := fixtures.GenerateRegisterRequest()
-		invalidReq.Username = "ab"
+		invalidReq := tc.Fixtures.NewRegister().WithUsernameLength(fixtures.UsernameMinLength - 1).Build()
 		_, err := tc.AuthClient.Register(*invalidReq)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), custom_errors.ErrValidationFailed.Error())
 	})
 
 	t.Run("LongUsername", func(t *testing.T) {
-		invalidReq := fixtures.GenerateRegisterRequest()
+		invalidReq := tc.Fixtures.GenerateRegisterRequest()
 		invalidReq.Username = "abcdefghijklmnopqrstuvwxyz1234567890"
 		_, err := tc.AuthClient.Register(*invalidReq)
 		assert.Error(t, err)
@@ -80,16 +78,14 @@ func TestRegisterInvalidInput(t *testing.T) {
 	})
 
 	t.Run("InvalidEmail", func(t *testing.T) {
-		invalidReq := fixtures.GenerateRegisterRequest()
-		invalidReq.Email = "invalid_email"
+		invalidReq := tc.Fixtures.NewRegister().WithEmail("invalid_email").Build()
 		_, err := tc.AuthClient.Register(*invalidReq)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), custom_errors.ErrValidationFailed.Error())
 	})
 
 	t.Run("ShortPassword", func(t *testing.T) {
-		invalidReq := fixtures.GenerateRegisterRequest()
-		invalidReq.Password = "12345"
+		invalidReq := tc.Fixtures.NewRegister().WithPasswordLength(fixtures.PasswordMinLength - 1).Build()
 		_, err := tc.AuthClient.Register(*invalidReq)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), custom_errors.ErrValidationFailed.Error())
@@ -98,7 +94,7 @@ func TestRegisterInvalidInput(t *testing.T) {
 
 func TestRegisterConflict(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	registerReq, teardown := setupRegisterTest(t, tc)
@@ -112,7 +108,7 @@ func TestRegisterConflict(t *testing.T) {
 
 	user, err := tc.UserClient.GetUserByUsername(registerReq.Username)
 	if err != nil {
-		log.Warn("Failed to get user info for cleanup tracking", "username", registerReq.Username, "error", err.Error())
+		tc.Log.Warn("Failed to get user info for cleanup tracking", "username", registerReq.Username, "error", err.Error())
 	} else {
 		tc.TrackUserForCleanup(user.ID, user.Username, resp.AccessToken)
 	}
@@ -120,18 +116,18 @@ func TestRegisterConflict(t *testing.T) {
 	tc.APIClient.SetToken("")
 
 	t.Run("DuplicateUsername", func(t *testing.T) {
-		conflictReq := fixtures.GenerateRegisterRequest()
+		conflictReq := tc.Fixtures.GenerateRegisterRequest()
 		conflictReq.Username = registerReq.Username
-		conflictReq.Email = fixtures.GenerateRegisterRequest().Email
+		conflictReq.Email = tc.Fixtures.GenerateRegisterRequest().Email
 		_, err := tc.AuthClient.Register(*conflictReq)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), custom_errors.ErrUsernameExists.Error())
 	})
 
 	t.Run("DuplicateEmail", func(t *testing.T) {
-		conflictReq := fixtures.GenerateRegisterRequest()
+		conflictReq := tc.Fixtures.GenerateRegisterRequest()
 		conflictReq.Email = registerReq.Email
-		conflictReq.Username = fixtures.GenerateRegisterRequest().Username
+		conflictReq.Username = tc.Fixtures.GenerateRegisterRequest().Username
 		_, err := tc.AuthClient.Register(*conflictReq)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), custom_errors.ErrEmailExists.Error())
@@ -12,8 +12,8 @@ import (
 func setupUpdatePasswordTest(t *testing.T, tc *TestContext) (*fixtures.RegisterRequest, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up update password test", "test", t.Name(), "username", registerReq.Username)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up update password test", "test", t.Name(), "username", registerReq.Username)
 
 	registerResp, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register test user")
@@ -23,20 +23,20 @@ func setupUpdatePasswordTest(t *testing.T, tc *TestContext) (*fixtures.RegisterR
 
 	user, err := tc.UserClient.GetUserByUsername(registerReq.Username)
 	if err != nil {
-		log.Warn("Failed to get user info for cleanup tracking", "username", registerReq.Username, "error", err.Error())
+		tc.Log.Warn("Failed to get user info for cleanup tracking", "username", registerReq.Username, "error", err.Error())
 	} else {
 		tc.TrackUserForCleanup(user.ID, user.Username, registerResp.AccessToken)
 	}
 
 	return registerReq, func() {
-		log.Info("Update password test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Update password test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestUpdatePasswordSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	registerReq, teardown := setupUpdatePasswordTest(t, tc)
@@ -53,7 +53,7 @@ func TestUpdatePasswordSuccess(t *testing.T) {
 	require.NotNil(t, resp, "Expected non-nil response")
 	assert.NotEmpty(t, resp.Message, "Expected success message")
 
-	loginReq := fixtures.GenerateLoginRequest(registerReq.Username, "NewPassword123!")
+	loginReq := tc.Fixtures.GenerateLoginRequest(registerReq.Username, "NewPassword123!")
 	loginResp, err := tc.AuthClient.Login(*loginReq)
 
 	require.NoError(t, err, "Should login with new password")
@@ -63,7 +63,7 @@ func TestUpdatePasswordSuccess(t *testing.T) {
 
 func TestUpdatePasswordValidation(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	registerReq, teardown := setupUpdatePasswordTest(t, tc)
@@ -105,7 +105,7 @@ func TestUpdatePasswordValidation(t *testing.T) {
 
 func TestUpdatePasswordUnauthorized(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, teardown := setupUpdatePasswordTest(t, tc)
@@ -125,7 +125,7 @@ func TestUpdatePasswordUnauthorized(t *testing.T) {
 
 func TestUpdatePasswordWrongOldPassword(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, teardown := setupUpdatePasswordTest(t, tc)
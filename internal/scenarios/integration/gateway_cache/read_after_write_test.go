@@ -0,0 +1,284 @@
+package gateway_cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/coherence"
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// coherenceConfirmReads is how many reads must stay fresh after the first
+// fresh one, to catch a second cache layer serving the old value.
+const coherenceConfirmReads = 2
+
+// probeWorker is one independent set of entities the probes write to. Each
+// worker drives only its own users' clients, so workers can run at once.
+type probeWorker struct {
+	tc       *TestContext
+	subject  *factory.User
+	observer *factory.User
+	postID   int64
+
+	notifications []int64
+}
+
+func newProbeWorker(t *testing.T, tc *TestContext) *probeWorker {
+	t.Helper()
+
+	ctx := tc.APIClient.Context()
+	users, err := tc.Factory.Users(ctx, 2)
+	require.NoError(t, err, "Failed to create probe users")
+	posts, err := tc.Factory.PostBy(ctx, users[0], 1)
+	require.NoError(t, err, "Failed to create probe post")
+
+	return &probeWorker{tc: tc, subject: users[0], observer: users[1], postID: posts[0].ID}
+}
+
+// probes returns one round of write-then-read probes, labelled with the
+// cache key patterns configured for them.
+func (w *probeWorker) probes() []coherence.Probe {
+	s, o := w.subject, w.observer
+	gen := w.tc.Fixtures
+
+	var fullName, avatarURL, title string
+	var unreadBefore = -1
+
+	probes := []coherence.Probe{
+		{
+			Endpoint: "GET /v1/users/{id}",
+			Write: func() error {
+				fullName = gen.Faker().Name()
+				_, err := s.Users.UpdateUser(*gen.NewUserUpdate(s.ID).WithUsername(s.Username).WithFullName(fullName).Build())
+				return err
+			},
+			Read: func() (bool, string, error) {
+				u, err := o.Users.GetUserByID(s.ID)
+				if err != nil {
+					return false, "", err
+				}
+				return u.FullName == fullName, "full_name=" + u.FullName, nil
+			},
+		},
+		{
+			Endpoint: "GET /v1/users/username/{username}",
+			Write: func() error {
+				fullName = gen.Faker().Name()
+				_, err := s.Users.UpdateUser(*gen.NewUserUpdate(s.ID).WithUsername(s.Username).WithFullName(fullName).Build())
+				return err
+			},
+			Read: func() (bool, string, error) {
+				u, err := o.Users.GetUserByUsername(s.Username)
+				if err != nil {
+					return false, "", err
+				}
+				return u.FullName == fullName, "full_name=" + u.FullName, nil
+			},
+		},
+		{
+			Endpoint: "GET /v1/users/{id} (avatar)",
+			Write: func() error {
+				req := gen.GenerateUpdateAvatarRequest()
+				avatarURL = req.AvatarURL
+				return s.Users.UpdateAvatar(*req)
+			},
+			Read: func() (bool, string, error) {
+				u, err := o.Users.GetUserByID(s.ID)
+				if err != nil {
+					return false, "", err
+				}
+				return u.AvatarURL == avatarURL, "avatar_url=" + u.AvatarURL, nil
+			},
+		},
+		{
+			Endpoint: "GET /v1/posts/{id}",
+			Write: func() error {
+				title = gen.NewPost().Build().Title
+				_, err := s.Posts.UpdatePost(w.postID, fixtures.UpdatePostRequest{Title: title})
+				return err
+			},
+			Read: func() (bool, string, error) {
+				p, err := o.Posts.GetPostByID(w.postID)
+				if err != nil {
+					return false, "", err
+				}
+				return p.Title == title, "title=" + p.Title, nil
+			},
+		},
+		{
+			Endpoint: "GET /v1/posts/list?author_id={id}",
+			Write: func() error {
+				title = gen.NewPost().Build().Title
+				_, err := s.Posts.UpdatePost(w.postID, fixtures.UpdatePostRequest{Title: title})
+				return err
+			},
+			Read: func() (bool, string, error) {
+				list, err := o.Posts.ListPosts(s.ID, time.Time{}, time.Time{}, 0, 100)
+				if err != nil {
+					return false, "", err
+				}
+				for _, p := range list.Posts {
+					if p.ID == w.postID {
+						return p.Title == title, "title=" + p.Title, nil
+					}
+				}
+				return false, fmt.Sprintf("post %d missing", w.postID), nil
+			},
+		},
+		{
+			Endpoint: "GET /v1/relation/{id}/followers (follow)",
+			Write: func() error {
+				_, err := s.Relations.Follow(o.ID)
+				return err
+			},
+			Read: func() (bool, string, error) {
+				resp, err := o.Relations.GetFollowers(o.ID, 1, 100)
+				if err != nil {
+					return false, "", err
+				}
+				return containsRelationUser(resp.Followers, s.ID), fmt.Sprintf("total=%d", resp.Total), nil
+			},
+		},
+		{
+			Endpoint: "GET /v1/relation/{id}/followees (unfollow)",
+			Write: func() error {
+				_, err := s.Relations.Unfollow(o.ID)
+				return err
+			},
+			Read: func() (bool, string, error) {
+				resp, err := o.Relations.GetFollowees(s.ID, 1, 100)
+				if err != nil {
+					return false, "", err
+				}
+				return !containsRelationUser(resp.Followees, o.ID), fmt.Sprintf("total=%d", resp.Total), nil
+			},
+		},
+		{
+			Endpoint: "GET /v1/notification/unread-count (send)",
+			Write: func() error {
+				resp, err := o.Notifications.SendNotification(*gen.NewNotification(s.ID).WithType(fixtures.NotificationTypeSystem).Build())
+				if err != nil {
+					return err
+				}
+				w.notifications = append(w.notifications, resp.NotificationID)
+				return nil
+			},
+			Read: func() (bool, string, error) {
+				resp, err := s.Notifications.GetUnreadCount(s.ID)
+				if err != nil {
+					return false, "", err
+				}
+				// The warming read records the count the write must raise.
+				if unreadBefore < 0 {
+					unreadBefore = resp.Count
+					return false, "", nil
+				}
+				return resp.Count > unreadBefore, fmt.Sprintf("count=%d before=%d", resp.Count, unreadBefore), nil
+			},
+		},
+		{
+			Endpoint: "GET /v1/notification/unread-count (read all)",
+			Write: func() error {
+				_, err := s.Notifications.ReadAllUserNotifications(s.ID)
+				return err
+			},
+			Read: func() (bool, string, error) {
+				resp, err := s.Notifications.GetUnreadCount(s.ID)
+				if err != nil {
+					return false, "", err
+				}
+				return resp.Count == 0, fmt.Sprintf("count=%d", resp.Count), nil
+			},
+		},
+	}
+	for i := range probes {
+		probes[i].KeyPattern = cfg.Cache.KeyPattern(probes[i].Endpoint)
+	}
+	return probes
+}
+
+// removeNotifications deletes what the unread-count probes sent. Failures
+// are only logged: deleting the subject removes them as well.
+func (w *probeWorker) removeNotifications() {
+	for _, id := range w.notifications {
+		if _, err := w.subject.Notifications.RemoveNotification(id); err != nil {
+			w.tc.Log.Warn("Failed to remove probe notification", "notification_id", id, "error", err.Error())
+		}
+	}
+	w.notifications = nil
+}
+
+func containsRelationUser(users []*fixtures.RelationUser, id int64) bool {
+	for _, u := range users {
+		if u.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestReadAfterWriteCoherence(t *testing.T) {
+	t.Parallel()
+	tc := NewTestContext(t)
+
+	opts := coherence.Options{
+		Window:  cfg.Cache.StalenessWindow(),
+		Poll:    max(cfg.Cache.StalenessWindow()/10, 10*time.Millisecond),
+		Confirm: coherenceConfirmReads,
+	}
+
+	workers := make([]*probeWorker, cfg.Cache.Concurrency)
+	for i := range workers {
+		workers[i] = newProbeWorker(t, tc)
+	}
+
+	ctx, cancel := context.WithTimeout(tc.APIClient.Context(), cfg.Test.TestTimeout)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results []coherence.Result
+	)
+	for _, w := range workers {
+		wg.Add(1)
+		go func(w *probeWorker) {
+			defer wg.Done()
+			defer w.removeNotifications()
+
+			for round := 0; round < cfg.Cache.Rounds; round++ {
+				for _, p := range w.probes() {
+					res := coherence.Run(ctx, p, opts)
+					mu.Lock()
+					results = append(results, res)
+					mu.Unlock()
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	rep := coherence.Summarize(results)
+	tc.Log.Info("Read-after-write coherence report",
+		"workers", len(workers),
+		"rounds", cfg.Cache.Rounds,
+		"window", opts.Window.String(),
+		"report", "\n"+rep.String())
+
+	for _, r := range results {
+		if r.Err != nil {
+			t.Errorf("Probe %s failed: %v", r.Endpoint, r.Err)
+		}
+	}
+	for _, s := range rep.Misbehaving() {
+		t.Errorf("%s (cache key %s) served pre-write data past %s in %d of %d probes, e.g. %s",
+			s.Endpoint, s.KeyPattern, opts.Window, s.Stale, s.Probes, s.Example)
+	}
+	assert.Empty(t, rep.Misbehaving(), "Reads must reflect writes within the staleness window")
+}
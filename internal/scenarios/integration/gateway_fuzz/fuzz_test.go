@@ -0,0 +1,375 @@
+package gateway_fuzz
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/Soloda1/pinstack-system-tests/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedCount is how many generated requests seed each corpus, besides the
+// boundary cases of the first one.
+const seedCount = 5
+
+// minEchoLen is the shortest input checked for being echoed. Shorter inputs,
+// and inputs of letters and spaces only, can turn up in an error message by
+// coincidence ("invalid", "user not found").
+const minEchoLen = 8
+
+// seeds returns seedCount generated requests followed by the boundary cases of
+// the first that still decode into T, so the corpus starts at the edges the
+// constraints declare as well as in the middle.
+func seeds[T any](gen *fixtures.Generator, build func() *T) []T {
+	out := make([]T, 0, seedCount)
+	for i := 0; i < seedCount; i++ {
+		out = append(out, *build())
+	}
+	for _, bc := range gen.BoundaryCases(&out[0]) {
+		var req T
+		if err := json.Unmarshal(bc.Body, &req); err == nil {
+			out = append(out, req)
+		}
+	}
+	return out
+}
+
+// checkResponse asserts the invariants every input must keep: the gateway
+// answers, never with a 5xx, errors come as {status, message} JSON, and no
+// error message repeats one of the inputs.
+func checkResponse(t *testing.T, err error, inputs ...string) {
+	t.Helper()
+	if err == nil {
+		return
+	}
+
+	var apiErr *client.APIError
+	require.True(t, errors.As(err, &apiErr), "request failed without a gateway response: %v", err)
+	assert.Less(t, apiErr.StatusCode, http.StatusInternalServerError, "server error: %v", apiErr)
+	assert.NoError(t, apiErr.Err, "status %d body is not the gateway's JSON error", apiErr.StatusCode)
+	for _, in := range inputs {
+		if echoable(in) {
+			assert.NotContains(t, apiErr.Message, in, "error message echoes the input")
+		}
+	}
+}
+
+func echoable(in string) bool {
+	if len(in) < minEchoLen {
+		return false
+	}
+	return strings.IndexFunc(in, func(r rune) bool { return !unicode.IsLetter(r) && r != ' ' }) >= 0
+}
+
+// cleanup deletes an entity an input created when the iteration ends, the
+// way the factory does for its own, and logs a failure to log.
+func cleanup(t *testing.T, log *logger.Logger, kind string, del func() error, attrs ...any) {
+	if !cfg.Test.Cleanup {
+		return
+	}
+	t.Cleanup(func() {
+		if err := del(); err != nil {
+			log.Warn("Failed to delete "+kind+" during cleanup", append(attrs, slog.String("error", err.Error()))...)
+		}
+	})
+}
+
+// use points u's clients at the iteration's trace.
+func use(t *testing.T, u *factory.User) {
+	u.API.SetContext(tracing.StartTest(t))
+}
+
+// query builds query parameters from name/value pairs, leaving out empty
+// values like the typed clients do.
+func query(pairs ...string) url.Values {
+	q := url.Values{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			q.Set(pairs[i], pairs[i+1])
+		}
+	}
+	return q
+}
+
+func FuzzRegister(f *testing.F) {
+	tc := NewTestContext(f)
+	for _, r := range seeds(tc.Fixtures, func() *fixtures.RegisterRequest { return tc.Fixtures.NewRegister().Build() }) {
+		f.Add(r.Username, r.Email, r.Password, r.FullName, r.Bio, r.AvatarURL)
+	}
+
+	f.Fuzz(func(t *testing.T, username, email, password, fullName, bio, avatarURL string) {
+		api := client.NewClient(cfg, logger.ForTest(t))
+		api.SetContext(tracing.StartTest(t))
+
+		resp, err := client.NewAuthClient(api).Register(fixtures.RegisterRequest{
+			Username:  username,
+			Email:     email,
+			Password:  password,
+			FullName:  fullName,
+			Bio:       bio,
+			AvatarURL: avatarURL,
+		})
+		checkResponse(t, err, username, email, password, fullName, bio, avatarURL)
+		if err != nil {
+			return
+		}
+
+		api.SetToken(resp.AccessToken)
+		users := client.NewUserClient(api)
+		cleanup(t, tc.Log, "user", func() error {
+			u, err := users.GetUserByUsername(username)
+			if err != nil {
+				return err
+			}
+			return users.DeleteUser(u.ID)
+		}, slog.String("username", username))
+	})
+}
+
+func FuzzUpdateUser(f *testing.F) {
+	tc := NewTestContext(f)
+	u, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(f, err, "Failed to create user")
+
+	for _, r := range seeds(tc.Fixtures, func() *fixtures.UpdateUserRequest { return tc.Fixtures.NewUserUpdate(u.ID).Build() }) {
+		f.Add(r.Username, r.Email, r.FullName, r.Bio)
+	}
+
+	f.Fuzz(func(t *testing.T, username, email, fullName, bio string) {
+		use(t, u)
+		_, err := u.Users.UpdateUser(fixtures.UpdateUserRequest{
+			ID:       u.ID,
+			Username: username,
+			Email:    email,
+			FullName: fullName,
+			Bio:      bio,
+		})
+		checkResponse(t, err, username, email, fullName, bio)
+	})
+}
+
+// postArgs are the fuzz arguments of a post request; the first tag and media
+// item stand for the lists.
+type postArgs struct {
+	title, content, tag, mediaType, mediaURL string
+	position                                 int
+}
+
+func postSeeds(tc *TestContext) []postArgs {
+	var out []postArgs
+	for _, r := range seeds(tc.Fixtures, func() *fixtures.CreatePostRequest {
+		return tc.Fixtures.NewPost().WithTags(1).WithMedia(fixtures.MediaTypeImage, 1).Build()
+	}) {
+		var (
+			tag   string
+			media fixtures.MediaItemInput
+		)
+		if len(r.Tags) > 0 {
+			tag = r.Tags[0]
+		}
+		if len(r.MediaItems) > 0 {
+			media = r.MediaItems[0]
+		}
+		out = append(out, postArgs{r.Title, r.Content, tag, media.Type, media.URL, media.Position})
+	}
+	return out
+}
+
+// postLists turns fuzz arguments back into tag and media lists, empty when
+// every argument of the item is zero.
+func postLists(tag, mediaType, mediaURL string, position int) ([]string, []fixtures.MediaItemInput) {
+	var (
+		tags  []string
+		media []fixtures.MediaItemInput
+	)
+	if tag != "" {
+		tags = []string{tag}
+	}
+	if mediaType != "" || mediaURL != "" || position != 0 {
+		media = []fixtures.MediaItemInput{{Type: mediaType, URL: mediaURL, Position: position}}
+	}
+	return tags, media
+}
+
+func FuzzCreatePost(f *testing.F) {
+	tc := NewTestContext(f)
+	u, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(f, err, "Failed to create author")
+
+	for _, a := range postSeeds(tc) {
+		f.Add(a.title, a.content, a.tag, a.mediaType, a.mediaURL, a.position)
+	}
+
+	f.Fuzz(func(t *testing.T, title, content, tag, mediaType, mediaURL string, position int) {
+		use(t, u)
+		tags, media := postLists(tag, mediaType, mediaURL, position)
+		post, err := u.Posts.CreatePost(fixtures.CreatePostRequest{
+			Title:      title,
+			Content:    content,
+			MediaItems: media,
+			Tags:       tags,
+		})
+		checkResponse(t, err, title, content, tag, mediaType, mediaURL)
+		if err != nil {
+			return
+		}
+		cleanup(t, tc.Log, "post", func() error {
+			return u.Posts.DeletePost(post.ID)
+		}, slog.Int64("post_id", post.ID))
+	})
+}
+
+func FuzzUpdatePost(f *testing.F) {
+	tc := NewTestContext(f)
+	ctx := tc.APIClient.Context()
+	u, err := tc.Factory.User(ctx)
+	require.NoError(f, err, "Failed to create author")
+	post, err := tc.Factory.PostFrom(ctx, u, tc.Fixtures.NewPost())
+	require.NoError(f, err, "Failed to create post")
+
+	for _, a := range postSeeds(tc) {
+		f.Add(a.title, a.content, a.tag, a.mediaType, a.mediaURL, a.position)
+	}
+
+	f.Fuzz(func(t *testing.T, title, content, tag, mediaType, mediaURL string, position int) {
+		use(t, u)
+		tags, media := postLists(tag, mediaType, mediaURL, position)
+		_, err := u.Posts.UpdatePost(post.ID, fixtures.UpdatePostRequest{
+			Title:      title,
+			Content:    content,
+			MediaItems: media,
+			Tags:       tags,
+		})
+		checkResponse(t, err, title, content, tag, mediaType, mediaURL)
+	})
+}
+
+// FuzzSendNotification mutates the type and the payload freely. The
+// recipient is picked by the fuzzed selector from the test's own users and IDs
+// that cannot exist, so no notification lands on a user outside the test. A
+// payload that is valid JSON is sent as is, anything else as the data string.
+func FuzzSendNotification(f *testing.F) {
+	tc := NewTestContext(f)
+	users, err := tc.Factory.Users(tc.APIClient.Context(), 2)
+	require.NoError(f, err, "Failed to create users")
+	sender, recipient := users[0], users[1]
+
+	recipients := []int64{recipient.ID, sender.ID, 0, -1, math.MinInt64, math.MaxInt64}
+
+	for i, r := range seeds(tc.Fixtures, func() *fixtures.SendNotificationRequest {
+		return tc.Fixtures.NewNotification(recipient.ID).Build()
+	}) {
+		payload, err := json.Marshal(r.Payload)
+		require.NoError(f, err)
+		f.Add(uint8(i), r.Type, payload)
+	}
+
+	f.Fuzz(func(t *testing.T, selector uint8, notificationType string, payload []byte) {
+		use(t, sender)
+		userID := recipients[int(selector)%len(recipients)]
+
+		var body interface{}
+		switch {
+		case len(payload) == 0:
+		case json.Valid(payload):
+			body = json.RawMessage(payload)
+		default:
+			body = map[string]interface{}{fixtures.PayloadDataKey: string(payload)}
+		}
+
+		resp, err := sender.Notifications.SendNotification(fixtures.SendNotificationRequest{
+			UserID:  userID,
+			Type:    notificationType,
+			Payload: body,
+		})
+		checkResponse(t, err, notificationType, string(payload))
+		if err != nil {
+			return
+		}
+		if userID != recipient.ID && userID != sender.ID {
+			t.Errorf("notification to non-existent user %d was accepted", userID)
+		}
+
+		owner := sender
+		if userID == recipient.ID {
+			owner = recipient
+			use(t, recipient)
+		}
+		cleanup(t, tc.Log, "notification", func() error {
+			_, err := owner.Notifications.RemoveNotification(resp.NotificationID)
+			return err
+		}, slog.Int64("notification_id", resp.NotificationID), slog.Int64("user_id", userID))
+	})
+}
+
+func FuzzListPosts(f *testing.F) {
+	tc := NewTestContext(f)
+	u, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(f, err, "Failed to create user")
+
+	gen := tc.Fixtures
+	for i := 0; i < seedCount; i++ {
+		after := gen.Faker().PastDate()
+		f.Add(
+			strconv.FormatInt(u.ID, 10),
+			after.Format(time.RFC3339),
+			after.Add(time.Duration(gen.Rand().Intn(30)+1)*24*time.Hour).Format(time.RFC3339),
+			strconv.Itoa(gen.Rand().Intn(50)),
+			strconv.Itoa(gen.Rand().Intn(100)+1),
+		)
+	}
+	f.Add("", "", "", "", "")
+	f.Add("-1", "yesterday", "2024-13-45T25:61:61Z", "-1", "0")
+	f.Add("9223372036854775808", "1970-01-01T00:00:00Z", "9999-12-31T23:59:59Z", "2147483648", "1e9")
+
+	f.Fuzz(func(t *testing.T, authorID, createdAfter, createdBefore, offset, limit string) {
+		use(t, u)
+		var resp fixtures.ListPostsResponse
+		err := u.API.Get("/v1/posts/list", query(
+			"author_id", authorID,
+			"created_after", createdAfter,
+			"created_before", createdBefore,
+			"offset", offset,
+			"limit", limit,
+		), &resp)
+		checkResponse(t, err, authorID, createdAfter, createdBefore, offset, limit)
+	})
+}
+
+func FuzzSearchUsers(f *testing.F) {
+	tc := NewTestContext(f)
+	u, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(f, err, "Failed to create user")
+
+	gen := tc.Fixtures
+	for i := 0; i < seedCount; i++ {
+		r := gen.NewRegister().Build()
+		f.Add(r.Username[:3], strconv.Itoa(gen.Rand().Intn(5)+1), strconv.Itoa(gen.Rand().Intn(50)+1))
+		f.Add(r.FullName, "", "")
+	}
+	f.Add(u.Username, "1", "10")
+	f.Add("", "", "")
+	f.Add("%", "0", "-1")
+	f.Add("_", "9223372036854775808", "abc")
+
+	f.Fuzz(func(t *testing.T, q, page, limit string) {
+		use(t, u)
+		var resp fixtures.SearchUsersResponse
+		err := u.API.Get("/v1/users/search", query("query", q, "page", page, "limit", limit), &resp)
+		checkResponse(t, err, q, page, limit)
+	})
+}
@@ -1,6 +1,7 @@
 package gateway_notification
 
 import (
+	"context"
 	"flag"
 	"os"
 	"sync"
@@ -8,8 +9,11 @@ import (
 
 	"github.com/Soloda1/pinstack-system-tests/config"
 	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
 	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/histogram"
 	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/Soloda1/pinstack-system-tests/internal/tracing"
 )
 
 var (
@@ -31,6 +35,9 @@ type NotificationCleanupInfo struct {
 }
 
 type TestContext struct {
+	Fixtures             *fixtures.Generator
+	Factory              *factory.Factory
+	Log                  *logger.Logger
 	APIClient            *client.Client
 	AuthClient           *client.AuthClient
 	UserClient           *client.UserClient
@@ -40,9 +47,15 @@ type TestContext struct {
 	mu                   sync.Mutex
 }
 
-func NewTestContext() *TestContext {
-	apiClient := client.NewClient(cfg, log)
+func NewTestContext(t *testing.T) *TestContext {
+	testLog := logger.ForTest(t)
+	gen := fixtures.ForTest(t)
+	apiClient := client.NewClient(cfg, testLog)
+	apiClient.SetContext(tracing.StartTest(t))
 	return &TestContext{
+		Fixtures:             gen,
+		Factory:              factory.New(t, cfg, testLog, gen),
+		Log:                  testLog,
 		APIClient:            apiClient,
 		AuthClient:           client.NewAuthClient(apiClient),
 		UserClient:           client.NewUserClient(apiClient),
@@ -62,7 +75,7 @@ func (tc *TestContext) TrackUserForCleanup(userID int64, username, accessToken s
 		AccessToken: accessToken,
 	}
 	tc.CreatedUsers = append(tc.CreatedUsers, userInfo)
-	log.Debug("Added user to cleanup list", "user_id", userID, "username", username)
+	tc.Log.Debug("Added user to cleanup list", "user_id", userID, "username", username)
 }
 
 func (tc *TestContext) TrackNotificationForCleanup(notificationID, userID int64, senderAccessToken, recipientAccessToken string) {
@@ -76,7 +89,7 @@ func (tc *TestContext) TrackNotificationForCleanup(notificationID, userID int64,
 		RecipientAccessToken: recipientAccessToken,
 	}
 	tc.CreatedNotifications = append(tc.CreatedNotifications, notificationInfo)
-	log.Debug("Added notification to cleanup list", "notification_id", notificationID, "user_id", userID)
+	tc.Log.Debug("Added notification to cleanup list", "notification_id", notificationID, "user_id", userID)
 }
 
 func (tc *TestContext) Cleanup() {
@@ -85,56 +98,56 @@ func (tc *TestContext) Cleanup() {
 
 	// Clean up notifications first
 	if len(tc.CreatedNotifications) > 0 {
-		log.Info("Starting notification cleanup process", "notifications_to_delete", len(tc.CreatedNotifications))
+		tc.Log.Info("Starting notification cleanup process", "notifications_to_delete", len(tc.CreatedNotifications))
 
 		var successfulNotificationDeletions int
 
 		for _, notificationInfo := range tc.CreatedNotifications {
-			log.Debug("Attempting to delete notification", "notification_id", notificationInfo.ID, "user_id", notificationInfo.UserID)
+			tc.Log.Debug("Attempting to delete notification", "notification_id", notificationInfo.ID, "user_id", notificationInfo.UserID)
 
 			tc.APIClient.SetToken(notificationInfo.RecipientAccessToken)
 
 			resp, err := tc.NotificationClient.RemoveNotification(notificationInfo.ID)
 			if err != nil {
-				log.Warn("Failed to delete notification during cleanup",
+				tc.Log.Warn("Failed to delete notification during cleanup",
 					"notification_id", notificationInfo.ID,
 					"user_id", notificationInfo.UserID,
 					"error", err.Error())
 			} else {
-				log.Debug("Successfully deleted notification", "notification_id", notificationInfo.ID, "success", resp.Success)
+				tc.Log.Debug("Successfully deleted notification", "notification_id", notificationInfo.ID, "success", resp.Success)
 				successfulNotificationDeletions++
 			}
 		}
 
-		log.Info("Notification cleanup process completed",
+		tc.Log.Info("Notification cleanup process completed",
 			"successful_deletions", successfulNotificationDeletions,
 			"total_notifications", len(tc.CreatedNotifications))
 	}
 
 	// Then clean up users
 	if len(tc.CreatedUsers) > 0 {
-		log.Info("Starting user cleanup process", "users_to_delete", len(tc.CreatedUsers))
+		tc.Log.Info("Starting user cleanup process", "users_to_delete", len(tc.CreatedUsers))
 
 		var successfulUserDeletions int
 
 		for _, userInfo := range tc.CreatedUsers {
-			log.Debug("Attempting to delete user", "user_id", userInfo.ID, "username", userInfo.Username)
+			tc.Log.Debug("Attempting to delete user", "user_id", userInfo.ID, "username", userInfo.Username)
 
 			tc.APIClient.SetToken(userInfo.AccessToken)
 
 			err := tc.UserClient.DeleteUser(userInfo.ID)
 			if err != nil {
-				log.Warn("Failed to delete user during cleanup",
+				tc.Log.Warn("Failed to delete user during cleanup",
 					"user_id", userInfo.ID,
 					"username", userInfo.Username,
 					"error", err.Error())
 			} else {
-				log.Debug("Successfully deleted user", "user_id", userInfo.ID, "username", userInfo.Username)
+				tc.Log.Debug("Successfully deleted user", "user_id", userInfo.ID, "username", userInfo.Username)
 				successfulUserDeletions++
 			}
 		}
 
-		log.Info("User cleanup process completed",
+		tc.Log.Info("User cleanup process completed",
 			"successful_deletions", successfulUserDeletions,
 			"total_users", len(tc.CreatedUsers))
 	}
@@ -148,8 +161,8 @@ func (tc *TestContext) Cleanup() {
 func setupTestUser(t *testing.T, tc *TestContext) (string, int64, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up test user", "test", t.Name(), "username", registerReq.Username)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up test user", "test", t.Name(), "username", registerReq.Username)
 
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	if err != nil {
@@ -164,7 +177,7 @@ func setupTestUser(t *testing.T, tc *TestContext) (string, int64, func()) {
 	tc.TrackUserForCleanup(userByUsername.ID, userByUsername.Username, tokens.AccessToken)
 
 	return tokens.AccessToken, userByUsername.ID, func() {
-		log.Info("Test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
@@ -173,10 +186,27 @@ func TestMain(m *testing.M) {
 	flag.Parse()
 
 	cfg = config.MustLoad("../../../../config")
-	log = logger.New(cfg.Env)
+	log = logger.New(cfg.Env, cfg.Test.LogLevel)
+	logger.SetRedactedFields(cfg.Redaction.Fields...)
+	logger.SetTestLevel(cfg.Env, cfg.Test.LogLevel)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Error("Failed to set up tracing", "error", err.Error())
+		os.Exit(1)
+	}
+
 	log.Info("Starting notification gateway tests", "env", cfg.Env)
 
+	saveLatencies := histogram.RecordClients(cfg.Perf, "gateway_notification")
 	code := m.Run()
+	if err := saveLatencies(); err != nil {
+		log.Warn("Failed to save latency histograms", "error", err.Error())
+	}
+
+	if err := shutdownTracing(context.Background()); err != nil {
+		log.Warn("Failed to flush traces", "error", err.Error())
+	}
 
 	os.Exit(code)
 }
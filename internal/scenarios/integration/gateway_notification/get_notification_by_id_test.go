@@ -12,8 +12,8 @@ import (
 func setupGetNotificationByIDTest(t *testing.T, tc *TestContext) (senderToken string, recipientID int64, recipientToken string, notificationID int64, teardown func()) {
 	t.Helper()
 
-	senderRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up get notification by ID test - registering sender", "test", t.Name(), "username", senderRegisterReq.Username)
+	senderRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up get notification by ID test - registering sender", "test", t.Name(), "username", senderRegisterReq.Username)
 
 	senderTokens, err := tc.AuthClient.Register(*senderRegisterReq)
 	require.NoError(t, err, "Failed to register sender user")
@@ -23,8 +23,8 @@ func setupGetNotificationByIDTest(t *testing.T, tc *TestContext) (senderToken st
 
 	tc.TrackUserForCleanup(senderUser.ID, senderUser.Username, senderTokens.AccessToken)
 
-	recipientRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up get notification by ID test - registering recipient", "test", t.Name(), "username", recipientRegisterReq.Username)
+	recipientRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up get notification by ID test - registering recipient", "test", t.Name(), "username", recipientRegisterReq.Username)
 
 	recipientTokens, err := tc.AuthClient.Register(*recipientRegisterReq)
 	require.NoError(t, err, "Failed to register recipient user")
@@ -35,7 +35,7 @@ func setupGetNotificationByIDTest(t *testing.T, tc *TestContext) (senderToken st
 	tc.TrackUserForCleanup(recipientUser.ID, recipientUser.Username, recipientTokens.AccessToken)
 
 	tc.APIClient.SetToken(senderTokens.AccessToken)
-	sendReq := fixtures.GenerateSendNotificationRequest(recipientUser.ID)
+	sendReq := tc.Fixtures.GenerateSendNotificationRequest(recipientUser.ID)
 
 	sendResp, err := tc.NotificationClient.SendNotification(*sendReq)
 	require.NoError(t, err, "Failed to send notification for test setup")
@@ -44,14 +44,14 @@ func setupGetNotificationByIDTest(t *testing.T, tc *TestContext) (senderToken st
 	tc.TrackNotificationForCleanup(sendResp.NotificationID, recipientUser.ID, senderTokens.AccessToken, recipientTokens.AccessToken)
 
 	return senderTokens.AccessToken, recipientUser.ID, recipientTokens.AccessToken, sendResp.NotificationID, func() {
-		log.Info("Get notification by ID test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Get notification by ID test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestGetNotificationByIDSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, recipientID, recipientAccessToken, notificationID, teardown := setupGetNotificationByIDTest(t, tc)
@@ -71,12 +71,12 @@ func TestGetNotificationByIDSuccess(t *testing.T) {
 	assert.False(t, notification.IsRead, "Notification should be unread by default")
 	assert.NotZero(t, notification.CreatedAt, "Created at should not be zero")
 
-	log.Info("Successfully retrieved notification by ID", "notification_id", notificationID, "type", notification.Type)
+	tc.Log.Info("Successfully retrieved notification by ID", "notification_id", notificationID, "type", notification.Type)
 }
 
 func TestGetNotificationByIDUnauthorized(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, _, notificationID, teardown := setupGetNotificationByIDTest(t, tc)
@@ -90,12 +90,12 @@ func TestGetNotificationByIDUnauthorized(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrUnauthenticated.Error(), "Error should be unauthenticated")
 	assert.Nil(t, notification, "Notification should be nil on error")
 
-	log.Info("Correctly rejected unauthorized request for notification", "notification_id", notificationID)
+	tc.Log.Info("Correctly rejected unauthorized request for notification", "notification_id", notificationID)
 }
 
 func TestGetNotificationByIDForbidden(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	senderToken, _, _, notificationID, teardown := setupGetNotificationByIDTest(t, tc)
@@ -109,15 +109,15 @@ func TestGetNotificationByIDForbidden(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrNotificationAccessDenied.Error(), "Error should be access denied")
 	assert.Nil(t, notification, "Notification should be nil on error")
 
-	log.Info("Correctly rejected forbidden request for notification", "notification_id", notificationID)
+	tc.Log.Info("Correctly rejected forbidden request for notification", "notification_id", notificationID)
 }
 
 func TestGetNotificationByIDInvalidID(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
-	registerReq := fixtures.GenerateRegisterRequest()
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register user")
 
@@ -162,7 +162,7 @@ func TestGetNotificationByIDInvalidID(t *testing.T) {
 			assert.Contains(t, err.Error(), testCase.expectedError.Error(), "Error should match expected type for %s", testCase.description)
 			assert.Nil(t, notification, "Notification should be nil on error")
 
-			log.Info("Correctly rejected request for invalid notification ID",
+			tc.Log.Info("Correctly rejected request for invalid notification ID",
 				"notification_id", testCase.notificationID,
 				"description", testCase.description,
 				"expected_error", testCase.expectedError.Error())
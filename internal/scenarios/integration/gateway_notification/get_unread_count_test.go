@@ -3,7 +3,6 @@ package gateway_notification
 import (
 	"testing"
 
-	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
 	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -12,8 +11,8 @@ import (
 func setupGetUnreadCountTest(t *testing.T, tc *TestContext) (senderToken string, recipientID int64, recipientToken string, teardown func()) {
 	t.Helper()
 
-	senderRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up get unread count test - registering sender", "test", t.Name(), "username", senderRegisterReq.Username)
+	senderRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up get unread count test - registering sender", "test", t.Name(), "username", senderRegisterReq.Username)
 
 	senderTokens, err := tc.AuthClient.Register(*senderRegisterReq)
 	require.NoError(t, err, "Failed to register sender user")
@@ -23,8 +22,8 @@ func setupGetUnreadCountTest(t *testing.T, tc *TestContext) (senderToken string,
 
 	tc.TrackUserForCleanup(senderUser.ID, senderUser.Username, senderTokens.AccessToken)
 
-	recipientRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up get unread count test - registering recipient", "test", t.Name(), "username", recipientRegisterReq.Username)
+	recipientRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up get unread count test - registering recipient", "test", t.Name(), "username", recipientRegisterReq.Username)
 
 	recipientTokens, err := tc.AuthClient.Register(*recipientRegisterReq)
 	require.NoError(t, err, "Failed to register recipient user")
@@ -35,14 +34,14 @@ func setupGetUnreadCountTest(t *testing.T, tc *TestContext) (senderToken string,
 	tc.TrackUserForCleanup(recipientUser.ID, recipientUser.Username, recipientTokens.AccessToken)
 
 	return senderTokens.AccessToken, recipientUser.ID, recipientTokens.AccessToken, func() {
-		log.Info("Get unread count test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Get unread count test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestGetUnreadCountSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	senderToken, recipientID, recipientToken, teardown := setupGetUnreadCountTest(t, tc)
@@ -57,7 +56,7 @@ func TestGetUnreadCountSuccess(t *testing.T) {
 	notificationsToSend := 3
 
 	for i := 0; i < notificationsToSend; i++ {
-		sendReq := fixtures.GenerateSendNotificationRequest(recipientID)
+		sendReq := tc.Fixtures.GenerateSendNotificationRequest(recipientID)
 		sendResp, err := tc.NotificationClient.SendNotification(*sendReq)
 		require.NoError(t, err, "Failed to send notification %d", i+1)
 
@@ -69,12 +68,12 @@ func TestGetUnreadCountSuccess(t *testing.T) {
 	require.NoError(t, err, "Failed to get unread count after sending notifications")
 	assert.Equal(t, notificationsToSend, countResp.Count, "Unread count should match sent notifications")
 
-	log.Info("Successfully verified unread count", "expected", notificationsToSend, "actual", countResp.Count)
+	tc.Log.Info("Successfully verified unread count", "expected", notificationsToSend, "actual", countResp.Count)
 }
 
 func TestGetUnreadCountAfterReadingNotifications(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	senderToken, recipientID, recipientToken, teardown := setupGetUnreadCountTest(t, tc)
@@ -85,7 +84,7 @@ func TestGetUnreadCountAfterReadingNotifications(t *testing.T) {
 	var sentNotificationIDs []int64
 
 	for i := 0; i < notificationsToSend; i++ {
-		sendReq := fixtures.GenerateSendNotificationRequest(recipientID)
+		sendReq := tc.Fixtures.GenerateSendNotificationRequest(recipientID)
 		sendResp, err := tc.NotificationClient.SendNotification(*sendReq)
 		require.NoError(t, err, "Failed to send notification %d", i+1)
 
@@ -112,12 +111,12 @@ func TestGetUnreadCountAfterReadingNotifications(t *testing.T) {
 	require.NoError(t, err, "Failed to get unread count after reading all notifications")
 	assert.Equal(t, 0, countResp.Count, "Unread count should be 0 after reading all")
 
-	log.Info("Successfully verified unread count changes after reading notifications")
+	tc.Log.Info("Successfully verified unread count changes after reading notifications")
 }
 
 func TestGetUnreadCountUnauthorized(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, recipientID, _, teardown := setupGetUnreadCountTest(t, tc)
@@ -131,15 +130,15 @@ func TestGetUnreadCountUnauthorized(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrUnauthenticated.Error(), "Error should be unauthenticated")
 	assert.Nil(t, countResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected unauthorized request for unread count", "user_id", recipientID)
+	tc.Log.Info("Correctly rejected unauthorized request for unread count", "user_id", recipientID)
 }
 
 func TestGetUnreadCountOwnUserID(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
-	registerReq := fixtures.GenerateRegisterRequest()
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register user")
 
@@ -156,5 +155,5 @@ func TestGetUnreadCountOwnUserID(t *testing.T) {
 	require.NotNil(t, countResp, "Response should not be nil")
 	assert.GreaterOrEqual(t, countResp.Count, 0, "Unread count should be non-negative")
 
-	log.Info("Successfully retrieved own unread count", "user_id", user.ID, "count", countResp.Count)
+	tc.Log.Info("Successfully retrieved own unread count", "user_id", user.ID, "count", countResp.Count)
 }
@@ -12,8 +12,8 @@ import (
 func setupGetUserNotificationFeedTest(t *testing.T, tc *TestContext) (senderToken string, recipientID int64, recipientToken string, teardown func()) {
 	t.Helper()
 
-	senderRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up get user notification feed test - registering sender", "test", t.Name(), "username", senderRegisterReq.Username)
+	senderRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up get user notification feed test - registering sender", "test", t.Name(), "username", senderRegisterReq.Username)
 
 	senderTokens, err := tc.AuthClient.Register(*senderRegisterReq)
 	require.NoError(t, err, "Failed to register sender user")
@@ -23,8 +23,8 @@ func setupGetUserNotificationFeedTest(t *testing.T, tc *TestContext) (senderToke
 
 	tc.TrackUserForCleanup(senderUser.ID, senderUser.Username, senderTokens.AccessToken)
 
-	recipientRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up get user notification feed test - registering recipient", "test", t.Name(), "username", recipientRegisterReq.Username)
+	recipientRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up get user notification feed test - registering recipient", "test", t.Name(), "username", recipientRegisterReq.Username)
 
 	recipientTokens, err := tc.AuthClient.Register(*recipientRegisterReq)
 	require.NoError(t, err, "Failed to register recipient user")
@@ -35,14 +35,14 @@ func setupGetUserNotificationFeedTest(t *testing.T, tc *TestContext) (senderToke
 	tc.TrackUserForCleanup(recipientUser.ID, recipientUser.Username, recipientTokens.AccessToken)
 
 	return senderTokens.AccessToken, recipientUser.ID, recipientTokens.AccessToken, func() {
-		log.Info("Get user notification feed test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Get user notification feed test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestGetUserNotificationFeedEmptyFeed(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, recipientID, recipientToken, teardown := setupGetUserNotificationFeedTest(t, tc)
@@ -61,12 +61,12 @@ func TestGetUserNotificationFeedEmptyFeed(t *testing.T) {
 	assert.Equal(t, 10, feedResp.Limit, "Limit should be 10")
 	assert.Equal(t, 0, feedResp.TotalPages, "Total pages should be 0")
 
-	log.Info("Successfully retrieved empty notification feed", "recipient_id", recipientID)
+	tc.Log.Info("Successfully retrieved empty notification feed", "recipient_id", recipientID)
 }
 
 func TestGetUserNotificationFeedWithNotifications(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	senderToken, recipientID, recipientToken, teardown := setupGetUserNotificationFeedTest(t, tc)
@@ -77,7 +77,7 @@ func TestGetUserNotificationFeedWithNotifications(t *testing.T) {
 	var sentNotificationIDs []int64
 
 	for i := 0; i < notificationsToSend; i++ {
-		sendReq := fixtures.GenerateSendNotificationRequest(recipientID)
+		sendReq := tc.Fixtures.GenerateSendNotificationRequest(recipientID)
 		sendResp, err := tc.NotificationClient.SendNotification(*sendReq)
 		require.NoError(t, err, "Failed to send notification %d", i+1)
 
@@ -107,14 +107,14 @@ func TestGetUserNotificationFeedWithNotifications(t *testing.T) {
 		assert.Contains(t, sentNotificationIDs, notification.ID, "Notification ID should be in sent list")
 	}
 
-	log.Info("Successfully retrieved notification feed with notifications",
+	tc.Log.Info("Successfully retrieved notification feed with notifications",
 		"recipient_id", recipientID,
 		"notifications_count", len(feedResp.Notifications))
 }
 
 func TestGetUserNotificationFeedPagination(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	senderToken, recipientID, recipientToken, teardown := setupGetUserNotificationFeedTest(t, tc)
@@ -124,7 +124,7 @@ func TestGetUserNotificationFeedPagination(t *testing.T) {
 	notificationsToSend := 15
 
 	for i := 0; i < notificationsToSend; i++ {
-		sendReq := fixtures.GenerateSendNotificationRequest(recipientID)
+		sendReq := tc.Fixtures.GenerateSendNotificationRequest(recipientID)
 		sendResp, err := tc.NotificationClient.SendNotification(*sendReq)
 		require.NoError(t, err, "Failed to send notification %d", i+1)
 
@@ -162,7 +162,7 @@ func TestGetUserNotificationFeedPagination(t *testing.T) {
 		assert.False(t, page1IDs[notification.ID], "Notification should not appear on both pages")
 	}
 
-	log.Info("Successfully tested pagination",
+	tc.Log.Info("Successfully tested pagination",
 		"total_notifications", notificationsToSend,
 		"page1_count", len(feedResp1.Notifications),
 		"page2_count", len(feedResp2.Notifications))
@@ -170,7 +170,7 @@ func TestGetUserNotificationFeedPagination(t *testing.T) {
 
 func TestGetUserNotificationFeedUnauthorized(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, recipientID, _, teardown := setupGetUserNotificationFeedTest(t, tc)
@@ -184,15 +184,15 @@ func TestGetUserNotificationFeedUnauthorized(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrUnauthenticated.Error(), "Error should be unauthenticated")
 	assert.Nil(t, feedResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected unauthorized request for notification feed", "recipient_id", recipientID)
+	tc.Log.Info("Correctly rejected unauthorized request for notification feed", "recipient_id", recipientID)
 }
 
 func TestGetUserNotificationFeedInvalidPagination(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
-	registerReq := fixtures.GenerateRegisterRequest()
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register user")
 
@@ -243,7 +243,7 @@ func TestGetUserNotificationFeedInvalidPagination(t *testing.T) {
 			assert.Contains(t, err.Error(), custom_errors.ErrInvalidInput.Error(), "Error should be validation failed for %s", testCase.description)
 			assert.Nil(t, feedResp, "Response should be nil on error")
 
-			log.Info("Correctly rejected request with invalid pagination",
+			tc.Log.Info("Correctly rejected request with invalid pagination",
 				"page", testCase.page,
 				"limit", testCase.limit,
 				"description", testCase.description)
@@ -4,7 +4,6 @@ import (
 	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
 	"testing"
 
-	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -12,8 +11,8 @@ import (
 func setupReadAllNotificationsTest(t *testing.T, tc *TestContext) (senderToken string, recipientID int64, recipientToken string, teardown func()) {
 	t.Helper()
 
-	senderRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up read all notifications test - registering sender", "test", t.Name(), "username", senderRegisterReq.Username)
+	senderRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up read all notifications test - registering sender", "test", t.Name(), "username", senderRegisterReq.Username)
 
 	senderTokens, err := tc.AuthClient.Register(*senderRegisterReq)
 	require.NoError(t, err, "Failed to register sender user")
@@ -23,8 +22,8 @@ func setupReadAllNotificationsTest(t *testing.T, tc *TestContext) (senderToken s
 
 	tc.TrackUserForCleanup(senderUser.ID, senderUser.Username, senderTokens.AccessToken)
 
-	recipientRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up read all notifications test - registering recipient", "test", t.Name(), "username", recipientRegisterReq.Username)
+	recipientRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up read all notifications test - registering recipient", "test", t.Name(), "username", recipientRegisterReq.Username)
 
 	recipientTokens, err := tc.AuthClient.Register(*recipientRegisterReq)
 	require.NoError(t, err, "Failed to register recipient user")
@@ -35,14 +34,14 @@ func setupReadAllNotificationsTest(t *testing.T, tc *TestContext) (senderToken s
 	tc.TrackUserForCleanup(recipientUser.ID, recipientUser.Username, recipientTokens.AccessToken)
 
 	return senderTokens.AccessToken, recipientUser.ID, recipientTokens.AccessToken, func() {
-		log.Info("Read all notifications test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Read all notifications test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestReadAllNotificationsSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	senderToken, recipientID, recipientToken, teardown := setupReadAllNotificationsTest(t, tc)
@@ -52,7 +51,7 @@ func TestReadAllNotificationsSuccess(t *testing.T) {
 	notificationsToSend := 3
 
 	for i := 0; i < notificationsToSend; i++ {
-		sendReq := fixtures.GenerateSendNotificationRequest(recipientID)
+		sendReq := tc.Fixtures.GenerateSendNotificationRequest(recipientID)
 		sendResp, err := tc.NotificationClient.SendNotification(*sendReq)
 		require.NoError(t, err, "Failed to send notification %d", i+1)
 
@@ -76,14 +75,14 @@ func TestReadAllNotificationsSuccess(t *testing.T) {
 		assert.True(t, notification.IsRead, "All notifications should be marked as read")
 	}
 
-	log.Info("Successfully marked all notifications as read",
+	tc.Log.Info("Successfully marked all notifications as read",
 		"recipient_id", recipientID,
 		"notifications_count", notificationsToSend)
 }
 
 func TestReadAllNotificationsNoNotifications(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, recipientID, recipientToken, teardown := setupReadAllNotificationsTest(t, tc)
@@ -99,12 +98,12 @@ func TestReadAllNotificationsNoNotifications(t *testing.T) {
 	assert.True(t, readAllResp.Success, "Operation should be successful")
 	assert.NotEmpty(t, readAllResp.Message, "Response should have a message")
 
-	log.Info("Successfully handled read all notifications with no notifications", "recipient_id", recipientID)
+	tc.Log.Info("Successfully handled read all notifications with no notifications", "recipient_id", recipientID)
 }
 
 func TestReadAllNotificationsUnauthorized(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, recipientID, _, teardown := setupReadAllNotificationsTest(t, tc)
@@ -118,12 +117,12 @@ func TestReadAllNotificationsUnauthorized(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrUnauthenticated.Error(), "Error should be unauthenticated")
 	assert.Nil(t, readAllResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected unauthorized request for read all notifications", "recipient_id", recipientID)
+	tc.Log.Info("Correctly rejected unauthorized request for read all notifications", "recipient_id", recipientID)
 }
 
 func TestReadAllNotificationsInvalidToken(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, recipientID, _, teardown := setupReadAllNotificationsTest(t, tc)
@@ -137,19 +136,19 @@ func TestReadAllNotificationsInvalidToken(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrInvalidToken.Error(), "Error should be invalid token")
 	assert.Nil(t, readAllResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected invalid token request for read all notifications", "recipient_id", recipientID)
+	tc.Log.Info("Correctly rejected invalid token request for read all notifications", "recipient_id", recipientID)
 }
 
 func TestReadAllNotificationsAlreadyRead(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	senderToken, recipientID, recipientToken, teardown := setupReadAllNotificationsTest(t, tc)
 	defer teardown()
 
 	tc.APIClient.SetToken(senderToken)
-	sendReq := fixtures.GenerateSendNotificationRequest(recipientID)
+	sendReq := tc.Fixtures.GenerateSendNotificationRequest(recipientID)
 	sendResp, err := tc.NotificationClient.SendNotification(*sendReq)
 	require.NoError(t, err, "Failed to send notification")
 
@@ -165,12 +164,12 @@ func TestReadAllNotificationsAlreadyRead(t *testing.T) {
 	require.NoError(t, err, "Should succeed even when notifications are already read")
 	assert.True(t, readAllResp2.Success, "Second operation should be successful")
 
-	log.Info("Successfully handled read all notifications when already read", "recipient_id", recipientID)
+	tc.Log.Info("Successfully handled read all notifications when already read", "recipient_id", recipientID)
 }
 
 func TestReadAllNotificationsWithMixedReadStatus(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	senderToken, recipientID, recipientToken, teardown := setupReadAllNotificationsTest(t, tc)
@@ -181,7 +180,7 @@ func TestReadAllNotificationsWithMixedReadStatus(t *testing.T) {
 	var sentNotificationIDs []int64
 
 	for i := 0; i < notificationsToSend; i++ {
-		sendReq := fixtures.GenerateSendNotificationRequest(recipientID)
+		sendReq := tc.Fixtures.GenerateSendNotificationRequest(recipientID)
 		sendResp, err := tc.NotificationClient.SendNotification(*sendReq)
 		require.NoError(t, err, "Failed to send notification %d", i+1)
 
@@ -208,7 +207,7 @@ func TestReadAllNotificationsWithMixedReadStatus(t *testing.T) {
 		assert.True(t, notification.IsRead, "All notifications should be marked as read")
 	}
 
-	log.Info("Successfully marked all notifications as read with mixed initial status",
+	tc.Log.Info("Successfully marked all notifications as read with mixed initial status",
 		"recipient_id", recipientID,
 		"notifications_count", notificationsToSend)
 }
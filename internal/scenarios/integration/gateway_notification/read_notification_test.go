@@ -4,7 +4,6 @@ import (
 	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
 	"testing"
 
-	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -12,8 +11,8 @@ import (
 func setupReadNotificationTest(t *testing.T, tc *TestContext) (senderToken string, recipientID int64, recipientToken string, notificationID int64, teardown func()) {
 	t.Helper()
 
-	senderRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up read notification test - registering sender", "test", t.Name(), "username", senderRegisterReq.Username)
+	senderRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up read notification test - registering sender", "test", t.Name(), "username", senderRegisterReq.Username)
 
 	senderTokens, err := tc.AuthClient.Register(*senderRegisterReq)
 	require.NoError(t, err, "Failed to register sender user")
@@ -23,8 +22,8 @@ func setupReadNotificationTest(t *testing.T, tc *TestContext) (senderToken strin
 
 	tc.TrackUserForCleanup(senderUser.ID, senderUser.Username, senderTokens.AccessToken)
 
-	recipientRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up read notification test - registering recipient", "test", t.Name(), "username", recipientRegisterReq.Username)
+	recipientRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up read notification test - registering recipient", "test", t.Name(), "username", recipientRegisterReq.Username)
 
 	recipientTokens, err := tc.AuthClient.Register(*recipientRegisterReq)
 	require.NoError(t, err, "Failed to register recipient user")
@@ -35,7 +34,7 @@ func setupReadNotificationTest(t *testing.T, tc *TestContext) (senderToken strin
 	tc.TrackUserForCleanup(recipientUser.ID, recipientUser.Username, recipientTokens.AccessToken)
 
 	tc.APIClient.SetToken(senderTokens.AccessToken)
-	sendReq := fixtures.GenerateSendNotificationRequest(recipientUser.ID)
+	sendReq := tc.Fixtures.GenerateSendNotificationRequest(recipientUser.ID)
 
 	sendResp, err := tc.NotificationClient.SendNotification(*sendReq)
 	require.NoError(t, err, "Failed to send notification for test setup")
@@ -44,14 +43,14 @@ func setupReadNotificationTest(t *testing.T, tc *TestContext) (senderToken strin
 	tc.TrackNotificationForCleanup(sendResp.NotificationID, recipientUser.ID, senderTokens.AccessToken, recipientTokens.AccessToken)
 
 	return senderTokens.AccessToken, recipientUser.ID, recipientTokens.AccessToken, sendResp.NotificationID, func() {
-		log.Info("Read notification test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Read notification test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestReadNotificationSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, recipientToken, notificationID, teardown := setupReadNotificationTest(t, tc)
@@ -75,12 +74,12 @@ func TestReadNotificationSuccess(t *testing.T) {
 	require.NoError(t, err, "Failed to get notification after marking as read")
 	assert.True(t, updatedNotification.IsRead, "Notification should be marked as read")
 
-	log.Info("Successfully marked notification as read", "notification_id", notificationID)
+	tc.Log.Info("Successfully marked notification as read", "notification_id", notificationID)
 }
 
 func TestReadNotificationAlreadyRead(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, recipientToken, notificationID, teardown := setupReadNotificationTest(t, tc)
@@ -100,12 +99,12 @@ func TestReadNotificationAlreadyRead(t *testing.T) {
 	require.NoError(t, err, "Failed to get notification after double read")
 	assert.True(t, notification.IsRead, "Notification should remain marked as read")
 
-	log.Info("Successfully handled double read of notification", "notification_id", notificationID)
+	tc.Log.Info("Successfully handled double read of notification", "notification_id", notificationID)
 }
 
 func TestReadNotificationUnauthorized(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, _, notificationID, teardown := setupReadNotificationTest(t, tc)
@@ -119,12 +118,12 @@ func TestReadNotificationUnauthorized(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrUnauthenticated.Error(), "Error should be unauthenticated")
 	assert.Nil(t, readResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected unauthorized request for read notification", "notification_id", notificationID)
+	tc.Log.Info("Correctly rejected unauthorized request for read notification", "notification_id", notificationID)
 }
 
 func TestReadNotificationForbidden(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	senderToken, _, _, notificationID, teardown := setupReadNotificationTest(t, tc)
@@ -138,15 +137,15 @@ func TestReadNotificationForbidden(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrNotificationAccessDenied.Error(), "Error should be access denied")
 	assert.Nil(t, readResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected forbidden request for read notification", "notification_id", notificationID)
+	tc.Log.Info("Correctly rejected forbidden request for read notification", "notification_id", notificationID)
 }
 
 func TestReadNotificationInvalidID(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
-	registerReq := fixtures.GenerateRegisterRequest()
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register user")
 
@@ -191,7 +190,7 @@ func TestReadNotificationInvalidID(t *testing.T) {
 			assert.Contains(t, err.Error(), testCase.expectedError.Error(), "Error should match expected type for %s", testCase.description)
 			assert.Nil(t, readResp, "Response should be nil on error")
 
-			log.Info("Correctly rejected request for invalid notification ID",
+			tc.Log.Info("Correctly rejected request for invalid notification ID",
 				"notification_id", testCase.notificationID,
 				"description", testCase.description,
 				"expected_error", testCase.expectedError.Error())
@@ -201,7 +200,7 @@ func TestReadNotificationInvalidID(t *testing.T) {
 
 func TestReadNotificationInvalidToken(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, _, notificationID, teardown := setupReadNotificationTest(t, tc)
@@ -215,12 +214,12 @@ func TestReadNotificationInvalidToken(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrInvalidToken.Error(), "Error should be invalid token")
 	assert.Nil(t, readResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected invalid token request for read notification", "notification_id", notificationID)
+	tc.Log.Info("Correctly rejected invalid token request for read notification", "notification_id", notificationID)
 }
 
 func TestReadNotificationMultipleNotifications(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	senderToken, recipientID, recipientToken, _, teardown := setupReadNotificationTest(t, tc)
@@ -231,7 +230,7 @@ func TestReadNotificationMultipleNotifications(t *testing.T) {
 	var notificationIDs []int64
 
 	for i := 0; i < notificationsToSend; i++ {
-		sendReq := fixtures.GenerateSendNotificationRequest(recipientID)
+		sendReq := tc.Fixtures.GenerateSendNotificationRequest(recipientID)
 		sendResp, err := tc.NotificationClient.SendNotification(*sendReq)
 		require.NoError(t, err, "Failed to send notification %d", i+1)
 
@@ -263,7 +262,7 @@ func TestReadNotificationMultipleNotifications(t *testing.T) {
 
 	assert.GreaterOrEqual(t, readCount, notificationsToSend, "At least sent notifications should be marked as read")
 
-	log.Info("Successfully marked multiple notifications as read individually",
+	tc.Log.Info("Successfully marked multiple notifications as read individually",
 		"recipient_id", recipientID,
 		"notifications_count", notificationsToSend,
 		"read_count", readCount)
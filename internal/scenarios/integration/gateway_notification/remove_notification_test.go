@@ -4,7 +4,6 @@ import (
 	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
 	"testing"
 
-	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -12,8 +11,8 @@ import (
 func setupRemoveNotificationTest(t *testing.T, tc *TestContext) (senderToken string, recipientID int64, recipientToken string, notificationID int64, teardown func()) {
 	t.Helper()
 
-	senderRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up remove notification test - registering sender", "test", t.Name(), "username", senderRegisterReq.Username)
+	senderRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up remove notification test - registering sender", "test", t.Name(), "username", senderRegisterReq.Username)
 
 	senderTokens, err := tc.AuthClient.Register(*senderRegisterReq)
 	require.NoError(t, err, "Failed to register sender user")
@@ -23,8 +22,8 @@ func setupRemoveNotificationTest(t *testing.T, tc *TestContext) (senderToken str
 
 	tc.TrackUserForCleanup(senderUser.ID, senderUser.Username, senderTokens.AccessToken)
 
-	recipientRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up remove notification test - registering recipient", "test", t.Name(), "username", recipientRegisterReq.Username)
+	recipientRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up remove notification test - registering recipient", "test", t.Name(), "username", recipientRegisterReq.Username)
 
 	recipientTokens, err := tc.AuthClient.Register(*recipientRegisterReq)
 	require.NoError(t, err, "Failed to register recipient user")
@@ -35,7 +34,7 @@ func setupRemoveNotificationTest(t *testing.T, tc *TestContext) (senderToken str
 	tc.TrackUserForCleanup(recipientUser.ID, recipientUser.Username, recipientTokens.AccessToken)
 
 	tc.APIClient.SetToken(senderTokens.AccessToken)
-	sendReq := fixtures.GenerateSendNotificationRequest(recipientUser.ID)
+	sendReq := tc.Fixtures.GenerateSendNotificationRequest(recipientUser.ID)
 
 	sendResp, err := tc.NotificationClient.SendNotification(*sendReq)
 	require.NoError(t, err, "Failed to send notification for test setup")
@@ -44,14 +43,14 @@ func setupRemoveNotificationTest(t *testing.T, tc *TestContext) (senderToken str
 	tc.TrackNotificationForCleanup(sendResp.NotificationID, recipientUser.ID, senderTokens.AccessToken, recipientTokens.AccessToken)
 
 	return senderTokens.AccessToken, recipientUser.ID, recipientTokens.AccessToken, sendResp.NotificationID, func() {
-		log.Info("Remove notification test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Remove notification test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestRemoveNotificationSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, recipientToken, notificationID, teardown := setupRemoveNotificationTest(t, tc)
@@ -75,12 +74,12 @@ func TestRemoveNotificationSuccess(t *testing.T) {
 	require.Error(t, err, "Should fail to get removed notification")
 	assert.Contains(t, err.Error(), custom_errors.ErrNotificationNotFound.Error(), "Error should be notification not found")
 
-	log.Info("Successfully removed notification", "notification_id", notificationID)
+	tc.Log.Info("Successfully removed notification", "notification_id", notificationID)
 }
 
 func TestRemoveNotificationUnauthorized(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, _, notificationID, teardown := setupRemoveNotificationTest(t, tc)
@@ -94,12 +93,12 @@ func TestRemoveNotificationUnauthorized(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrUnauthenticated.Error(), "Error should be unauthenticated")
 	assert.Nil(t, removeResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected unauthorized request for remove notification", "notification_id", notificationID)
+	tc.Log.Info("Correctly rejected unauthorized request for remove notification", "notification_id", notificationID)
 }
 
 func TestRemoveNotificationForbidden(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	senderToken, _, _, notificationID, teardown := setupRemoveNotificationTest(t, tc)
@@ -113,12 +112,12 @@ func TestRemoveNotificationForbidden(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrNotificationAccessDenied.Error(), "Error should be access denied")
 	assert.Nil(t, removeResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected forbidden request for remove notification", "notification_id", notificationID)
+	tc.Log.Info("Correctly rejected forbidden request for remove notification", "notification_id", notificationID)
 }
 
 func TestRemoveNotificationAlreadyRemoved(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, recipientToken, notificationID, teardown := setupRemoveNotificationTest(t, tc)
@@ -136,15 +135,15 @@ func TestRemoveNotificationAlreadyRemoved(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrNotificationNotFound.Error(), "Error should be notification not found")
 	assert.Nil(t, removeResp2, "Response should be nil on error")
 
-	log.Info("Correctly handled attempt to remove already removed notification", "notification_id", notificationID)
+	tc.Log.Info("Correctly handled attempt to remove already removed notification", "notification_id", notificationID)
 }
 
 func TestRemoveNotificationInvalidID(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
-	registerReq := fixtures.GenerateRegisterRequest()
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register user")
 
@@ -191,14 +190,14 @@ func TestRemoveNotificationInvalidID(t *testing.T) {
 			assert.Contains(t, err.Error(), testCase.expectedError.Error(), "Error should match expected type for %s", testCase.description)
 			assert.Nil(t, removeResp, "Response should be nil on error for %s", testCase.description)
 
-			log.Info("Correctly rejected invalid ID", "test_case", testCase.name, "notification_id", testCase.notificationID)
+			tc.Log.Info("Correctly rejected invalid ID", "test_case", testCase.name, "notification_id", testCase.notificationID)
 		})
 	}
 }
 
 func TestRemoveNotificationInvalidToken(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, _, notificationID, teardown := setupRemoveNotificationTest(t, tc)
@@ -212,5 +211,5 @@ func TestRemoveNotificationInvalidToken(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrInvalidToken.Error(), "Error should be unauthenticated")
 	assert.Nil(t, removeResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected invalid token for remove notification", "notification_id", notificationID)
+	tc.Log.Info("Correctly rejected invalid token for remove notification", "notification_id", notificationID)
 }
@@ -12,8 +12,8 @@ import (
 func setupSendNotificationTest(t *testing.T, tc *TestContext) (senderToken string, senderId int64, recipientID int64, recipientToken string, teardown func()) {
 	t.Helper()
 
-	senderRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up send notification test - registering sender", "test", t.Name(), "username", senderRegisterReq.Username)
+	senderRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up send notification test - registering sender", "test", t.Name(), "username", senderRegisterReq.Username)
 
 	senderTokens, err := tc.AuthClient.Register(*senderRegisterReq)
 	require.NoError(t, err, "Failed to register sender user")
@@ -23,8 +23,8 @@ func setupSendNotificationTest(t *testing.T, tc *TestContext) (senderToken strin
 
 	tc.TrackUserForCleanup(senderUser.ID, senderUser.Username, senderTokens.AccessToken)
 
-	recipientRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up send notification test - registering recipient", "test", t.Name(), "username", recipientRegisterReq.Username)
+	recipientRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up send notification test - registering recipient", "test", t.Name(), "username", recipientRegisterReq.Username)
 
 	recipientTokens, err := tc.AuthClient.Register(*recipientRegisterReq)
 	require.NoError(t, err, "Failed to register recipient user")
@@ -35,14 +35,14 @@ func setupSendNotificationTest(t *testing.T, tc *TestContext) (senderToken strin
 	tc.TrackUserForCleanup(recipientUser.ID, recipientUser.Username, recipientTokens.AccessToken)
 
 	return senderTokens.AccessToken, senderUser.ID, recipientUser.ID, recipientTokens.AccessToken, func() {
-		log.Info("Send notification test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Send notification test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestSendNotificationSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	senderAccessToken, _, recipientID, recipientAccessToken, teardown := setupSendNotificationTest(t, tc)
@@ -54,7 +54,7 @@ func TestSendNotificationSuccess(t *testing.T) {
 
 	for _, notificationType := range notificationTypes {
 		t.Run(notificationType, func(t *testing.T) {
-			notificationReqPtr := fixtures.GenerateSendNotificationRequest(recipientID)
+			notificationReqPtr := tc.Fixtures.GenerateSendNotificationRequest(recipientID)
 			notificationReq := *notificationReqPtr
 			notificationReq.Type = notificationType // Override with specific type for this test
 
@@ -66,14 +66,14 @@ func TestSendNotificationSuccess(t *testing.T) {
 
 			tc.TrackNotificationForCleanup(response.NotificationID, recipientID, senderAccessToken, recipientAccessToken)
 
-			log.Info("Successfully sent notification", "notification_id", response.NotificationID, "type", notificationType)
+			tc.Log.Info("Successfully sent notification", "notification_id", response.NotificationID, "type", notificationType)
 		})
 	}
 }
 
 func TestSendNotificationUnauthorized(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, recipientID, _, teardown := setupSendNotificationTest(t, tc)
@@ -81,7 +81,7 @@ func TestSendNotificationUnauthorized(t *testing.T) {
 
 	tc.APIClient.SetToken("")
 
-	notificationReqPtr := fixtures.GenerateSendNotificationRequest(recipientID)
+	notificationReqPtr := tc.Fixtures.GenerateSendNotificationRequest(recipientID)
 	notificationReq := *notificationReqPtr
 	notificationReq.Type = fixtures.NotificationTypeSystem
 	notificationReq.Payload = map[string]string{
@@ -95,7 +95,7 @@ func TestSendNotificationUnauthorized(t *testing.T) {
 
 func TestSendNotificationInvalidToken(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, recipientID, _, teardown := setupSendNotificationTest(t, tc)
@@ -103,7 +103,7 @@ func TestSendNotificationInvalidToken(t *testing.T) {
 
 	tc.APIClient.SetToken("invalid_token_12345")
 
-	notificationReqPtr := fixtures.GenerateSendNotificationRequest(recipientID)
+	notificationReqPtr := tc.Fixtures.GenerateSendNotificationRequest(recipientID)
 	notificationReq := *notificationReqPtr
 	notificationReq.Type = fixtures.NotificationTypeSystem
 
@@ -114,7 +114,7 @@ func TestSendNotificationInvalidToken(t *testing.T) {
 
 func TestSendNotificationToNonExistentUser(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	senderAccessToken, _, _, _, teardown := setupSendNotificationTest(t, tc)
@@ -122,7 +122,7 @@ func TestSendNotificationToNonExistentUser(t *testing.T) {
 
 	tc.APIClient.SetToken(senderAccessToken)
 
-	notificationReqPtr := fixtures.GenerateSendNotificationRequest(999999) // Non-existent user ID
+	notificationReqPtr := tc.Fixtures.GenerateSendNotificationRequest(999999) // Non-existent user ID
 	notificationReq := *notificationReqPtr
 	notificationReq.Type = fixtures.NotificationTypeSystem
 
@@ -133,7 +133,7 @@ func TestSendNotificationToNonExistentUser(t *testing.T) {
 
 func TestSendNotificationValidationErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	senderAccessToken, _, recipientID, _, teardown := setupSendNotificationTest(t, tc)
@@ -141,7 +141,7 @@ func TestSendNotificationValidationErrors(t *testing.T) {
 
 	tc.APIClient.SetToken(senderAccessToken)
 
-	baseNotifReqPtr := fixtures.GenerateSendNotificationRequest(recipientID)
+	baseNotifReqPtr := tc.Fixtures.GenerateSendNotificationRequest(recipientID)
 	baseNotifReq := *baseNotifReqPtr
 
 	testCases := []struct {
@@ -182,7 +182,7 @@ func TestSendNotificationValidationErrors(t *testing.T) {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			ctx := NewTestContext()
+			ctx := NewTestContext(t)
 			defer ctx.Cleanup()
 
 			accessToken, _, _, _, teardown := setupSendNotificationTest(t, ctx)
@@ -200,7 +200,7 @@ func TestSendNotificationValidationErrors(t *testing.T) {
 
 func TestSendNotificationSelfNotification(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, userID, teardown := setupTestUser(t, tc)
@@ -208,7 +208,7 @@ func TestSendNotificationSelfNotification(t *testing.T) {
 
 	tc.APIClient.SetToken(accessToken)
 
-	notificationReqPtr := fixtures.GenerateSendNotificationRequest(userID)
+	notificationReqPtr := tc.Fixtures.GenerateSendNotificationRequest(userID)
 	notificationReq := *notificationReqPtr
 	notificationReq.Type = fixtures.NotificationTypeFollowCreated
 
@@ -0,0 +1,46 @@
+package gateway_posts
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePostBoundaryValues(t *testing.T) {
+	t.Parallel()
+	tc := NewTestContext(t)
+	defer tc.Cleanup()
+
+	accessToken, authorID, teardown := setupCreatePostTest(t, tc)
+	defer teardown()
+
+	cases := tc.Fixtures.BoundaryCases(tc.Fixtures.NewPost().Build())
+
+	t.Run("Cases", func(t *testing.T) {
+		for _, bc := range cases {
+			bc := bc
+			t.Run(bc.Name, func(t *testing.T) {
+				t.Parallel()
+
+				ctx := NewTestContext(t)
+				ctx.APIClient.SetToken(accessToken)
+
+				var response fixtures.CreatePostResponse
+				err := ctx.APIClient.Post("/v1/posts", bc.Body, &response)
+
+				if !bc.Valid {
+					require.Error(t, err, "expected %s=%v to be rejected", bc.Field.JSONName, bc.Value)
+					assert.Equal(t, http.StatusBadRequest, client.StatusCode(err))
+					return
+				}
+
+				require.NoError(t, err, "expected %s=%v to be accepted", bc.Field.JSONName, bc.Value)
+				tc.TrackPostForCleanup(response.ID, authorID, accessToken)
+			})
+		}
+	})
+}
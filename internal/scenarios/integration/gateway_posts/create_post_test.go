@@ -12,8 +12,8 @@ import (
 func setupCreatePostTest(t *testing.T, tc *TestContext) (string, int64, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up create post test", "test", t.Name(), "username", registerReq.Username)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up create post test", "test", t.Name(), "username", registerReq.Username)
 
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register test user")
@@ -24,14 +24,14 @@ func setupCreatePostTest(t *testing.T, tc *TestContext) (string, int64, func())
 	tc.TrackUserForCleanup(userByUsername.ID, userByUsername.Username, tokens.AccessToken)
 
 	return tokens.AccessToken, userByUsername.ID, func() {
-		log.Info("Create post test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Create post test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestCreatePostSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, userID, teardown := setupCreatePostTest(t, tc)
@@ -39,7 +39,7 @@ func TestCreatePostSuccess(t *testing.T) {
 
 	tc.APIClient.SetToken(accessToken)
 
-	postReq := fixtures.GenerateCreatePostRequest()
+	postReq := tc.Fixtures.GenerateCreatePostRequest()
 
 	createdPost, err := tc.PostClient.CreatePost(*postReq)
 	require.NoError(t, err)
@@ -83,7 +83,7 @@ func TestCreatePostSuccess(t *testing.T) {
 
 func TestCreatePostUnauthorized(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, teardown := setupCreatePostTest(t, tc)
@@ -91,7 +91,7 @@ func TestCreatePostUnauthorized(t *testing.T) {
 
 	tc.APIClient.SetToken("")
 
-	postReq := fixtures.GenerateCreatePostRequest()
+	postReq := tc.Fixtures.GenerateCreatePostRequest()
 
 	_, err := tc.PostClient.CreatePost(*postReq)
 	require.Error(t, err)
@@ -105,7 +105,7 @@ func TestCreatePostUnauthorized(t *testing.T) {
 
 func TestCreatePostValidationErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, _, teardown := setupCreatePostTest(t, tc)
@@ -127,11 +127,8 @@ func TestCreatePostValidationErrors(t *testing.T) {
 			expectedErr: custom_errors.ErrValidationFailed.Error(),
 		},
 		{
-			name: "TitleTooLong",
-			postReq: fixtures.CreatePostRequest{
-				Title:   string(make([]byte, 256)), // Create a very long title
-				Content: "Test content",
-			},
+			name:        "TitleTooLong",
+			postReq:     *tc.Fixtures.NewPost().WithTitleLength(fixtures.PostTitleMaxLength + 1).Build(),
 			expectedErr: custom_errors.ErrValidationFailed.Error(),
 		},
 		{
@@ -169,7 +166,7 @@ func TestCreatePostValidationErrors(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			ctx := NewTestContext()
+			ctx := NewTestContext(t)
 			defer ctx.Cleanup()
 
 			accessToken, _, teardown := setupCreatePostTest(t, ctx)
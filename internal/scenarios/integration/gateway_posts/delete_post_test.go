@@ -4,7 +4,6 @@ import (
 	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
 	"testing"
 
-	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -12,8 +11,8 @@ import (
 func setupDeletePostTest(t *testing.T, tc *TestContext) (string, int64, int64, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up delete post test", "test", t.Name(), "username", registerReq.Username)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up delete post test", "test", t.Name(), "username", registerReq.Username)
 
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register test user")
@@ -24,21 +23,21 @@ func setupDeletePostTest(t *testing.T, tc *TestContext) (string, int64, int64, f
 	tc.TrackUserForCleanup(userByUsername.ID, userByUsername.Username, tokens.AccessToken)
 	tc.APIClient.SetToken(tokens.AccessToken)
 
-	postReq := fixtures.GenerateCreatePostRequest()
+	postReq := tc.Fixtures.GenerateCreatePostRequest()
 	createdPost, err := tc.PostClient.CreatePost(*postReq)
 	require.NoError(t, err, "Failed to create test post")
 
-	log.Info("Created test post for deletion", "post_id", createdPost.ID, "title", createdPost.Title)
+	tc.Log.Info("Created test post for deletion", "post_id", createdPost.ID, "title", createdPost.Title)
 
 	return tokens.AccessToken, userByUsername.ID, createdPost.ID, func() {
-		log.Info("Delete post test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Delete post test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestDeletePostSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, _, postID, teardown := setupDeletePostTest(t, tc)
@@ -56,7 +55,7 @@ func TestDeletePostSuccess(t *testing.T) {
 
 func TestDeletePostUnauthorized(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, userID, postID, teardown := setupDeletePostTest(t, tc)
@@ -83,7 +82,7 @@ func TestDeletePostUnauthorized(t *testing.T) {
 
 func TestDeletePostNotFound(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, userID, postID, teardown := setupDeletePostTest(t, tc)
@@ -101,7 +100,7 @@ func TestDeletePostNotFound(t *testing.T) {
 
 func TestDeletePostForbidden(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken1, userID1, postID, teardown1 := setupDeletePostTest(t, tc)
@@ -109,7 +108,7 @@ func TestDeletePostForbidden(t *testing.T) {
 
 	tc.TrackPostForCleanup(postID, userID1, accessToken1)
 
-	registerReq2 := fixtures.GenerateRegisterRequest()
+	registerReq2 := tc.Fixtures.GenerateRegisterRequest()
 	tokens2, err := tc.AuthClient.Register(*registerReq2)
 	require.NoError(t, err)
 
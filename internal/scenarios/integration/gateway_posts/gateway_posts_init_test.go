@@ -1,6 +1,7 @@
 package gateway_posts
 
 import (
+	"context"
 	"flag"
 	"os"
 	"sync"
@@ -8,7 +9,11 @@ import (
 
 	"github.com/Soloda1/pinstack-system-tests/config"
 	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/histogram"
 	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/Soloda1/pinstack-system-tests/internal/tracing"
 )
 
 var (
@@ -29,6 +34,9 @@ type PostCleanupInfo struct {
 }
 
 type TestContext struct {
+	Fixtures     *fixtures.Generator
+	Factory      *factory.Factory
+	Log          *logger.Logger
 	APIClient    *client.Client
 	AuthClient   *client.AuthClient
 	UserClient   *client.UserClient
@@ -38,9 +46,15 @@ type TestContext struct {
 	mu           sync.Mutex
 }
 
-func NewTestContext() *TestContext {
-	apiClient := client.NewClient(cfg, log)
+func NewTestContext(t *testing.T) *TestContext {
+	testLog := logger.ForTest(t)
+	gen := fixtures.ForTest(t)
+	apiClient := client.NewClient(cfg, testLog)
+	apiClient.SetContext(tracing.StartTest(t))
 	return &TestContext{
+		Fixtures:     gen,
+		Factory:      factory.New(t, cfg, testLog, gen),
+		Log:          testLog,
 		APIClient:    apiClient,
 		AuthClient:   client.NewAuthClient(apiClient),
 		UserClient:   client.NewUserClient(apiClient),
@@ -60,7 +74,7 @@ func (tc *TestContext) TrackUserForCleanup(userID int64, username, accessToken s
 		AccessToken: accessToken,
 	}
 	tc.CreatedUsers = append(tc.CreatedUsers, userInfo)
-	log.Debug("Added user to cleanup list", "user_id", userID, "username", username)
+	tc.Log.Debug("Added user to cleanup list", "user_id", userID, "username", username)
 }
 
 func (tc *TestContext) TrackPostForCleanup(postID, authorID int64, accessToken string) {
@@ -73,7 +87,7 @@ func (tc *TestContext) TrackPostForCleanup(postID, authorID int64, accessToken s
 		AccessToken: accessToken,
 	}
 	tc.CreatedPosts = append(tc.CreatedPosts, postInfo)
-	log.Debug("Added post to cleanup list", "post_id", postID, "author_id", authorID)
+	tc.Log.Debug("Added post to cleanup list", "post_id", postID, "author_id", authorID)
 }
 
 func (tc *TestContext) Cleanup() {
@@ -82,57 +96,57 @@ func (tc *TestContext) Cleanup() {
 
 	// Clean up posts first
 	if len(tc.CreatedPosts) > 0 {
-		log.Info("Starting post cleanup process", "posts_to_delete", len(tc.CreatedPosts))
+		tc.Log.Info("Starting post cleanup process", "posts_to_delete", len(tc.CreatedPosts))
 
 		var successfulPostDeletions int
 
 		for _, postInfo := range tc.CreatedPosts {
-			log.Debug("Attempting to delete post", "post_id", postInfo.ID, "author_id", postInfo.AuthorID)
+			tc.Log.Debug("Attempting to delete post", "post_id", postInfo.ID, "author_id", postInfo.AuthorID)
 
 			// Use the access token of the user who created the post
 			tc.APIClient.SetToken(postInfo.AccessToken)
 
 			err := tc.PostClient.DeletePost(postInfo.ID)
 			if err != nil {
-				log.Warn("Failed to delete post during cleanup",
+				tc.Log.Warn("Failed to delete post during cleanup",
 					"post_id", postInfo.ID,
 					"author_id", postInfo.AuthorID,
 					"error", err.Error())
 			} else {
-				log.Debug("Successfully deleted post", "post_id", postInfo.ID)
+				tc.Log.Debug("Successfully deleted post", "post_id", postInfo.ID)
 				successfulPostDeletions++
 			}
 		}
 
-		log.Info("Post cleanup process completed",
+		tc.Log.Info("Post cleanup process completed",
 			"successful_deletions", successfulPostDeletions,
 			"total_posts", len(tc.CreatedPosts))
 	}
 
 	// Then clean up users
 	if len(tc.CreatedUsers) > 0 {
-		log.Info("Starting user cleanup process", "users_to_delete", len(tc.CreatedUsers))
+		tc.Log.Info("Starting user cleanup process", "users_to_delete", len(tc.CreatedUsers))
 
 		var successfulUserDeletions int
 
 		for _, userInfo := range tc.CreatedUsers {
-			log.Debug("Attempting to delete user", "user_id", userInfo.ID, "username", userInfo.Username)
+			tc.Log.Debug("Attempting to delete user", "user_id", userInfo.ID, "username", userInfo.Username)
 
 			tc.APIClient.SetToken(userInfo.AccessToken)
 
 			err := tc.UserClient.DeleteUser(userInfo.ID)
 			if err != nil {
-				log.Warn("Failed to delete user during cleanup",
+				tc.Log.Warn("Failed to delete user during cleanup",
 					"user_id", userInfo.ID,
 					"username", userInfo.Username,
 					"error", err.Error())
 			} else {
-				log.Debug("Successfully deleted user", "user_id", userInfo.ID, "username", userInfo.Username)
+				tc.Log.Debug("Successfully deleted user", "user_id", userInfo.ID, "username", userInfo.Username)
 				successfulUserDeletions++
 			}
 		}
 
-		log.Info("User cleanup process completed",
+		tc.Log.Info("User cleanup process completed",
 			"successful_deletions", successfulUserDeletions,
 			"total_users", len(tc.CreatedUsers))
 	}
@@ -146,10 +160,27 @@ func TestMain(m *testing.M) {
 	flag.Parse()
 
 	cfg = config.MustLoad("../../../../config")
-	log = logger.New(cfg.Env)
+	log = logger.New(cfg.Env, cfg.Test.LogLevel)
+	logger.SetRedactedFields(cfg.Redaction.Fields...)
+	logger.SetTestLevel(cfg.Env, cfg.Test.LogLevel)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Error("Failed to set up tracing", "error", err.Error())
+		os.Exit(1)
+	}
+
 	log.Info("Starting posts gateway tests", "env", cfg.Env)
 
+	saveLatencies := histogram.RecordClients(cfg.Perf, "gateway_posts")
 	code := m.Run()
+	if err := saveLatencies(); err != nil {
+		log.Warn("Failed to save latency histograms", "error", err.Error())
+	}
+
+	if err := shutdownTracing(context.Background()); err != nil {
+		log.Warn("Failed to flush traces", "error", err.Error())
+	}
 
 	os.Exit(code)
 }
@@ -12,8 +12,8 @@ import (
 func setupGetPostTest(t *testing.T, tc *TestContext) (string, int64, int64, *fixtures.CreatePostRequest, *fixtures.CreatePostResponse, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up get post test", "test", t.Name(), "username", registerReq.Username)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up get post test", "test", t.Name(), "username", registerReq.Username)
 
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register test user")
@@ -24,23 +24,23 @@ func setupGetPostTest(t *testing.T, tc *TestContext) (string, int64, int64, *fix
 	tc.TrackUserForCleanup(userByUsername.ID, userByUsername.Username, tokens.AccessToken)
 	tc.APIClient.SetToken(tokens.AccessToken)
 
-	postReq := fixtures.GenerateCreatePostRequest()
+	postReq := tc.Fixtures.GenerateCreatePostRequest()
 	createdPost, err := tc.PostClient.CreatePost(*postReq)
 	require.NoError(t, err, "Failed to create test post")
 
 	tc.TrackPostForCleanup(createdPost.ID, userByUsername.ID, tokens.AccessToken)
 
-	log.Info("Created test post for get test", "post_id", createdPost.ID, "title", createdPost.Title)
+	tc.Log.Info("Created test post for get test", "post_id", createdPost.ID, "title", createdPost.Title)
 
 	return tokens.AccessToken, userByUsername.ID, createdPost.ID, postReq, createdPost, func() {
-		log.Info("Get post test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Get post test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestGetPostByIDSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, postID, postReq, createdPost, teardown := setupGetPostTest(t, tc)
@@ -80,7 +80,7 @@ func TestGetPostByIDSuccess(t *testing.T) {
 
 func TestGetPostByIDNotFound(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	nonExistentPostID := int64(999999)
@@ -91,7 +91,7 @@ func TestGetPostByIDNotFound(t *testing.T) {
 
 func TestGetPostByIDDeletedPost(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, _, postID, _, _, teardown := setupGetPostTest(t, tc)
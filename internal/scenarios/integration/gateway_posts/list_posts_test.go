@@ -13,8 +13,8 @@ import (
 func setupListPostsTest(t *testing.T, tc *TestContext) (string, int64, []*fixtures.CreatePostResponse, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up list posts test", "test", t.Name(), "username", registerReq.Username)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up list posts test", "test", t.Name(), "username", registerReq.Username)
 
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register test user")
@@ -28,27 +28,27 @@ func setupListPostsTest(t *testing.T, tc *TestContext) (string, int64, []*fixtur
 	var createdPosts []*fixtures.CreatePostResponse
 
 	for i := 0; i < 5; i++ {
-		postReq := fixtures.GenerateCreatePostRequest()
+		postReq := tc.Fixtures.GenerateCreatePostRequest()
 		createdPost, err := tc.PostClient.CreatePost(*postReq)
 		require.NoError(t, err, "Failed to create test post")
 
 		tc.TrackPostForCleanup(createdPost.ID, userByUsername.ID, tokens.AccessToken)
 		createdPosts = append(createdPosts, createdPost)
 
-		log.Info("Created test post for list test", "post_id", createdPost.ID, "title", createdPost.Title)
+		tc.Log.Info("Created test post for list test", "post_id", createdPost.ID, "title", createdPost.Title)
 
 		time.Sleep(10 * time.Millisecond)
 	}
 
 	return tokens.AccessToken, userByUsername.ID, createdPosts, func() {
-		log.Info("List posts test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("List posts test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestListPostsAll(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, authorID, createdPosts, teardown := setupListPostsTest(t, tc)
@@ -72,7 +72,7 @@ func TestListPostsAll(t *testing.T) {
 
 func TestListPostsByAuthor(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, authorID, createdPosts, teardown := setupListPostsTest(t, tc)
@@ -106,7 +106,7 @@ func TestListPostsByAuthor(t *testing.T) {
 
 func TestListPostsWithDateFilters(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, authorID, createdPosts, teardown := setupListPostsTest(t, tc)
@@ -143,7 +143,7 @@ func TestListPostsWithDateFilters(t *testing.T) {
 
 func TestListPostsPagination(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, authorID, createdPosts, teardown := setupListPostsTest(t, tc)
@@ -177,7 +177,7 @@ func TestListPostsPagination(t *testing.T) {
 
 func TestListPostsWithInvalidParams(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, authorID, _, teardown := setupListPostsTest(t, tc)
@@ -0,0 +1,175 @@
+package gateway_posts
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/stretchr/testify/require"
+)
+
+// authorPollInterval is how often posts are re-read while waiting for the
+// denormalized author to catch up with the user service.
+const authorPollInterval = 250 * time.Millisecond
+
+// authorObservation is the author one read endpoint returned for a post.
+type authorObservation struct {
+	Source string
+	PostID int64
+	Author fixtures.PostAuthor
+}
+
+func (o authorObservation) String() string {
+	return fmt.Sprintf("%s post %d: username=%q full_name=%q avatar_url=%q",
+		o.Source, o.PostID, o.Author.Username, o.Author.FullName, o.Author.AvatarURL)
+}
+
+// authorOf is the PostAuthor the post service should show for u.
+func authorOf(u fixtures.User) fixtures.PostAuthor {
+	return fixtures.PostAuthor{
+		ID:        u.ID,
+		Username:  u.Username,
+		FullName:  u.FullName,
+		AvatarURL: u.AvatarURL,
+	}
+}
+
+// observePostAuthors reads every post both by ID and through ListPosts of
+// its author. A post missing from the listing is an error, not a stale view.
+func observePostAuthors(u *factory.User, postIDs []int64) ([]authorObservation, error) {
+	var obs []authorObservation
+
+	for _, id := range postIDs {
+		post, err := u.Posts.GetPostByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("get post %d: %w", id, err)
+		}
+		obs = append(obs, authorObservation{Source: "GetPostByID", PostID: id, Author: post.Author})
+	}
+
+	list, err := u.Posts.ListPosts(u.ID, time.Time{}, time.Time{}, 0, 100)
+	if err != nil {
+		return nil, fmt.Errorf("list posts of author %d: %w", u.ID, err)
+	}
+	listed := make(map[int64]fixtures.PostAuthor, len(list.Posts))
+	for _, p := range list.Posts {
+		listed[p.ID] = p.Author
+	}
+	for _, id := range postIDs {
+		author, ok := listed[id]
+		if !ok {
+			return nil, fmt.Errorf("post %d is missing from ListPosts of author %d", id, u.ID)
+		}
+		obs = append(obs, authorObservation{Source: "ListPosts", PostID: id, Author: author})
+	}
+
+	return obs, nil
+}
+
+func staleAuthors(obs []authorObservation, want fixtures.PostAuthor) []authorObservation {
+	var stale []authorObservation
+	for _, o := range obs {
+		if o.Author != want {
+			stale = append(stale, o)
+		}
+	}
+	return stale
+}
+
+// waitForAuthorSync polls the posts until every read shows want or the
+// deadline passes. It returns how long catching up took and, if it never
+// did, the views that were still stale at the deadline.
+func waitForAuthorSync(t *testing.T, u *factory.User, postIDs []int64, want fixtures.PostAuthor) (time.Duration, []authorObservation) {
+	t.Helper()
+
+	start := time.Now()
+	deadline := start.Add(cfg.Outbox.DeliveryBound(len(postIDs)) + cfg.API.Timeout)
+	for {
+		obs, err := observePostAuthors(u, postIDs)
+		require.NoError(t, err, "Failed to read posts of author %d", u.ID)
+
+		stale := staleAuthors(obs, want)
+		if len(stale) == 0 {
+			return time.Since(start), nil
+		}
+		if time.Now().After(deadline) {
+			return time.Since(start), stale
+		}
+		time.Sleep(authorPollInterval)
+	}
+}
+
+func TestPostAuthorConsistency(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		update func(t *testing.T, tc *TestContext, u *factory.User) fixtures.User
+	}{
+		{
+			name: "UpdateUser",
+			update: func(t *testing.T, tc *TestContext, u *factory.User) fixtures.User {
+				req := tc.Fixtures.NewUserUpdate(u.ID).Build()
+				_, err := u.Users.UpdateUser(*req)
+				require.NoError(t, err, "Failed to update user")
+				profile := u.User
+				profile.Username = req.Username
+				profile.FullName = req.FullName
+				return profile
+			},
+		},
+		{
+			name: "UpdateAvatar",
+			update: func(t *testing.T, tc *TestContext, u *factory.User) fixtures.User {
+				req := tc.Fixtures.GenerateUpdateAvatarRequest()
+				require.NoError(t, u.Users.UpdateAvatar(*req), "Failed to update avatar")
+				profile := u.User
+				profile.AvatarURL = req.AvatarURL
+				return profile
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			tc := NewTestContext(t)
+			defer tc.Cleanup()
+
+			ctx := tc.APIClient.Context()
+			author, err := tc.Factory.User(ctx)
+			require.NoError(t, err, "Failed to create author")
+			posts, err := tc.Factory.PostBy(ctx, author, 2)
+			require.NoError(t, err, "Failed to create posts")
+
+			postIDs := make([]int64, 0, len(posts))
+			for _, p := range posts {
+				postIDs = append(postIDs, p.ID)
+			}
+
+			_, stale := waitForAuthorSync(t, author, postIDs, authorOf(author.User))
+			require.Empty(t, stale, "Posts must show the author as registered before any update")
+
+			profile := testCase.update(t, tc, author)
+			want := authorOf(profile)
+
+			lag, stale := waitForAuthorSync(t, author, postIDs, want)
+			if len(stale) > 0 {
+				for _, o := range stale {
+					t.Errorf("Denormalized author still stale after %s: %s, want username=%q full_name=%q avatar_url=%q",
+						lag.Round(time.Millisecond), o, want.Username, want.FullName, want.AvatarURL)
+				}
+				return
+			}
+
+			tc.Log.Info("Denormalized post author caught up",
+				"update", testCase.name,
+				"author_id", author.ID,
+				"posts", len(postIDs),
+				"lag_ms", lag.Milliseconds())
+		})
+	}
+}
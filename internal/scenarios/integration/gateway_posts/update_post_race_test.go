@@ -0,0 +1,121 @@
+package gateway_posts
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/race"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sortedTagNames(tags []fixtures.Tag) string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func sortedMediaURLs(media []fixtures.PostMedia) string {
+	urls := make([]string, 0, len(media))
+	for _, m := range media {
+		urls = append(urls, m.URL)
+	}
+	sort.Strings(urls)
+	return strings.Join(urls, ",")
+}
+
+func sortedInputURLs(items []fixtures.MediaItemInput) string {
+	urls := make([]string, 0, len(items))
+	for _, item := range items {
+		urls = append(urls, item.URL)
+	}
+	sort.Strings(urls)
+	return strings.Join(urls, ",")
+}
+
+// payloadOwners returns, for every field of post, the index of the payload
+// it came from, or -1 if no payload has that value.
+func payloadOwners(post *fixtures.Post, payloads []*fixtures.UpdatePostRequest) map[string]int {
+	owners := map[string]int{"title": -1, "content": -1, "tags": -1, "media": -1}
+	tags := sortedTagNames(post.Tags)
+	media := sortedMediaURLs(post.Media)
+	for i, p := range payloads {
+		reqTags := append([]string(nil), p.Tags...)
+		sort.Strings(reqTags)
+		if p.Title == post.Title {
+			owners["title"] = i
+		}
+		if p.Content == post.Content {
+			owners["content"] = i
+		}
+		if strings.Join(reqTags, ",") == tags {
+			owners["tags"] = i
+		}
+		if sortedInputURLs(p.MediaItems) == media {
+			owners["media"] = i
+		}
+	}
+	return owners
+}
+
+func TestConcurrentUpdatePostNotTorn(t *testing.T) {
+	t.Parallel()
+	tc := NewTestContext(t)
+	defer tc.Cleanup()
+
+	ctx := tc.APIClient.Context()
+	author, err := tc.Factory.User(ctx)
+	require.NoError(t, err, "Failed to create author")
+	posts, err := tc.Factory.PostBy(ctx, author, 1)
+	require.NoError(t, err, "Failed to create post")
+	postID := posts[0].ID
+
+	writers := cfg.Race.Writers
+	payloads := make([]*fixtures.UpdatePostRequest, writers)
+	for i := range payloads {
+		payloads[i] = tc.Fixtures.NewPost().WithTags(fixtures.MinTagItems).WithMedia("image", 2).BuildUpdate()
+	}
+
+	errs := race.Together(writers, func(i int) error {
+		_, err := author.Posts.UpdatePost(postID, *payloads[i])
+		return err
+	})
+
+	succeeded := make(map[int]bool)
+	for i, err := range errs {
+		if err == nil {
+			succeeded[i] = true
+			continue
+		}
+		code := client.StatusCode(err)
+		assert.True(t, code >= http.StatusBadRequest && code < http.StatusInternalServerError,
+			"Writer %d: a losing concurrent update must fail with a 4xx, got %v", i, err)
+	}
+	require.NotEmpty(t, succeeded, "At least one concurrent update must succeed")
+
+	final, err := author.Posts.GetPostByID(postID)
+	require.NoError(t, err, "Failed to read post after concurrent updates")
+
+	owners := payloadOwners(final, payloads)
+	title := owners["title"]
+	require.NotEqual(t, -1, title, "Final title %q matches none of the submitted payloads", final.Title)
+	for field, owner := range owners {
+		assert.Equal(t, title, owner, "Torn write: %s comes from payload %d but title from payload %d", field, owner, title)
+	}
+	assert.True(t, succeeded[title], "Final state comes from payload %d whose request failed", title)
+
+	tc.Log.Info("Concurrent post updates settled on one payload",
+		"post_id", postID,
+		"writers", writers,
+		"succeeded", len(succeeded),
+		"winner", title,
+		"owners", fmt.Sprint(owners))
+}
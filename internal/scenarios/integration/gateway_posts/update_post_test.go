@@ -12,8 +12,8 @@ import (
 func setupUpdatePostTest(t *testing.T, tc *TestContext) (string, int64, int64, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up update post test", "test", t.Name(), "username", registerReq.Username)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up update post test", "test", t.Name(), "username", registerReq.Username)
 
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register test user")
@@ -24,32 +24,32 @@ func setupUpdatePostTest(t *testing.T, tc *TestContext) (string, int64, int64, f
 	tc.TrackUserForCleanup(userByUsername.ID, userByUsername.Username, tokens.AccessToken)
 	tc.APIClient.SetToken(tokens.AccessToken)
 
-	postReq := fixtures.GenerateCreatePostRequest()
+	postReq := tc.Fixtures.GenerateCreatePostRequest()
 	createdPost, err := tc.PostClient.CreatePost(*postReq)
 	require.NoError(t, err, "Failed to create test post")
 
 	tc.TrackPostForCleanup(createdPost.ID, userByUsername.ID, tokens.AccessToken)
 
-	log.Info("Created test post for update test",
+	tc.Log.Info("Created test post for update test",
 		"post_id", createdPost.ID,
 		"title", createdPost.Title,
 		"author_id", userByUsername.ID)
 
 	return tokens.AccessToken, userByUsername.ID, createdPost.ID, func() {
-		log.Info("Update post test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Update post test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestUpdatePost(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, authorID, postID, teardown := setupUpdatePostTest(t, tc)
 	defer teardown()
 
-	updateReq := fixtures.GenerateUpdatePostRequest()
+	updateReq := tc.Fixtures.GenerateUpdatePostRequest()
 
 	updatedPost, err := tc.PostClient.UpdatePost(postID, *updateReq)
 	require.NoError(t, err, "Failed to update post")
@@ -89,7 +89,7 @@ func TestUpdatePost(t *testing.T) {
 
 func TestUpdatePostNotFound(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, _, _, teardown := setupUpdatePostTest(t, tc)
@@ -98,7 +98,7 @@ func TestUpdatePostNotFound(t *testing.T) {
 	tc.APIClient.SetToken(accessToken)
 
 	nonExistentPostID := int64(999999) // Use a very large ID that likely doesn't exist
-	updateReq := fixtures.GenerateUpdatePostRequest()
+	updateReq := tc.Fixtures.GenerateUpdatePostRequest()
 
 	_, err := tc.PostClient.UpdatePost(nonExistentPostID, *updateReq)
 	require.Error(t, err)
@@ -107,14 +107,14 @@ func TestUpdatePostNotFound(t *testing.T) {
 
 func TestUpdatePostForbidden(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, postID, teardown := setupUpdatePostTest(t, tc)
 	defer teardown()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Registering second user for forbidden test", "username", registerReq.Username)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Registering second user for forbidden test", "username", registerReq.Username)
 
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err)
@@ -126,7 +126,7 @@ func TestUpdatePostForbidden(t *testing.T) {
 
 	tc.APIClient.SetToken(tokens.AccessToken)
 
-	updateReq := fixtures.GenerateUpdatePostRequest()
+	updateReq := tc.Fixtures.GenerateUpdatePostRequest()
 
 	_, err = tc.PostClient.UpdatePost(postID, *updateReq)
 	require.Error(t, err)
@@ -135,7 +135,7 @@ func TestUpdatePostForbidden(t *testing.T) {
 
 func TestUpdatePostWithInvalidData(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, postID, teardown := setupUpdatePostTest(t, tc)
@@ -169,7 +169,7 @@ func TestUpdatePostWithInvalidData(t *testing.T) {
 
 func TestPartialUpdatePost(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, postID, teardown := setupUpdatePostTest(t, tc)
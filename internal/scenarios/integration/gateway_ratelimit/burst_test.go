@@ -0,0 +1,143 @@
+package gateway_ratelimit
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recoveryPoll is how often a throttled endpoint is retried while waiting for
+// the limiter to let requests through again.
+const recoveryPoll = time.Second
+
+// floodTarget is an endpoint the bursts can be aimed at. Per-IP targets are
+// called without a token, so only the caller's address identifies them.
+type floodTarget struct {
+	name  string
+	perIP bool
+	// call makes one request as u; peer is another user it may read.
+	call func(u, peer *factory.User) error
+}
+
+var floodTargets = []floodTarget{
+	{name: "get_user", call: func(u, peer *factory.User) error {
+		_, err := u.Users.GetUserByID(peer.ID)
+		return err
+	}},
+	{name: "list_posts", call: func(u, peer *factory.User) error {
+		_, err := u.Posts.ListPosts(peer.ID, time.Time{}, time.Time{}, 0, 10)
+		return err
+	}},
+	{name: "unread_count", call: func(u, _ *factory.User) error {
+		_, err := u.Notifications.GetUnreadCount(u.ID)
+		return err
+	}},
+	{name: "login", perIP: true, call: func(u, _ *factory.User) error {
+		anon := client.NewAuthClient(client.NewClient(cfg, log))
+		_, err := anon.Login(fixtures.LoginRequest{Login: u.Username, Password: u.Password + "-wrong"})
+		return err
+	}},
+}
+
+func selectedTargets(t *testing.T) []floodTarget {
+	byName := make(map[string]floodTarget, len(floodTargets))
+	for _, ft := range floodTargets {
+		byName[ft.name] = ft
+	}
+	var targets []floodTarget
+	for _, name := range cfg.RateLimit.Endpoints {
+		ft, ok := byName[name]
+		require.True(t, ok, "rate_limit.endpoints: unknown endpoint %q", name)
+		targets = append(targets, ft)
+	}
+	return targets
+}
+
+// TestRateLimitBurst floods every selected endpoint in turn. Targets run one
+// after another, since a limiter left tripped by one would throttle the next.
+func TestRateLimitBurst(t *testing.T) {
+	for _, ft := range selectedTargets(t) {
+		t.Run(ft.name, func(t *testing.T) {
+			tc := NewTestContext(t)
+			ctx := tc.APIClient.Context()
+
+			users, err := tc.Factory.Users(ctx, 2)
+			require.NoError(t, err, "Failed to create users")
+			flooder, bystander := users[0], users[1]
+			call := func() error { return ft.call(flooder, bystander) }
+
+			res := ratelimit.Burst(ctx, cfg.RateLimit.Burst, cfg.RateLimit.Concurrency, call)
+			tc.Log.Info("Burst finished",
+				"endpoint", ft.name,
+				"sent", res.Sent,
+				"ok", res.OK,
+				"throttled", res.Throttled,
+				"rejected", res.Rejected,
+				"server_errors", res.ServerErrors,
+				"failed", res.Failed,
+				"took", res.Took.String())
+
+			require.Equal(t, cfg.RateLimit.Burst, res.Sent)
+			assert.Zero(t, res.ServerErrors+res.Failed,
+				"A flood must be refused, not break the gateway: %s", firstErrors(res.Unexpected, 3))
+
+			if res.Throttled == 0 {
+				if cfg.RateLimit.ExpectLimit {
+					t.Fatalf("None of %d requests to %s was throttled", res.Sent, ft.name)
+				}
+				t.Skipf("Gateway throttled none of %d requests to %s; set rate_limit.expect_limit once it ships limits", res.Sent, ft.name)
+			}
+
+			t.Run("429 responses", func(t *testing.T) {
+				seen := make(map[string]bool)
+				for _, e := range res.Throttles {
+					for _, p := range ratelimit.CheckThrottle(e, cfg.RateLimit.Window) {
+						if !seen[p] {
+							seen[p] = true
+							t.Errorf("429 (request_id=%s): %s", e.RequestID, p)
+						}
+					}
+				}
+			})
+
+			t.Run("other users unaffected", func(t *testing.T) {
+				if ft.perIP {
+					t.Skip("Per-IP limits cannot be told apart from one address")
+				}
+				err := ft.call(bystander, flooder)
+				assert.NotEqual(t, http.StatusTooManyRequests, client.StatusCode(err),
+					"%s throttled another user while only %s flooded it: %v", ft.name, flooder.Username, err)
+			})
+
+			t.Run("recovers after the window", func(t *testing.T) {
+				first := time.Duration(0)
+				if wait, ok := client.RetryAfter(res.Throttles[0]); ok {
+					first = wait
+				}
+				took, err := ratelimit.Recover(ctx, first, cfg.RateLimit.Window, recoveryPoll, call)
+				require.NoError(t, err)
+				tc.Log.Info("Endpoint recovered", "endpoint", ft.name, "after", took.String())
+			})
+		})
+	}
+}
+
+func firstErrors(errs []error, n int) string {
+	if len(errs) == 0 {
+		return "none"
+	}
+	msgs := make([]string, 0, n)
+	for _, err := range errs[:min(n, len(errs))] {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d errors, e.g. %s", len(errs), strings.Join(msgs, "; "))
+}
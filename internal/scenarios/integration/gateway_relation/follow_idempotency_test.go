@@ -0,0 +1,224 @@
+package gateway_relation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/events"
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// followDeliveryReport compares the follows that succeeded for one pair with
+// what each stage after them produced, so a duplicate can be pinned to the
+// outbox (extra events) or to the notification consumer (extra
+// notifications for the same events).
+type followDeliveryReport struct {
+	FollowerID    int64
+	FolloweeID    int64
+	Follows       int
+	Events        int // follow_created records on relation-events; -1 without Kafka
+	Notifications []fixtures.Notification
+	Unread        int
+}
+
+func (r followDeliveryReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "pair %d -> %d: %d successful follows\n", r.FollowerID, r.FolloweeID, r.Follows)
+	if r.Events >= 0 {
+		fmt.Fprintf(&sb, "  relation-events follow_created: %d%s\n", r.Events, excess(r.Events, r.Follows))
+	} else {
+		sb.WriteString("  relation-events follow_created: not observed (no Kafka subscription)\n")
+	}
+	fmt.Fprintf(&sb, "  feed follow_created:            %d%s\n", len(r.Notifications), excess(len(r.Notifications), r.Follows))
+	fmt.Fprintf(&sb, "  unread counter:                 %d%s\n", r.Unread, excess(r.Unread, r.Follows))
+	for _, n := range r.Notifications {
+		fmt.Fprintf(&sb, "    notification %d created %s payload %v\n", n.ID, n.CreatedAt.Format(time.RFC3339Nano), n.Payload)
+	}
+	return sb.String()
+}
+
+func excess(got, want int) string {
+	switch {
+	case got > want:
+		return fmt.Sprintf("  <- %d DUPLICATE(S)", got-want)
+	case got < want:
+		return fmt.Sprintf("  <- %d MISSING", want-got)
+	}
+	return ""
+}
+
+// trySubscribeRelationEvents subscribes like subscribeRelationEvents but
+// returns nil when the broker is unreachable; the suite then reports the
+// feed and counter only.
+func trySubscribeRelationEvents(t *testing.T, tc *TestContext) *events.Consumer {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(tc.APIClient.Context(), 10*time.Second)
+	defer cancel()
+
+	consumer, err := events.SubscribeRelationEvents(ctx, cfg, tc.Log)
+	if err != nil {
+		t.Logf("Kafka unavailable, duplicate report covers the feed only: %v", err)
+		return nil
+	}
+	t.Cleanup(func() { _ = consumer.Close() })
+	return consumer
+}
+
+// collectFollowDelivery waits until the followee's feed holds follows
+// follow_created notifications, then waits one more tick plus slack so late
+// duplicates land too, and counts every stage.
+func collectFollowDelivery(t *testing.T, consumer *events.Consumer, follower, followee *factory.User, follows int) followDeliveryReport {
+	t.Helper()
+
+	r := followDeliveryReport{FollowerID: follower.ID, FolloweeID: followee.ID, Follows: follows, Events: -1}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Outbox.DeliveryBound(follows)+cfg.API.Timeout)
+	defer cancel()
+
+	feed := func() []fixtures.Notification {
+		var out []fixtures.Notification
+		for page := 1; ; page++ {
+			resp, err := followee.Notifications.GetUserNotificationFeed(followee.ID, page, 100)
+			require.NoError(t, err, "Failed to get notification feed")
+			for _, n := range resp.Notifications {
+				if n.Type == fixtures.NotificationTypeFollowCreated {
+					out = append(out, n)
+				}
+			}
+			if page >= resp.TotalPages {
+				return out
+			}
+		}
+	}
+
+	for len(feed()) < follows && ctx.Err() == nil {
+		time.Sleep(feedPollInterval)
+	}
+	time.Sleep(cfg.Outbox.TickInterval() + time.Duration(cfg.Outbox.DeliverySlackMs)*time.Millisecond)
+
+	r.Notifications = feed()
+
+	unread, err := followee.Notifications.GetUnreadCount(followee.ID)
+	require.NoError(t, err, "Failed to get unread count")
+	r.Unread = unread.Count
+
+	if consumer != nil {
+		r.Events = len(consumer.Events(events.Filter{
+			Type:       events.TypeFollowCreated,
+			FollowerID: follower.ID,
+			FolloweeID: followee.ID,
+		}))
+	}
+	return r
+}
+
+func assertDeliveredOnce(t *testing.T, r followDeliveryReport) {
+	t.Helper()
+
+	t.Logf("Duplicate delivery report:\n%s", r)
+	if r.Events >= 0 {
+		assert.Equal(t, r.Follows, r.Events, "Outbox must publish one follow_created per follow")
+	}
+	assert.Len(t, r.Notifications, r.Follows, "Notification service must create one follow_created per follow")
+	assert.Equal(t, r.Follows, r.Unread, "Unread counter must count each follow once")
+}
+
+func TestFollowCyclesNotifyOncePerFollow(t *testing.T) {
+	t.Parallel()
+	tc := NewTestContext(t)
+	defer tc.Cleanup()
+
+	consumer := trySubscribeRelationEvents(t, tc)
+
+	follower, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(t, err, "Failed to create follower user")
+	followee, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(t, err, "Failed to create followee user")
+
+	cycles := cfg.Idempotency.FollowCycles
+	follows := 0
+	for i := 0; i < cycles; i++ {
+		_, err := follower.Relations.Follow(followee.ID)
+		require.NoError(t, err, "Follow in cycle %d failed", i)
+		follows++
+
+		if i == cycles-1 {
+			break
+		}
+		_, err = follower.Relations.Unfollow(followee.ID)
+		require.NoError(t, err, "Unfollow in cycle %d failed", i)
+	}
+	tc.TrackRelationForCleanup(follower.ID, followee.ID, follower.AccessToken)
+
+	report := collectFollowDelivery(t, consumer, follower, followee, follows)
+	tc.DiscoverAndTrackAllNotifications(followee.ID, followee.AccessToken)
+
+	assertDeliveredOnce(t, report)
+}
+
+func TestConcurrentDuplicateFollows(t *testing.T) {
+	t.Parallel()
+	tc := NewTestContext(t)
+	defer tc.Cleanup()
+
+	consumer := trySubscribeRelationEvents(t, tc)
+
+	follower, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(t, err, "Failed to create follower user")
+	followee, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(t, err, "Failed to create followee user")
+
+	n := cfg.Idempotency.ConcurrentFollows
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+		rejected  []string
+		start     = make(chan struct{})
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := follower.Relations.Follow(followee.ID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				rejected = append(rejected, err.Error())
+				return
+			}
+			succeeded++
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if succeeded > 0 {
+		tc.TrackRelationForCleanup(follower.ID, followee.ID, follower.AccessToken)
+	}
+	t.Logf("%d identical follows: %d succeeded, %d rejected", n, succeeded, len(rejected))
+	for _, msg := range rejected {
+		tc.Log.Debug("Duplicate follow rejected", "error", msg)
+	}
+
+	assert.Equal(t, 1, succeeded, "Exactly one of %d concurrent identical follows should succeed", n)
+
+	report := collectFollowDelivery(t, consumer, follower, followee, succeeded)
+	tc.DiscoverAndTrackAllNotifications(followee.ID, followee.AccessToken)
+
+	followers, err := followee.Relations.GetFollowers(followee.ID, 1, 10)
+	require.NoError(t, err, "Failed to get followers")
+	assert.EqualValues(t, 1, followers.Total, "The pair must be stored once")
+
+	assertDeliveredOnce(t, report)
+}
@@ -5,7 +5,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -13,37 +12,23 @@ import (
 func setupFollowUserTest(t *testing.T, tc *TestContext) (followerToken string, followerID int64, followeeToken string, followeeID int64, teardown func()) {
 	t.Helper()
 
-	followerRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up follow user test - registering follower", "test", t.Name(), "username", followerRegisterReq.Username)
+	tc.Log.Info("Setting up follow user test - registering follower and followee", "test", t.Name())
 
-	followerTokens, err := tc.AuthClient.Register(*followerRegisterReq)
-	require.NoError(t, err, "Failed to register follower user")
+	follower, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(t, err, "Failed to create follower user")
 
-	followerUser, err := tc.UserClient.GetUserByUsername(followerRegisterReq.Username)
-	require.NoError(t, err, "Failed to get follower user info")
+	followee, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(t, err, "Failed to create followee user")
 
-	tc.TrackUserForCleanup(followerUser.ID, followerUser.Username, followerTokens.AccessToken)
-
-	followeeRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up follow user test - registering followee", "test", t.Name(), "username", followeeRegisterReq.Username)
-
-	followeeTokens, err := tc.AuthClient.Register(*followeeRegisterReq)
-	require.NoError(t, err, "Failed to register followee user")
-
-	followeeUser, err := tc.UserClient.GetUserByUsername(followeeRegisterReq.Username)
-	require.NoError(t, err, "Failed to get followee user info")
-
-	tc.TrackUserForCleanup(followeeUser.ID, followeeUser.Username, followeeTokens.AccessToken)
-
-	return followerTokens.AccessToken, followerUser.ID, followeeTokens.AccessToken, followeeUser.ID, func() {
-		log.Info("Follow user test complete, local cleanup", "test", t.Name())
+	return follower.AccessToken, follower.ID, followee.AccessToken, followee.ID, func() {
+		tc.Log.Info("Follow user test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestFollowUserSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	followerToken, followerID, followeeToken, followeeID, teardown := setupFollowUserTest(t, tc)
@@ -60,12 +45,12 @@ func TestFollowUserSuccess(t *testing.T) {
 	tc.TrackRelationForCleanup(followerID, followeeID, followerToken)
 	tc.DiscoverAndTrackAllNotifications(followeeID, followeeToken)
 
-	log.Info("Successfully followed user", "follower_id", followerID, "followee_id", followeeID)
+	tc.Log.Info("Successfully followed user", "follower_id", followerID, "followee_id", followeeID)
 }
 
 func TestFollowUserUnauthorized(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, _, followeeID, teardown := setupFollowUserTest(t, tc)
@@ -79,12 +64,12 @@ func TestFollowUserUnauthorized(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrUnauthenticated.Error(), "Error should be unauthenticated")
 	assert.Nil(t, followResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected unauthorized follow request", "followee_id", followeeID)
+	tc.Log.Info("Correctly rejected unauthorized follow request", "followee_id", followeeID)
 }
 
 func TestFollowUserInvalidToken(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, _, followeeID, teardown := setupFollowUserTest(t, tc)
@@ -98,12 +83,12 @@ func TestFollowUserInvalidToken(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrInvalidToken.Error(), "Error should be unauthenticated")
 	assert.Nil(t, followResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected invalid token follow request", "followee_id", followeeID)
+	tc.Log.Info("Correctly rejected invalid token follow request", "followee_id", followeeID)
 }
 
 func TestFollowUserNotFound(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	followerToken, _, _, _, teardown := setupFollowUserTest(t, tc)
@@ -118,12 +103,12 @@ func TestFollowUserNotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrUserNotFound.Error(), "Error should be user not found")
 	assert.Nil(t, followResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected follow request for non-existent user", "followee_id", nonExistentUserID)
+	tc.Log.Info("Correctly rejected follow request for non-existent user", "followee_id", nonExistentUserID)
 }
 
 func TestFollowUserSelf(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	followerToken, followerID, _, _, teardown := setupFollowUserTest(t, tc)
@@ -137,12 +122,12 @@ func TestFollowUserSelf(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrSelfFollow.Error(), "Error should be self follow")
 	assert.Nil(t, followResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected self-follow request", "user_id", followerID)
+	tc.Log.Info("Correctly rejected self-follow request", "user_id", followerID)
 }
 
 func TestFollowUserAlreadyFollowing(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	followerToken, followerID, followeeToken, followeeID, teardown := setupFollowUserTest(t, tc)
@@ -164,12 +149,12 @@ func TestFollowUserAlreadyFollowing(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrAlreadyFollowing.Error(), "Error should be already following")
 	assert.Nil(t, followResp2, "Second response should be nil on error")
 
-	log.Info("Correctly rejected duplicate follow request", "follower_id", followerID, "followee_id", followeeID)
+	tc.Log.Info("Correctly rejected duplicate follow request", "follower_id", followerID, "followee_id", followeeID)
 }
 
 func TestFollowUserValidationErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	followerToken, _, _, _, teardown := setupFollowUserTest(t, tc)
@@ -205,14 +190,14 @@ func TestFollowUserValidationErrors(t *testing.T) {
 			assert.Contains(t, err.Error(), testCase.expectedErr.Error(), "Error should match expected type for %s", testCase.description)
 			assert.Nil(t, followResp, "Response should be nil on error for %s", testCase.description)
 
-			log.Info("Correctly rejected invalid followee ID", "test_case", testCase.name, "followee_id", testCase.followeeID)
+			tc.Log.Info("Correctly rejected invalid followee ID", "test_case", testCase.name, "followee_id", testCase.followeeID)
 		})
 	}
 }
 
 func TestFollowUserWithNotificationGeneration(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	followerToken, followerID, followeeToken, followeeID, teardown := setupFollowUserTest(t, tc)
@@ -237,14 +222,14 @@ func TestFollowUserWithNotificationGeneration(t *testing.T) {
 	for _, notification := range feedResp.Notifications {
 		if notification.Type == "follow_created" {
 			foundFollowNotification = true
-			log.Info("Found follow_created notification", "notification_id", notification.ID, "followee_id", followeeID)
+			tc.Log.Info("Found follow_created notification", "notification_id", notification.ID, "followee_id", followeeID)
 			break
 		}
 	}
 
 	assert.True(t, foundFollowNotification, "Should have created follow_created notification")
 
-	log.Info("Successfully verified follow user with notification generation",
+	tc.Log.Info("Successfully verified follow user with notification generation",
 		"follower_id", followerID,
 		"followee_id", followeeID,
 		"notification_found", foundFollowNotification)
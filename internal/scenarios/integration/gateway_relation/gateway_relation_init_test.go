@@ -1,6 +1,7 @@
 package gateway_relation
 
 import (
+	"context"
 	"flag"
 	"os"
 	"sync"
@@ -9,7 +10,11 @@ import (
 
 	"github.com/Soloda1/pinstack-system-tests/config"
 	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/histogram"
 	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/Soloda1/pinstack-system-tests/internal/tracing"
 )
 
 var (
@@ -36,6 +41,9 @@ type NotificationCleanupInfo struct {
 }
 
 type TestContext struct {
+	Fixtures             *fixtures.Generator
+	Factory              *factory.Factory
+	Log                  *logger.Logger
 	APIClient            *client.Client
 	AuthClient           *client.AuthClient
 	UserClient           *client.UserClient
@@ -47,9 +55,15 @@ type TestContext struct {
 	mu                   sync.Mutex
 }
 
-func NewTestContext() *TestContext {
-	apiClient := client.NewClient(cfg, log)
+func NewTestContext(t *testing.T) *TestContext {
+	testLog := logger.ForTest(t)
+	gen := fixtures.ForTest(t)
+	apiClient := client.NewClient(cfg, testLog)
+	apiClient.SetContext(tracing.StartTest(t))
 	return &TestContext{
+		Fixtures:             gen,
+		Factory:              factory.New(t, cfg, testLog, gen),
+		Log:                  testLog,
 		APIClient:            apiClient,
 		AuthClient:           client.NewAuthClient(apiClient),
 		UserClient:           client.NewUserClient(apiClient),
@@ -71,7 +85,7 @@ func (tc *TestContext) TrackUserForCleanup(userID int64, username, accessToken s
 		AccessToken: accessToken,
 	}
 	tc.CreatedUsers = append(tc.CreatedUsers, userInfo)
-	log.Debug("Added user to cleanup list", "user_id", userID, "username", username)
+	tc.Log.Debug("Added user to cleanup list", "user_id", userID, "username", username)
 }
 
 func (tc *TestContext) TrackRelationForCleanup(followerID, followeeID int64, followerToken string) {
@@ -84,7 +98,7 @@ func (tc *TestContext) TrackRelationForCleanup(followerID, followeeID int64, fol
 		FollowerToken: followerToken,
 	}
 	tc.CreatedRelations = append(tc.CreatedRelations, relationInfo)
-	log.Debug("Added relation to cleanup list", "follower_id", followerID, "followee_id", followeeID)
+	tc.Log.Debug("Added relation to cleanup list", "follower_id", followerID, "followee_id", followeeID)
 }
 
 func (tc *TestContext) TrackNotificationForCleanup(notificationID, userID int64, recipientAccessToken string) {
@@ -97,7 +111,7 @@ func (tc *TestContext) TrackNotificationForCleanup(notificationID, userID int64,
 		RecipientAccessToken: recipientAccessToken,
 	}
 	tc.CreatedNotifications = append(tc.CreatedNotifications, notificationInfo)
-	log.Debug("Added notification to cleanup list", "notification_id", notificationID, "user_id", userID)
+	tc.Log.Debug("Added notification to cleanup list", "notification_id", notificationID, "user_id", userID)
 }
 
 func (tc *TestContext) DiscoverAndTrackAllNotifications(userID int64, userToken string) {
@@ -108,7 +122,7 @@ func (tc *TestContext) DiscoverAndTrackAllNotifications(userID int64, userToken
 
 	feedResp, err := tc.NotificationClient.GetUserNotificationFeed(userID, 1, 100)
 	if err != nil {
-		log.Warn("Failed to get notification feed for notification discovery",
+		tc.Log.Warn("Failed to get notification feed for notification discovery",
 			"user_id", userID,
 			"error", err.Error())
 		return
@@ -121,7 +135,7 @@ func (tc *TestContext) DiscoverAndTrackAllNotifications(userID int64, userToken
 			RecipientAccessToken: userToken,
 		}
 		tc.CreatedNotifications = append(tc.CreatedNotifications, notificationInfo)
-		log.Debug("Discovered and tracked notification",
+		tc.Log.Debug("Discovered and tracked notification",
 			"notification_id", notification.ID,
 			"user_id", userID,
 			"type", notification.Type)
@@ -133,12 +147,12 @@ func (tc *TestContext) Cleanup() {
 	defer tc.mu.Unlock()
 
 	if len(tc.CreatedRelations) > 0 {
-		log.Info("Starting relation cleanup process", "relations_to_delete", len(tc.CreatedRelations))
+		tc.Log.Info("Starting relation cleanup process", "relations_to_delete", len(tc.CreatedRelations))
 
 		var successfulRelationDeletions int
 
 		for _, relationInfo := range tc.CreatedRelations {
-			log.Debug("Attempting to unfollow relation",
+			tc.Log.Debug("Attempting to unfollow relation",
 				"follower_id", relationInfo.FollowerID,
 				"followee_id", relationInfo.FolloweeID)
 
@@ -146,12 +160,12 @@ func (tc *TestContext) Cleanup() {
 
 			resp, err := tc.RelationClient.Unfollow(relationInfo.FolloweeID)
 			if err != nil {
-				log.Warn("Failed to unfollow during cleanup",
+				tc.Log.Warn("Failed to unfollow during cleanup",
 					"follower_id", relationInfo.FollowerID,
 					"followee_id", relationInfo.FolloweeID,
 					"error", err.Error())
 			} else {
-				log.Debug("Successfully unfollowed relation",
+				tc.Log.Debug("Successfully unfollowed relation",
 					"follower_id", relationInfo.FollowerID,
 					"followee_id", relationInfo.FolloweeID,
 					"message", resp.Message)
@@ -159,18 +173,18 @@ func (tc *TestContext) Cleanup() {
 			}
 		}
 
-		log.Info("Relation cleanup process completed",
+		tc.Log.Info("Relation cleanup process completed",
 			"successful_deletions", successfulRelationDeletions,
 			"total_relations", len(tc.CreatedRelations))
 	}
 
 	if len(tc.CreatedNotifications) > 0 {
-		log.Info("Starting notification cleanup process", "notifications_to_delete", len(tc.CreatedNotifications))
+		tc.Log.Info("Starting notification cleanup process", "notifications_to_delete", len(tc.CreatedNotifications))
 
 		var successfulNotificationDeletions int
 
 		for _, notificationInfo := range tc.CreatedNotifications {
-			log.Debug("Attempting to delete notification",
+			tc.Log.Debug("Attempting to delete notification",
 				"notification_id", notificationInfo.ID,
 				"user_id", notificationInfo.UserID)
 
@@ -178,46 +192,46 @@ func (tc *TestContext) Cleanup() {
 
 			resp, err := tc.NotificationClient.RemoveNotification(notificationInfo.ID)
 			if err != nil {
-				log.Warn("Failed to delete notification during cleanup",
+				tc.Log.Warn("Failed to delete notification during cleanup",
 					"notification_id", notificationInfo.ID,
 					"user_id", notificationInfo.UserID,
 					"error", err.Error())
 			} else {
-				log.Debug("Successfully deleted notification",
+				tc.Log.Debug("Successfully deleted notification",
 					"notification_id", notificationInfo.ID,
 					"success", resp.Success)
 				successfulNotificationDeletions++
 			}
 		}
 
-		log.Info("Notification cleanup process completed",
+		tc.Log.Info("Notification cleanup process completed",
 			"successful_deletions", successfulNotificationDeletions,
 			"total_notifications", len(tc.CreatedNotifications))
 	}
 
 	if len(tc.CreatedUsers) > 0 {
-		log.Info("Starting user cleanup process", "users_to_delete", len(tc.CreatedUsers))
+		tc.Log.Info("Starting user cleanup process", "users_to_delete", len(tc.CreatedUsers))
 
 		var successfulUserDeletions int
 
 		for _, userInfo := range tc.CreatedUsers {
-			log.Debug("Attempting to delete user", "user_id", userInfo.ID, "username", userInfo.Username)
+			tc.Log.Debug("Attempting to delete user", "user_id", userInfo.ID, "username", userInfo.Username)
 
 			tc.APIClient.SetToken(userInfo.AccessToken)
 
 			err := tc.UserClient.DeleteUser(userInfo.ID)
 			if err != nil {
-				log.Warn("Failed to delete user during cleanup",
+				tc.Log.Warn("Failed to delete user during cleanup",
 					"user_id", userInfo.ID,
 					"username", userInfo.Username,
 					"error", err.Error())
 			} else {
-				log.Debug("Successfully deleted user", "user_id", userInfo.ID, "username", userInfo.Username)
+				tc.Log.Debug("Successfully deleted user", "user_id", userInfo.ID, "username", userInfo.Username)
 				successfulUserDeletions++
 			}
 		}
 
-		log.Info("User cleanup process completed",
+		tc.Log.Info("User cleanup process completed",
 			"successful_deletions", successfulUserDeletions,
 			"total_users", len(tc.CreatedUsers))
 	}
@@ -234,11 +248,28 @@ func TestMain(m *testing.M) {
 	flag.Parse()
 
 	cfg = config.MustLoad("../../../../config")
-	log = logger.New(cfg.Env)
+	log = logger.New(cfg.Env, cfg.Test.LogLevel)
+	logger.SetRedactedFields(cfg.Redaction.Fields...)
+	logger.SetTestLevel(cfg.Env, cfg.Test.LogLevel)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Error("Failed to set up tracing", "error", err.Error())
+		os.Exit(1)
+	}
+
 	outboxTickInterval = cfg.Outbox.TickInterval()
 	log.Info("Starting relation gateway tests", "env", cfg.Env)
 
+	saveLatencies := histogram.RecordClients(cfg.Perf, "gateway_relation")
 	code := m.Run()
+	if err := saveLatencies(); err != nil {
+		log.Warn("Failed to save latency histograms", "error", err.Error())
+	}
+
+	if err := shutdownTracing(context.Background()); err != nil {
+		log.Warn("Failed to flush traces", "error", err.Error())
+	}
 
 	os.Exit(code)
 }
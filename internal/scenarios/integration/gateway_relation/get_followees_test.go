@@ -4,7 +4,6 @@ import (
 	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
 	"testing"
 
-	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -12,8 +11,8 @@ import (
 func setupGetFolloweesTest(t *testing.T, tc *TestContext) (followerUserToken string, followerUserID int64, followeeTokens []string, followeeIDs []int64, teardown func()) {
 	t.Helper()
 
-	followerRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up get followees test - registering follower user", "test", t.Name(), "username", followerRegisterReq.Username)
+	followerRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up get followees test - registering follower user", "test", t.Name(), "username", followerRegisterReq.Username)
 
 	followerTokens, err := tc.AuthClient.Register(*followerRegisterReq)
 	require.NoError(t, err, "Failed to register follower user")
@@ -28,8 +27,8 @@ func setupGetFolloweesTest(t *testing.T, tc *TestContext) (followerUserToken str
 	var followeeIDsList []int64
 
 	for i := 0; i < numFollowees; i++ {
-		followeeRegisterReq := fixtures.GenerateRegisterRequest()
-		log.Info("Setting up get followees test - registering followee", "test", t.Name(), "followee_index", i, "username", followeeRegisterReq.Username)
+		followeeRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+		tc.Log.Info("Setting up get followees test - registering followee", "test", t.Name(), "followee_index", i, "username", followeeRegisterReq.Username)
 
 		followeeTokens, err := tc.AuthClient.Register(*followeeRegisterReq)
 		require.NoError(t, err, "Failed to register followee user %d", i)
@@ -44,14 +43,14 @@ func setupGetFolloweesTest(t *testing.T, tc *TestContext) (followerUserToken str
 	}
 
 	return followerTokens.AccessToken, followerUser.ID, followeeTokensList, followeeIDsList, func() {
-		log.Info("Get followees test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Get followees test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestGetFolloweesSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	followerUserToken, followerUserID, followeeTokens, followeeIDs, teardown := setupGetFolloweesTest(t, tc)
@@ -91,12 +90,12 @@ func TestGetFolloweesSuccess(t *testing.T) {
 		assert.True(t, returnedFolloweeIDs[expectedFolloweeID], "Expected followee %d should be in response", expectedFolloweeID)
 	}
 
-	log.Info("Successfully retrieved followees", "follower_user_id", followerUserID, "followees_count", len(followeesResp.Followees))
+	tc.Log.Info("Successfully retrieved followees", "follower_user_id", followerUserID, "followees_count", len(followeesResp.Followees))
 }
 
 func TestGetFolloweesEmptyList(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	followerUserToken, followerUserID, _, _, teardown := setupGetFolloweesTest(t, tc)
@@ -114,12 +113,12 @@ func TestGetFolloweesEmptyList(t *testing.T) {
 	assert.Equal(t, int64(0), followeesResp.Total, "Total should be 0")
 	assert.Empty(t, followeesResp.Followees, "Followees list should be empty")
 
-	log.Info("Successfully handled empty followees list", "follower_user_id", followerUserID)
+	tc.Log.Info("Successfully handled empty followees list", "follower_user_id", followerUserID)
 }
 
 func TestGetFolloweesUserNotFound(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	followerUserToken, _, _, _, teardown := setupGetFolloweesTest(t, tc)
@@ -134,12 +133,12 @@ func TestGetFolloweesUserNotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrUserNotFound.Error(), "Error should be user not found")
 	assert.Nil(t, followeesResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected get followees request for non-existent user", "user_id", nonExistentUserID)
+	tc.Log.Info("Correctly rejected get followees request for non-existent user", "user_id", nonExistentUserID)
 }
 
 func TestGetFolloweesPagination(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	followerUserToken, followerUserID, followeeTokens, followeeIDs, teardown := setupGetFolloweesTest(t, tc)
@@ -168,7 +167,7 @@ func TestGetFolloweesPagination(t *testing.T) {
 	assert.Equal(t, int32(2), followeesResp.Limit, "Limit should be 2")
 	assert.LessOrEqual(t, len(followeesResp.Followees), 2, "Should return at most 2 followees")
 
-	log.Info("Successfully tested followees pagination",
+	tc.Log.Info("Successfully tested followees pagination",
 		"follower_user_id", followerUserID,
 		"page", followeesResp.Page,
 		"limit", followeesResp.Limit,
@@ -178,7 +177,7 @@ func TestGetFolloweesPagination(t *testing.T) {
 
 func TestGetFolloweesValidationErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	followerUserToken, _, _, _, teardown := setupGetFolloweesTest(t, tc)
@@ -222,7 +221,7 @@ func TestGetFolloweesValidationErrors(t *testing.T) {
 			assert.Contains(t, err.Error(), testCase.expectedErr.Error(), "Error should match expected type for %s", testCase.description)
 			assert.Nil(t, followeesResp, "Response should be nil on error for %s", testCase.description)
 
-			log.Info("Correctly rejected invalid parameters", "test_case", testCase.name)
+			tc.Log.Info("Correctly rejected invalid parameters", "test_case", testCase.name)
 		})
 	}
 }
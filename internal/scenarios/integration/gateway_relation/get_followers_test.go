@@ -4,7 +4,6 @@ import (
 	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
 	"testing"
 
-	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -12,8 +11,8 @@ import (
 func setupGetFollowersTest(t *testing.T, tc *TestContext) (targetUserToken string, targetUserID int64, followerTokens []string, followerIDs []int64, teardown func()) {
 	t.Helper()
 
-	targetRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up get followers test - registering target user", "test", t.Name(), "username", targetRegisterReq.Username)
+	targetRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up get followers test - registering target user", "test", t.Name(), "username", targetRegisterReq.Username)
 
 	targetTokens, err := tc.AuthClient.Register(*targetRegisterReq)
 	require.NoError(t, err, "Failed to register target user")
@@ -28,8 +27,8 @@ func setupGetFollowersTest(t *testing.T, tc *TestContext) (targetUserToken strin
 	var followerIDsList []int64
 
 	for i := 0; i < numFollowers; i++ {
-		followerRegisterReq := fixtures.GenerateRegisterRequest()
-		log.Info("Setting up get followers test - registering follower", "test", t.Name(), "follower_index", i, "username", followerRegisterReq.Username)
+		followerRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+		tc.Log.Info("Setting up get followers test - registering follower", "test", t.Name(), "follower_index", i, "username", followerRegisterReq.Username)
 
 		followerTokens, err := tc.AuthClient.Register(*followerRegisterReq)
 		require.NoError(t, err, "Failed to register follower user %d", i)
@@ -44,14 +43,14 @@ func setupGetFollowersTest(t *testing.T, tc *TestContext) (targetUserToken strin
 	}
 
 	return targetTokens.AccessToken, targetUser.ID, followerTokensList, followerIDsList, func() {
-		log.Info("Get followers test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Get followers test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestGetFollowersSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	targetUserToken, targetUserID, followerTokens, followerIDs, teardown := setupGetFollowersTest(t, tc)
@@ -90,12 +89,12 @@ func TestGetFollowersSuccess(t *testing.T) {
 		assert.True(t, returnedFollowerIDs[expectedFollowerID], "Expected follower %d should be in response", expectedFollowerID)
 	}
 
-	log.Info("Successfully retrieved followers", "target_user_id", targetUserID, "followers_count", len(followersResp.Followers))
+	tc.Log.Info("Successfully retrieved followers", "target_user_id", targetUserID, "followers_count", len(followersResp.Followers))
 }
 
 func TestGetFollowersEmptyList(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	targetUserToken, targetUserID, _, _, teardown := setupGetFollowersTest(t, tc)
@@ -113,12 +112,12 @@ func TestGetFollowersEmptyList(t *testing.T) {
 	assert.Equal(t, int64(0), followersResp.Total, "Total should be 0")
 	assert.Empty(t, followersResp.Followers, "Followers list should be empty")
 
-	log.Info("Successfully handled empty followers list", "target_user_id", targetUserID)
+	tc.Log.Info("Successfully handled empty followers list", "target_user_id", targetUserID)
 }
 
 func TestGetFollowersUserNotFound(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	targetUserToken, _, _, _, teardown := setupGetFollowersTest(t, tc)
@@ -133,12 +132,12 @@ func TestGetFollowersUserNotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrUserNotFound.Error(), "Error should be user not found")
 	assert.Nil(t, followersResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected get followers request for non-existent user", "user_id", nonExistentUserID)
+	tc.Log.Info("Correctly rejected get followers request for non-existent user", "user_id", nonExistentUserID)
 }
 
 func TestGetFollowersPagination(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	targetUserToken, targetUserID, followerTokens, followerIDs, teardown := setupGetFollowersTest(t, tc)
@@ -165,7 +164,7 @@ func TestGetFollowersPagination(t *testing.T) {
 	assert.Equal(t, int32(2), followersResp.Limit, "Limit should be 2")
 	assert.LessOrEqual(t, len(followersResp.Followers), 2, "Should return at most 2 followers")
 
-	log.Info("Successfully tested followers pagination",
+	tc.Log.Info("Successfully tested followers pagination",
 		"target_user_id", targetUserID,
 		"page", followersResp.Page,
 		"limit", followersResp.Limit,
@@ -175,7 +174,7 @@ func TestGetFollowersPagination(t *testing.T) {
 
 func TestGetFollowersValidationErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	targetUserToken, _, _, _, teardown := setupGetFollowersTest(t, tc)
@@ -217,7 +216,7 @@ func TestGetFollowersValidationErrors(t *testing.T) {
 			assert.Contains(t, err.Error(), testCase.expectedErr.Error(), "Error should match expected type for %s", testCase.description)
 			assert.Nil(t, followersResp, "Response should be nil on error for %s", testCase.description)
 
-			log.Info("Correctly rejected invalid parameters", "test_case", testCase.name)
+			tc.Log.Info("Correctly rejected invalid parameters", "test_case", testCase.name)
 		})
 	}
 }
@@ -0,0 +1,124 @@
+package gateway_relation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/latency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// feedPollInterval is the resolution of the delivery measurement.
+const feedPollInterval = 25 * time.Millisecond
+
+// waitForFeedNotification polls the feed of user until a notification of
+// notificationType shows up.
+func waitForFeedNotification(ctx context.Context, user *factory.User, notificationType string) error {
+	ticker := time.NewTicker(feedPollInterval)
+	defer ticker.Stop()
+
+	for {
+		feed, err := user.Notifications.GetUserNotificationFeed(user.ID, 1, 10)
+		if err != nil {
+			return fmt.Errorf("get notification feed of user %d: %w", user.ID, err)
+		}
+		for _, n := range feed.Notifications {
+			if n.Type == notificationType {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("no %s notification for user %d: %w", notificationType, user.ID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// TestOutboxFollowDeliveryLatency measures the time from a Follow call to the
+// follow_created notification appearing in the followee's feed. It is not
+// parallel so that the other tests of the package do not load the outbox
+// while it measures.
+func TestOutboxFollowDeliveryLatency(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Delivery latency measurement is skipped in -short mode")
+	}
+
+	tc := NewTestContext(t)
+	defer tc.Cleanup()
+
+	ctx := tc.APIClient.Context()
+	samples, parallel := cfg.Outbox.LatencySamples, cfg.Outbox.LatencyParallel
+	bound := cfg.Outbox.DeliveryBound(parallel)
+
+	users, err := tc.Factory.Users(ctx, 2*samples)
+	require.NoError(t, err, "Failed to create users for latency samples")
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		failures  []error
+		latencies = make([]time.Duration, 0, samples)
+		sem       = make(chan struct{}, parallel)
+	)
+	for i := 0; i < samples; i++ {
+		follower, followee := users[2*i], users[2*i+1]
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			start := time.Now()
+			err := tc.Factory.Follow(ctx, follower, followee)
+			if err == nil {
+				// Only the wait is bounded: the follow's cleanup must not
+				// inherit a context that is cancelled when this goroutine
+				// returns.
+				waitCtx, cancel := context.WithTimeout(ctx, 2*bound+cfg.API.Timeout)
+				err = waitForFeedNotification(waitCtx, followee, fixtures.NotificationTypeFollowCreated)
+				cancel()
+			}
+			took := time.Since(start)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures = append(failures, err)
+				return
+			}
+			latencies = append(latencies, took)
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < samples; i++ {
+		tc.DiscoverAndTrackAllNotifications(users[2*i+1].ID, users[2*i+1].AccessToken)
+	}
+
+	summary := latency.Summarize(latencies)
+	t.Logf("follow -> follow_created delivery: %s", summary)
+	t.Logf("bound %s from tick=%s batch=%d concurrency=%d slack=%dms with %d in flight (poll resolution %s)",
+		bound, cfg.Outbox.TickInterval(), cfg.Outbox.BatchSize, cfg.Outbox.Concurrency,
+		cfg.Outbox.DeliverySlackMs, parallel, feedPollInterval)
+	tc.Log.Info("Outbox delivery latency",
+		"samples", summary.Count,
+		"p50", summary.P50.String(),
+		"p99", summary.P99.String(),
+		"max", summary.Max.String(),
+		"bound", bound.String())
+
+	for _, err := range failures {
+		t.Errorf("Sample failed: %v", err)
+	}
+	assert.LessOrEqual(t, summary.Max, bound+feedPollInterval,
+		"Slowest delivery exceeds the bound derived from the outbox config")
+}
@@ -0,0 +1,93 @@
+package gateway_relation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// relationEventTimeout bounds how long an event may take to leave the
+// outbox: a few ticks plus Kafka latency.
+func relationEventTimeout() time.Duration {
+	return 3*outboxTickInterval + 5*time.Second
+}
+
+func subscribeRelationEvents(t *testing.T, tc *TestContext) *events.Consumer {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(tc.APIClient.Context(), 10*time.Second)
+	defer cancel()
+
+	consumer, err := events.SubscribeRelationEvents(ctx, cfg, tc.Log)
+	require.NoError(t, err, "Failed to subscribe to %s", cfg.Kafka.RelationEventsTopic)
+	t.Cleanup(func() { _ = consumer.Close() })
+	return consumer
+}
+
+func TestRelationEventsFollowUnfollow(t *testing.T) {
+	t.Parallel()
+	tc := NewTestContext(t)
+	defer tc.Cleanup()
+
+	consumer := subscribeRelationEvents(t, tc)
+
+	follower, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(t, err, "Failed to create follower user")
+	followee, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(t, err, "Failed to create followee user")
+
+	_, err = follower.Relations.Follow(followee.ID)
+	require.NoError(t, err, "Failed to follow user")
+	_, err = follower.Relations.Unfollow(followee.ID)
+	require.NoError(t, err, "Failed to unfollow user")
+
+	ctx, cancel := context.WithTimeout(context.Background(), relationEventTimeout())
+	defer cancel()
+
+	got, err := consumer.WaitFor(ctx, events.Between(follower.ID, followee.ID), 2)
+	require.NoError(t, err, "Relation events did not arrive")
+
+	assert.Equal(t, events.TypeFollowCreated, got[0].Type, "follow_created must come first")
+	assert.Equal(t, events.TypeFollowDeleted, got[1].Type, "follow_deleted must follow it")
+	for _, e := range got {
+		assert.Equal(t, follower.ID, e.FollowerID)
+		assert.Equal(t, followee.ID, e.FolloweeID)
+		assert.NotEmpty(t, e.Payload)
+	}
+
+	// Give a redelivery the same window the originals had before asserting
+	// exactly-once.
+	time.Sleep(outboxTickInterval + time.Second)
+	assert.Len(t, consumer.Events(events.Between(follower.ID, followee.ID)), 2, "Each relation event must be delivered exactly once")
+
+	tc.Log.Info("Verified relation events", "follower_id", follower.ID, "followee_id", followee.ID)
+}
+
+func TestRelationEventsPayloadShape(t *testing.T) {
+	t.Parallel()
+	tc := NewTestContext(t)
+	defer tc.Cleanup()
+
+	consumer := subscribeRelationEvents(t, tc)
+
+	follower, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(t, err, "Failed to create follower user")
+	followee, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(t, err, "Failed to create followee user")
+
+	require.NoError(t, tc.Factory.Follow(tc.APIClient.Context(), follower, followee))
+
+	ctx, cancel := context.WithTimeout(context.Background(), relationEventTimeout())
+	defer cancel()
+
+	got, err := consumer.WaitFor(ctx, events.Filter{Type: events.TypeFollowCreated, FollowerID: follower.ID, FolloweeID: followee.ID}, 1)
+	require.NoError(t, err, "follow_created did not arrive")
+
+	assert.Contains(t, string(got[0].Payload), "follower_id")
+	assert.Contains(t, string(got[0].Payload), "followee_id")
+	assert.Empty(t, consumer.Malformed(follower.ID, followee.ID), "Every record about this follow must decode as a relation event")
+}
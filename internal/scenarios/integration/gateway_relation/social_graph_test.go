@@ -0,0 +1,63 @@
+package gateway_relation
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/socialgraph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const socialGraphPageSize = 5
+
+func TestSocialGraphCounts(t *testing.T) {
+	t.Parallel()
+	tc := NewTestContext(t)
+	defer tc.Cleanup()
+
+	ctx := tc.APIClient.Context()
+
+	graph, err := socialgraph.Generate(socialgraph.DefaultConfig(tc.Fixtures.Seed()))
+	require.NoError(t, err)
+
+	tc.Log.Info("Materializing social graph", "test", t.Name(), "users", graph.Size(), "edges", len(graph.Edges()))
+
+	users, err := tc.Factory.Users(ctx, graph.Size())
+	require.NoError(t, err, "Failed to create graph users")
+
+	err = graph.Materialize(ctx, cfg.Test.Concurrent, socialgraph.FactoryFollow(tc.Factory, users))
+	require.NoError(t, err, "Failed to materialize social graph")
+
+	ids := func(nodes []int) []int64 {
+		out := make([]int64, 0, len(nodes))
+		for _, n := range nodes {
+			out = append(out, users[n].ID)
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+		return out
+	}
+
+	tc.APIClient.SetToken(users[0].AccessToken)
+
+	for n := 0; n < graph.Size(); n++ {
+		var followers []int64
+		for page := 1; ; page++ {
+			resp, err := tc.RelationClient.GetFollowers(users[n].ID, page, socialGraphPageSize)
+			require.NoError(t, err, "Failed to get followers of node %d page %d", n, page)
+			require.EqualValues(t, graph.FollowerCount(n), resp.Total, "Follower total of node %d", n)
+			for _, u := range resp.Followers {
+				followers = append(followers, u.ID)
+			}
+			if len(resp.Followers) < socialGraphPageSize {
+				break
+			}
+		}
+		sort.Slice(followers, func(i, j int) bool { return followers[i] < followers[j] })
+		assert.Equal(t, ids(graph.Followers(n)), followers, "Followers of node %d", n)
+
+		resp, err := tc.RelationClient.GetFollowees(users[n].ID, 1, socialGraphPageSize)
+		require.NoError(t, err, "Failed to get followees of node %d", n)
+		assert.EqualValues(t, graph.FolloweeCount(n), resp.Total, "Followee total of node %d", n)
+	}
+}
@@ -1,7 +1,6 @@
 package gateway_relation
 
 import (
-	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
 	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -11,8 +10,8 @@ import (
 func setupUnfollowUserTest(t *testing.T, tc *TestContext) (followerToken string, followerID int64, followeeToken string, followeeID int64, teardown func()) {
 	t.Helper()
 
-	followerRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up unfollow user test - registering follower", "test", t.Name(), "username", followerRegisterReq.Username)
+	followerRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up unfollow user test - registering follower", "test", t.Name(), "username", followerRegisterReq.Username)
 
 	followerTokens, err := tc.AuthClient.Register(*followerRegisterReq)
 	require.NoError(t, err, "Failed to register follower user")
@@ -22,8 +21,8 @@ func setupUnfollowUserTest(t *testing.T, tc *TestContext) (followerToken string,
 
 	tc.TrackUserForCleanup(followerUser.ID, followerUser.Username, followerTokens.AccessToken)
 
-	followeeRegisterReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up unfollow user test - registering followee", "test", t.Name(), "username", followeeRegisterReq.Username)
+	followeeRegisterReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up unfollow user test - registering followee", "test", t.Name(), "username", followeeRegisterReq.Username)
 
 	followeeTokens, err := tc.AuthClient.Register(*followeeRegisterReq)
 	require.NoError(t, err, "Failed to register followee user")
@@ -34,7 +33,7 @@ func setupUnfollowUserTest(t *testing.T, tc *TestContext) (followerToken string,
 	tc.TrackUserForCleanup(followeeUser.ID, followeeUser.Username, followeeTokens.AccessToken)
 
 	return followerTokens.AccessToken, followerUser.ID, followeeTokens.AccessToken, followeeUser.ID, func() {
-		log.Info("Unfollow user test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Unfollow user test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
@@ -60,7 +59,7 @@ func setupUnfollowUserTestWithExistingRelation(t *testing.T, tc *TestContext) (f
 
 func TestUnfollowUserSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	followerToken, followerID, _, followeeID, teardown := setupUnfollowUserTestWithExistingRelation(t, tc)
@@ -74,12 +73,12 @@ func TestUnfollowUserSuccess(t *testing.T) {
 	require.NotNil(t, unfollowResp, "Response should not be nil")
 	assert.NotEmpty(t, unfollowResp.Message, "Response should have a message")
 
-	log.Info("Successfully unfollowed user", "follower_id", followerID, "followee_id", followeeID)
+	tc.Log.Info("Successfully unfollowed user", "follower_id", followerID, "followee_id", followeeID)
 }
 
 func TestUnfollowUserUnauthorized(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, _, followeeID, teardown := setupUnfollowUserTest(t, tc)
@@ -93,12 +92,12 @@ func TestUnfollowUserUnauthorized(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrUnauthenticated.Error(), "Error should be unauthenticated")
 	assert.Nil(t, unfollowResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected unauthorized unfollow request", "followee_id", followeeID)
+	tc.Log.Info("Correctly rejected unauthorized unfollow request", "followee_id", followeeID)
 }
 
 func TestUnfollowUserInvalidToken(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, _, followeeID, teardown := setupUnfollowUserTest(t, tc)
@@ -112,12 +111,12 @@ func TestUnfollowUserInvalidToken(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrInvalidToken.Error(), "Error should be unauthenticated")
 	assert.Nil(t, unfollowResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected invalid token unfollow request", "followee_id", followeeID)
+	tc.Log.Info("Correctly rejected invalid token unfollow request", "followee_id", followeeID)
 }
 
 func TestUnfollowUserSelf(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	followerToken, followerID, _, _, teardown := setupUnfollowUserTest(t, tc)
@@ -131,12 +130,12 @@ func TestUnfollowUserSelf(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrSelfUnfollow.Error(), "Error should be self unfollow")
 	assert.Nil(t, unfollowResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected self-unfollow request", "user_id", followerID)
+	tc.Log.Info("Correctly rejected self-unfollow request", "user_id", followerID)
 }
 
 func TestUnfollowUserRelationNotFound(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	followerToken, followerID, _, followeeID, teardown := setupUnfollowUserTest(t, tc)
@@ -150,12 +149,12 @@ func TestUnfollowUserRelationNotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrFollowRelationNotFound.Error(), "Error should be follow relation not found")
 	assert.Nil(t, unfollowResp, "Response should be nil on error")
 
-	log.Info("Correctly rejected unfollow request for non-existent relation", "follower_id", followerID, "followee_id", followeeID)
+	tc.Log.Info("Correctly rejected unfollow request for non-existent relation", "follower_id", followerID, "followee_id", followeeID)
 }
 
 func TestUnfollowUserValidationErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	followerToken, _, _, _, teardown := setupUnfollowUserTest(t, tc)
@@ -191,14 +190,14 @@ func TestUnfollowUserValidationErrors(t *testing.T) {
 			assert.Contains(t, err.Error(), testCase.expectedErr.Error(), "Error should match expected type for %s", testCase.description)
 			assert.Nil(t, unfollowResp, "Response should be nil on error for %s", testCase.description)
 
-			log.Info("Correctly rejected invalid followee ID", "test_case", testCase.name, "followee_id", testCase.followeeID)
+			tc.Log.Info("Correctly rejected invalid followee ID", "test_case", testCase.name, "followee_id", testCase.followeeID)
 		})
 	}
 }
 
 func TestUnfollowUserDoubleUnfollow(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	followerToken, followerID, _, followeeID, teardown := setupUnfollowUserTestWithExistingRelation(t, tc)
@@ -216,5 +215,5 @@ func TestUnfollowUserDoubleUnfollow(t *testing.T) {
 	assert.Contains(t, err.Error(), custom_errors.ErrFollowRelationNotFound.Error(), "Error should be follow relation not found")
 	assert.Nil(t, unfollowResp2, "Second response should be nil on error")
 
-	log.Info("Correctly rejected duplicate unfollow request", "follower_id", followerID, "followee_id", followeeID)
+	tc.Log.Info("Correctly rejected duplicate unfollow request", "follower_id", followerID, "followee_id", followeeID)
 }
@@ -0,0 +1,81 @@
+package gateway_security
+
+import (
+	"context"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/histogram"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/Soloda1/pinstack-system-tests/internal/security"
+	"github.com/Soloda1/pinstack-system-tests/internal/tracing"
+)
+
+var (
+	cfg       *config.Config
+	log       *logger.Logger
+	catalogue *security.Catalogue
+)
+
+// TestContext holds what the security suites need. Every entity comes from
+// the factory, which deletes it when the test ends.
+type TestContext struct {
+	Fixtures  *fixtures.Generator
+	Factory   *factory.Factory
+	Log       *logger.Logger
+	APIClient *client.Client
+}
+
+func NewTestContext(t *testing.T) *TestContext {
+	testLog := logger.ForTest(t)
+	gen := fixtures.ForTest(t)
+	apiClient := client.NewClient(cfg, testLog)
+	apiClient.SetContext(tracing.StartTest(t))
+	return &TestContext{
+		Fixtures:  gen,
+		Factory:   factory.New(t, cfg, testLog, gen),
+		Log:       testLog,
+		APIClient: apiClient,
+	}
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	cfg = config.MustLoad("../../../../config")
+	log = logger.New(cfg.Env, cfg.Test.LogLevel)
+	logger.SetRedactedFields(cfg.Redaction.Fields...)
+	logger.SetTestLevel(cfg.Env, cfg.Test.LogLevel)
+
+	var err error
+	catalogue, err = security.Load(cfg.Security.PayloadsFile)
+	if err != nil {
+		log.Error("Failed to load payload catalogue", "error", err.Error())
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Error("Failed to set up tracing", "error", err.Error())
+		os.Exit(1)
+	}
+
+	log.Info("Starting security tests", "env", cfg.Env, "payloads", len(catalogue.Payloads))
+
+	saveLatencies := histogram.RecordClients(cfg.Perf, "gateway_security")
+	code := m.Run()
+	if err := saveLatencies(); err != nil {
+		log.Warn("Failed to save latency histograms", "error", err.Error())
+	}
+
+	if err := shutdownTracing(context.Background()); err != nil {
+		log.Warn("Failed to flush traces", "error", err.Error())
+	}
+
+	os.Exit(code)
+}
@@ -0,0 +1,179 @@
+package gateway_security
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/security"
+	"github.com/Soloda1/pinstack-system-tests/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkError asserts that err, if any, is a response from the gateway that is
+// not a 5xx and carries no internal error details.
+func checkError(t *testing.T, err error) {
+	t.Helper()
+	if err == nil {
+		return
+	}
+
+	var apiErr *client.APIError
+	require.True(t, errors.As(err, &apiErr), "request failed without a gateway response: %v", err)
+	assert.Less(t, apiErr.StatusCode, http.StatusInternalServerError, "server error: %v", apiErr)
+	// The body may echo credentials from the request, so it is printed
+	// through the redactor like every logged body.
+	assert.Empty(t, catalogue.Leaks(string(apiErr.Body)), "error response leaks internal details: %s",
+		log.Redactor().JSONString(string(apiErr.Body)))
+}
+
+// checkRejected asserts that the request was refused and the refusal is safe.
+func checkRejected(t *testing.T, err error) {
+	t.Helper()
+	require.Error(t, err, "malicious request was accepted")
+	checkError(t, err)
+}
+
+// postRaw sends base with field replaced by the raw JSON value.
+func postRaw(api *client.Client, path string, base interface{}, field string, value []byte, result interface{}) error {
+	body, err := security.Splice(base, field, value)
+	if err != nil {
+		return err
+	}
+	return api.Post(path, client.RawBody(body), result)
+}
+
+func TestSQLInjectionLogin(t *testing.T) {
+	tc := NewTestContext(t)
+	u, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(t, err, "Failed to create user")
+
+	for _, p := range catalogue.Of(security.SQL) {
+		t.Run(p.Name, func(t *testing.T) {
+			auth := client.NewAuthClient(NewTestContext(t).APIClient)
+
+			_, err := auth.Login(fixtures.LoginRequest{Login: u.Username, Password: p.Value})
+			checkRejected(t, err)
+
+			// The classic bypass comments out the password check after a
+			// known login.
+			_, err = auth.Login(fixtures.LoginRequest{Login: u.Username + p.Value, Password: u.Password})
+			checkRejected(t, err)
+		})
+	}
+}
+
+func TestSQLInjectionLookups(t *testing.T) {
+	tc := NewTestContext(t)
+	u, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(t, err, "Failed to create user")
+
+	for _, p := range catalogue.Of(security.SQL) {
+		t.Run(p.Name, func(t *testing.T) {
+			u.API.SetContext(tracing.StartTest(t))
+
+			_, err := u.Users.GetUserByUsername(p.Value)
+			checkRejected(t, err)
+
+			_, err = u.Users.GetUserByEmail(p.Value)
+			checkRejected(t, err)
+
+			// A search may legitimately match nothing.
+			_, err = u.Users.SearchUsers(p.Value, 0, 0)
+			checkError(t, err)
+
+			var posts fixtures.ListPostsResponse
+			err = u.API.Get("/v1/posts/list", url.Values{"author_id": {p.Value}}, &posts)
+			checkRejected(t, err)
+		})
+	}
+}
+
+func TestNoSQLInjection(t *testing.T) {
+	tc := NewTestContext(t)
+	users, err := tc.Factory.Users(tc.APIClient.Context(), 2)
+	require.NoError(t, err, "Failed to create users")
+	attacker, victim := users[0], users[1]
+
+	for _, p := range catalogue.Of(security.NoSQL) {
+		t.Run(p.Name, func(t *testing.T) {
+			ptc := NewTestContext(t)
+			value := []byte(p.Value)
+
+			t.Run("login", func(t *testing.T) {
+				var resp fixtures.LoginResponse
+				err := postRaw(ptc.APIClient, "/v1/auth/login",
+					fixtures.LoginRequest{Login: victim.Username, Password: "wrong"}, "password", value, &resp)
+				checkRejected(t, err)
+
+				err = postRaw(ptc.APIClient, "/v1/auth/login",
+					fixtures.LoginRequest{Login: "nobody", Password: victim.Password}, "login", value, &resp)
+				checkRejected(t, err)
+			})
+
+			t.Run("register", func(t *testing.T) {
+				var resp fixtures.RegisterResponse
+				err := postRaw(ptc.APIClient, "/v1/auth/register",
+					ptc.Fixtures.NewRegister().Build(), "username", value, &resp)
+				checkRejected(t, err)
+			})
+
+			t.Run("update other user", func(t *testing.T) {
+				attacker.API.SetContext(tracing.StartTest(t))
+				marker := "nosql-" + ptc.Fixtures.RunID()
+
+				body, err := security.Splice(fixtures.UpdateUserRequest{Bio: marker}, "id", value)
+				require.NoError(t, err)
+				var resp fixtures.UpdateUserResponse
+				err = attacker.API.Put("/v1/users", client.RawBody(body), &resp)
+				checkRejected(t, err)
+
+				victim.API.SetContext(tracing.StartTest(t))
+				profile, err := victim.Users.GetUserByID(victim.ID)
+				require.NoError(t, err, "Failed to get victim")
+				assert.NotEqual(t, marker, profile.Bio, "the operator matched another user")
+			})
+		})
+	}
+}
+
+// TestPathTraversalInUserLookups puts each traversal payload unescaped after
+// the username and email lookup routes. The gateway must answer 400 or 404 and
+// never resolve the path to a user.
+func TestPathTraversalInUserLookups(t *testing.T) {
+	tc := NewTestContext(t)
+	u, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(t, err, "Failed to create user")
+
+	routes := []string{"/v1/users/username/", "/v1/users/email/"}
+
+	for _, p := range catalogue.Of(security.Traversal) {
+		t.Run(p.Name, func(t *testing.T) {
+			if _, err := url.Parse(p.Value); err != nil {
+				t.Skipf("%q cannot be sent unescaped: %v", p.Value, err)
+			}
+
+			u.API.SetContext(tracing.StartTest(t))
+			for _, route := range routes {
+				var found fixtures.User
+				err := u.API.Get(route+p.Value, nil, &found)
+				if err == nil {
+					if found.ID != 0 && found.ID != u.ID {
+						assert.Failf(t, "path segment escaped its route",
+							"%s%s returned another user's data: user %d (%s)", route, p.Value, found.ID, found.Username)
+					} else {
+						assert.Failf(t, "path segment escaped its route", "%s%s was answered with a success", route, p.Value)
+					}
+					continue
+				}
+				checkError(t, err)
+				assert.Contains(t, []int{http.StatusBadRequest, http.StatusNotFound}, client.StatusCode(err),
+					"%s%s: %v", route, p.Value, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package gateway_security
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/security"
+	"github.com/Soloda1/pinstack-system-tests/internal/tracing"
+	"github.com/stretchr/testify/require"
+)
+
+// requireServing fails the test if the gateway no longer answers ordinary
+// requests after the malicious ones.
+func requireServing(t *testing.T, u *factory.User) {
+	t.Helper()
+	u.API.SetContext(tracing.StartTest(t))
+	_, err := u.Users.GetUserByID(u.ID)
+	require.NoError(t, err, "gateway stopped serving ordinary requests")
+}
+
+func TestOversizedBodies(t *testing.T) {
+	tc := NewTestContext(t)
+	ctx := tc.APIClient.Context()
+	u, err := tc.Factory.User(ctx)
+	require.NoError(t, err, "Failed to create user")
+
+	big := strings.Repeat("A", cfg.Security.OversizedBytes)
+
+	t.Run("post content", func(t *testing.T) {
+		_, err := tc.Factory.PostFrom(ctx, u, tc.Fixtures.NewPost().WithContent(big))
+		checkRejected(t, err)
+	})
+
+	t.Run("register bio", func(t *testing.T) {
+		_, err := tc.Factory.UserFrom(ctx, tc.Fixtures.NewRegister().WithBio(big))
+		checkRejected(t, err)
+	})
+
+	requireServing(t, u)
+}
+
+func TestDeeplyNestedJSON(t *testing.T) {
+	tc := NewTestContext(t)
+	ctx := tc.APIClient.Context()
+	users, err := tc.Factory.Users(ctx, 2)
+	require.NoError(t, err, "Failed to create users")
+	u, recipient := users[0], users[1]
+
+	nested := security.Nested(cfg.Security.NestingDepth)
+
+	t.Run("register", func(t *testing.T) {
+		var resp fixtures.RegisterResponse
+		err := postRaw(NewTestContext(t).APIClient, "/v1/auth/register",
+			tc.Fixtures.NewRegister().Build(), "username", nested, &resp)
+		checkRejected(t, err)
+	})
+
+	t.Run("create post", func(t *testing.T) {
+		u.API.SetContext(tracing.StartTest(t))
+		var resp fixtures.CreatePostResponse
+		err := postRaw(u.API, "/v1/posts", tc.Fixtures.NewPost().Build(), "tags", nested, &resp)
+		if err == nil {
+			t.Cleanup(func() { _ = u.Posts.DeletePost(resp.ID) })
+		}
+		checkRejected(t, err)
+	})
+
+	// The payload is free-form JSON, so storing a deep one is not wrong in
+	// itself; the gateway only has to survive parsing it.
+	t.Run("notification payload", func(t *testing.T) {
+		u.API.SetContext(tracing.StartTest(t))
+		var resp fixtures.SendNotificationResponse
+		err := postRaw(u.API, "/v1/notification/send",
+			tc.Fixtures.NewNotification(recipient.ID).Build(), "payload", nested, &resp)
+		checkError(t, err)
+		if err == nil {
+			t.Cleanup(func() {
+				recipient.API.SetContext(ctx)
+				if _, err := recipient.Notifications.RemoveNotification(resp.NotificationID); err != nil {
+					tc.Log.Warn("Failed to delete notification during cleanup",
+						slog.Int64("notification_id", resp.NotificationID), slog.String("error", err.Error()))
+				}
+			})
+		}
+	})
+
+	requireServing(t, u)
+}
@@ -0,0 +1,68 @@
+package gateway_security
+
+import (
+	"testing"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/security"
+	"github.com/Soloda1/pinstack-system-tests/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkStored asserts that a stored payload came back escaped or fully
+// sanitized. Markup stored unchanged passes only with security.allow_verbatim.
+func checkStored(t *testing.T, field, sent, got string) {
+	t.Helper()
+	stored := security.CheckStored(sent, got)
+	switch stored {
+	case security.StoredEscaped, security.StoredSanitized:
+	case security.StoredVerbatim:
+		assert.True(t, cfg.Security.AllowVerbatim, "%s stored markup unchanged: %q", field, got)
+	default:
+		assert.Fail(t, "markup left after sanitizing", "%s came back as %q", field, got)
+	}
+	t.Logf("%s stored %s", field, stored)
+}
+
+// TestStoredXSS stores every XSS payload in post content and bio and reads it
+// back. Refusing the payload outright is fine too.
+func TestStoredXSS(t *testing.T) {
+	tc := NewTestContext(t)
+	ctx := tc.APIClient.Context()
+	u, err := tc.Factory.User(ctx)
+	require.NoError(t, err, "Failed to create user")
+
+	for _, p := range catalogue.Of(security.XSS) {
+		t.Run(p.Name, func(t *testing.T) {
+			t.Run("post", func(t *testing.T) {
+				created, err := tc.Factory.PostFrom(ctx, u, tc.Fixtures.NewPost().WithTitle(p.Value).WithContent(p.Value))
+				if err != nil {
+					checkError(t, err)
+					t.Logf("post refused: %v", err)
+					return
+				}
+
+				u.API.SetContext(tracing.StartTest(t))
+				post, err := u.Posts.GetPostByID(created.ID)
+				require.NoError(t, err, "Failed to get post")
+				checkStored(t, "title", p.Value, post.Title)
+				checkStored(t, "content", p.Value, post.Content)
+			})
+
+			t.Run("bio", func(t *testing.T) {
+				u.API.SetContext(tracing.StartTest(t))
+				_, err := u.Users.UpdateUser(fixtures.UpdateUserRequest{ID: u.ID, Bio: p.Value})
+				if err != nil {
+					checkError(t, err)
+					t.Logf("bio refused: %v", err)
+					return
+				}
+
+				profile, err := u.Users.GetUserByID(u.ID)
+				require.NoError(t, err, "Failed to get user")
+				checkStored(t, "bio", p.Value, profile.Bio)
+			})
+		})
+	}
+}
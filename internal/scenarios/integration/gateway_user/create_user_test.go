@@ -12,28 +12,28 @@ import (
 func setupCreateUserTest(t *testing.T, tc *TestContext) (string, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up create user test", "test", t.Name(), "username", registerReq.Username)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up create user test", "test", t.Name(), "username", registerReq.Username)
 
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register test user")
 
 	userByUsername, err := tc.UserClient.GetUserByUsername(registerReq.Username)
 	if err != nil {
-		log.Warn("Failed to get user info for cleanup tracking", "username", registerReq.Username, "error", err.Error())
+		tc.Log.Warn("Failed to get user info for cleanup tracking", "username", registerReq.Username, "error", err.Error())
 	} else {
 		tc.TrackUserForCleanup(userByUsername.ID, userByUsername.Username, tokens.AccessToken)
 	}
 
 	return tokens.AccessToken, func() {
-		log.Info("Create user test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Create user test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestCreateUserSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, teardown := setupCreateUserTest(t, tc)
@@ -41,7 +41,7 @@ func TestCreateUserSuccess(t *testing.T) {
 
 	tc.APIClient.SetToken(accessToken)
 
-	createReq := fixtures.GenerateCreateUserRequest()
+	createReq := tc.Fixtures.GenerateCreateUserRequest()
 
 	createdUser, err := tc.UserClient.CreateUser(*createReq)
 	require.NoError(t, err)
@@ -60,11 +60,11 @@ func TestCreateUserSuccess(t *testing.T) {
 
 func TestCreateUserUnauthorized(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	t.Run("NoToken", func(t *testing.T) {
-		createReq := fixtures.GenerateCreateUserRequest()
+		createReq := tc.Fixtures.GenerateCreateUserRequest()
 
 		_, err := tc.UserClient.CreateUser(*createReq)
 		require.Error(t, err)
@@ -74,7 +74,7 @@ func TestCreateUserUnauthorized(t *testing.T) {
 	t.Run("InvalidToken", func(t *testing.T) {
 		tc.APIClient.SetToken("invalid_token")
 
-		createReq := fixtures.GenerateCreateUserRequest()
+		createReq := tc.Fixtures.GenerateCreateUserRequest()
 
 		_, err := tc.UserClient.CreateUser(*createReq)
 		require.Error(t, err)
@@ -84,7 +84,7 @@ func TestCreateUserUnauthorized(t *testing.T) {
 
 func TestCreateUserValidationErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, teardown := setupCreateUserTest(t, tc)
@@ -153,7 +153,7 @@ func TestCreateUserValidationErrors(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			ctx := NewTestContext()
+			ctx := NewTestContext(t)
 			defer ctx.Cleanup()
 
 			accessToken, teardown := setupCreateUserTest(t, ctx)
@@ -161,7 +161,7 @@ func TestCreateUserValidationErrors(t *testing.T) {
 
 			ctx.APIClient.SetToken(accessToken)
 
-			createReq := fixtures.GenerateCreateUserRequest()
+			createReq := ctx.Fixtures.GenerateCreateUserRequest()
 			tc.modifyReq(createReq)
 
 			_, err := ctx.UserClient.CreateUser(*createReq)
@@ -173,7 +173,7 @@ func TestCreateUserValidationErrors(t *testing.T) {
 
 func TestCreateUserConflictErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, teardown := setupCreateUserTest(t, tc)
@@ -181,14 +181,14 @@ func TestCreateUserConflictErrors(t *testing.T) {
 
 	tc.APIClient.SetToken(accessToken)
 
-	createReq := fixtures.GenerateCreateUserRequest()
+	createReq := tc.Fixtures.GenerateCreateUserRequest()
 
 	createdUser, err := tc.UserClient.CreateUser(*createReq)
 	require.NoError(t, err)
 	tc.TrackUserForCleanup(createdUser.ID, createdUser.Username, accessToken)
 
 	t.Run("DuplicateUsername", func(t *testing.T) {
-		duplicateReq := fixtures.GenerateCreateUserRequest()
+		duplicateReq := tc.Fixtures.GenerateCreateUserRequest()
 		duplicateReq.Username = createReq.Username
 
 		_, err := tc.UserClient.CreateUser(*duplicateReq)
@@ -197,7 +197,7 @@ func TestCreateUserConflictErrors(t *testing.T) {
 	})
 
 	t.Run("DuplicateEmail", func(t *testing.T) {
-		duplicateReq := fixtures.GenerateCreateUserRequest()
+		duplicateReq := tc.Fixtures.GenerateCreateUserRequest()
 		duplicateReq.Email = createReq.Email
 
 		_, err := tc.UserClient.CreateUser(*duplicateReq)
@@ -4,7 +4,6 @@ import (
 	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
 	"testing"
 
-	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -12,8 +11,8 @@ import (
 func setupDeleteUserTest(t *testing.T, tc *TestContext) (string, int64, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up delete user test", "test", t.Name(), "username", registerReq.Username)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up delete user test", "test", t.Name(), "username", registerReq.Username)
 
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register test user")
@@ -24,14 +23,14 @@ func setupDeleteUserTest(t *testing.T, tc *TestContext) (string, int64, func())
 	tc.TrackUserForCleanup(userByUsername.ID, userByUsername.Username, tokens.AccessToken)
 
 	return tokens.AccessToken, userByUsername.ID, func() {
-		log.Info("Delete user test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Delete user test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestDeleteUserSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, userID, teardown := setupDeleteUserTest(t, tc)
@@ -49,7 +48,7 @@ func TestDeleteUserSuccess(t *testing.T) {
 
 func TestDeleteUserUnauthorized(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, userID, teardown := setupDeleteUserTest(t, tc)
@@ -73,7 +72,7 @@ func TestDeleteUserUnauthorized(t *testing.T) {
 
 func TestDeleteUserValidationErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, _, teardown := setupDeleteUserTest(t, tc)
@@ -103,7 +102,7 @@ func TestDeleteUserValidationErrors(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			ctx := NewTestContext()
+			ctx := NewTestContext(t)
 			defer ctx.Cleanup()
 
 			accessToken, _, teardown := setupDeleteUserTest(t, ctx)
@@ -120,7 +119,7 @@ func TestDeleteUserValidationErrors(t *testing.T) {
 
 func TestDeleteUserPermissionErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken1, userID1, teardown1 := setupDeleteUserTest(t, tc)
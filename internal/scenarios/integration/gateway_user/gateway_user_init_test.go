@@ -1,6 +1,7 @@
 package gateway_user
 
 import (
+	"context"
 	"flag"
 	"os"
 	"sync"
@@ -8,7 +9,11 @@ import (
 
 	"github.com/Soloda1/pinstack-system-tests/config"
 	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/histogram"
 	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/Soloda1/pinstack-system-tests/internal/tracing"
 )
 
 var (
@@ -23,6 +28,9 @@ type UserCleanupInfo struct {
 }
 
 type TestContext struct {
+	Fixtures     *fixtures.Generator
+	Factory      *factory.Factory
+	Log          *logger.Logger
 	APIClient    *client.Client
 	AuthClient   *client.AuthClient
 	UserClient   *client.UserClient
@@ -30,9 +38,15 @@ type TestContext struct {
 	mu           sync.Mutex
 }
 
-func NewTestContext() *TestContext {
-	apiClient := client.NewClient(cfg, log)
+func NewTestContext(t *testing.T) *TestContext {
+	testLog := logger.ForTest(t)
+	gen := fixtures.ForTest(t)
+	apiClient := client.NewClient(cfg, testLog)
+	apiClient.SetContext(tracing.StartTest(t))
 	return &TestContext{
+		Fixtures:     gen,
+		Factory:      factory.New(t, cfg, testLog, gen),
+		Log:          testLog,
 		APIClient:    apiClient,
 		AuthClient:   client.NewAuthClient(apiClient),
 		UserClient:   client.NewUserClient(apiClient),
@@ -50,7 +64,7 @@ func (tc *TestContext) TrackUserForCleanup(userID int64, username, accessToken s
 		AccessToken: accessToken,
 	}
 	tc.CreatedUsers = append(tc.CreatedUsers, userInfo)
-	log.Debug("Added user to cleanup list", "user_id", userID, "username", username)
+	tc.Log.Debug("Added user to cleanup list", "user_id", userID, "username", username)
 }
 
 func (tc *TestContext) Cleanup() {
@@ -61,30 +75,30 @@ func (tc *TestContext) Cleanup() {
 		return
 	}
 
-	log.Info("Starting cleanup process", "users_to_delete", len(tc.CreatedUsers))
+	tc.Log.Info("Starting cleanup process", "users_to_delete", len(tc.CreatedUsers))
 
 	var successfulUserDeletions int
 
 	for _, userInfo := range tc.CreatedUsers {
-		log.Debug("Attempting to delete user", "user_id", userInfo.ID, "username", userInfo.Username)
+		tc.Log.Debug("Attempting to delete user", "user_id", userInfo.ID, "username", userInfo.Username)
 
 		tc.APIClient.SetToken(userInfo.AccessToken)
 
 		err := tc.UserClient.DeleteUser(userInfo.ID)
 		if err != nil {
-			log.Warn("Failed to delete user during cleanup",
+			tc.Log.Warn("Failed to delete user during cleanup",
 				"user_id", userInfo.ID,
 				"username", userInfo.Username,
 				"error", err.Error())
 		} else {
-			log.Debug("Successfully deleted user", "user_id", userInfo.ID, "username", userInfo.Username)
+			tc.Log.Debug("Successfully deleted user", "user_id", userInfo.ID, "username", userInfo.Username)
 			successfulUserDeletions++
 		}
 	}
 
 	tc.APIClient.SetToken("")
 
-	log.Info("Cleanup process completed",
+	tc.Log.Info("Cleanup process completed",
 		"successful_user_deletions", successfulUserDeletions,
 		"total_users", len(tc.CreatedUsers))
 
@@ -95,9 +109,27 @@ func TestMain(m *testing.M) {
 	flag.Parse()
 
 	cfg = config.MustLoad("../../../../config")
-	log = logger.New(cfg.Env)
+	log = logger.New(cfg.Env, cfg.Test.LogLevel)
+	logger.SetRedactedFields(cfg.Redaction.Fields...)
+	logger.SetTestLevel(cfg.Env, cfg.Test.LogLevel)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Error("Failed to set up tracing", "error", err.Error())
+		os.Exit(1)
+	}
+
 	log.Info("Starting user gateway tests", "env", cfg.Env)
 
+	saveLatencies := histogram.RecordClients(cfg.Perf, "gateway_user")
 	code := m.Run()
+	if err := saveLatencies(); err != nil {
+		log.Warn("Failed to save latency histograms", "error", err.Error())
+	}
+
+	if err := shutdownTracing(context.Background()); err != nil {
+		log.Warn("Failed to flush traces", "error", err.Error())
+	}
+
 	os.Exit(code)
 }
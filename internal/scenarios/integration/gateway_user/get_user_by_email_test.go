@@ -4,7 +4,6 @@ import (
 	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
 	"testing"
 
-	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -12,8 +11,8 @@ import (
 func setupGetUserByEmailTest(t *testing.T, tc *TestContext) (string, string, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up get user by email test", "test", t.Name(), "username", registerReq.Username, "email", registerReq.Email)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up get user by email test", "test", t.Name(), "username", registerReq.Username, "email", registerReq.Email)
 
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register test user")
@@ -24,13 +23,13 @@ func setupGetUserByEmailTest(t *testing.T, tc *TestContext) (string, string, fun
 	tc.TrackUserForCleanup(userByUsername.ID, userByUsername.Username, tokens.AccessToken)
 
 	return tokens.AccessToken, registerReq.Email, func() {
-		log.Info("Get user by email test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Get user by email test complete, local cleanup", "test", t.Name())
 	}
 }
 
 func TestGetUserByEmailSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, userEmail, teardown := setupGetUserByEmailTest(t, tc)
@@ -47,7 +46,7 @@ func TestGetUserByEmailSuccess(t *testing.T) {
 
 func TestGetUserByEmailNotFound(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, teardown := setupGetUserByEmailTest(t, tc)
@@ -61,7 +60,7 @@ func TestGetUserByEmailNotFound(t *testing.T) {
 
 func TestGetUserByEmailValidationErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, teardown := setupGetUserByEmailTest(t, tc)
@@ -89,7 +88,7 @@ func TestGetUserByEmailValidationErrors(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			ctx := NewTestContext()
+			ctx := NewTestContext(t)
 			defer ctx.Cleanup()
 
 			_, _, teardown := setupGetUserByEmailTest(t, ctx)
@@ -4,7 +4,6 @@ import (
 	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
 	"testing"
 
-	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -12,8 +11,8 @@ import (
 func setupGetUserByIDTest(t *testing.T, tc *TestContext) (string, int64, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up get user by ID test", "test", t.Name(), "username", registerReq.Username)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up get user by ID test", "test", t.Name(), "username", registerReq.Username)
 
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register test user")
@@ -24,14 +23,14 @@ func setupGetUserByIDTest(t *testing.T, tc *TestContext) (string, int64, func())
 	tc.TrackUserForCleanup(userByUsername.ID, userByUsername.Username, tokens.AccessToken)
 
 	return tokens.AccessToken, userByUsername.ID, func() {
-		log.Info("Get user by ID test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Get user by ID test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestGetUserByIDSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, userID, teardown := setupGetUserByIDTest(t, tc)
@@ -50,7 +49,7 @@ func TestGetUserByIDSuccess(t *testing.T) {
 
 func TestGetUserByIDNotFound(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, _, teardown := setupGetUserByIDTest(t, tc)
@@ -66,7 +65,7 @@ func TestGetUserByIDNotFound(t *testing.T) {
 
 func TestGetUserByIDValidationErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, _, teardown := setupGetUserByIDTest(t, tc)
@@ -96,7 +95,7 @@ func TestGetUserByIDValidationErrors(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			ctx := NewTestContext()
+			ctx := NewTestContext(t)
 			defer ctx.Cleanup()
 
 			accessToken, _, teardown := setupGetUserByIDTest(t, ctx)
@@ -15,12 +15,12 @@ func setupSearchUsersTest(t *testing.T, tc *TestContext) (string, []fixtures.Use
 	var users []fixtures.User
 	userCount := 3
 
-	log.Info("Setting up search users test", "test", t.Name(), "user_count", userCount)
+	tc.Log.Info("Setting up search users test", "test", t.Name(), "user_count", userCount)
 
 	searchPrefix := "searchtest"
 
 	for i := 0; i < userCount; i++ {
-		registerReq := fixtures.GenerateRegisterRequest()
+		registerReq := tc.Fixtures.GenerateRegisterRequest()
 		registerReq.Username = searchPrefix + registerReq.Username
 
 		tokens, err := tc.AuthClient.Register(*registerReq)
@@ -33,7 +33,7 @@ func setupSearchUsersTest(t *testing.T, tc *TestContext) (string, []fixtures.Use
 		users = append(users, *user)
 	}
 
-	registerReq := fixtures.GenerateRegisterRequest()
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register auth user")
 
@@ -43,13 +43,13 @@ func setupSearchUsersTest(t *testing.T, tc *TestContext) (string, []fixtures.Use
 	tc.TrackUserForCleanup(authUser.ID, authUser.Username, tokens.AccessToken)
 
 	return tokens.AccessToken, users, func() {
-		log.Info("Search users test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Search users test complete, local cleanup", "test", t.Name())
 	}
 }
 
 func TestSearchUsersSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, testUsers, teardown := setupSearchUsersTest(t, tc)
@@ -96,7 +96,7 @@ func TestSearchUsersSuccess(t *testing.T) {
 
 func TestSearchUsersNoResults(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, _, teardown := setupSearchUsersTest(t, tc)
@@ -115,7 +115,7 @@ func TestSearchUsersNoResults(t *testing.T) {
 
 func TestSearchUsersValidationErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, _, teardown := setupSearchUsersTest(t, tc)
@@ -144,7 +144,7 @@ func TestSearchUsersValidationErrors(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			ctx := NewTestContext()
+			ctx := NewTestContext(t)
 			defer ctx.Cleanup()
 
 			accessToken, _, teardown := setupSearchUsersTest(t, ctx)
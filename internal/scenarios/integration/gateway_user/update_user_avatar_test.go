@@ -12,8 +12,8 @@ import (
 func setupUpdateAvatarTest(t *testing.T, tc *TestContext) (string, int64, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up update avatar test", "test", t.Name(), "username", registerReq.Username)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up update avatar test", "test", t.Name(), "username", registerReq.Username)
 
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register test user")
@@ -24,14 +24,14 @@ func setupUpdateAvatarTest(t *testing.T, tc *TestContext) (string, int64, func()
 	tc.TrackUserForCleanup(userByUsername.ID, userByUsername.Username, tokens.AccessToken)
 
 	return tokens.AccessToken, userByUsername.ID, func() {
-		log.Info("Update avatar test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Update avatar test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestUpdateAvatarSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, userID, teardown := setupUpdateAvatarTest(t, tc)
@@ -39,7 +39,7 @@ func TestUpdateAvatarSuccess(t *testing.T) {
 
 	tc.APIClient.SetToken(accessToken)
 
-	avatarReq := fixtures.GenerateUpdateAvatarRequest()
+	avatarReq := tc.Fixtures.GenerateUpdateAvatarRequest()
 
 	err := tc.UserClient.UpdateAvatar(*avatarReq)
 	require.NoError(t, err)
@@ -51,13 +51,13 @@ func TestUpdateAvatarSuccess(t *testing.T) {
 
 func TestUpdateAvatarUnauthorized(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, _, teardown := setupUpdateAvatarTest(t, tc)
 	defer teardown()
 
-	avatarReq := fixtures.GenerateUpdateAvatarRequest()
+	avatarReq := tc.Fixtures.GenerateUpdateAvatarRequest()
 
 	t.Run("NoToken", func(t *testing.T) {
 		tc.APIClient.SetToken("")
@@ -78,7 +78,7 @@ func TestUpdateAvatarUnauthorized(t *testing.T) {
 
 func TestUpdateAvatarValidationErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, _, teardown := setupUpdateAvatarTest(t, tc)
@@ -108,7 +108,7 @@ func TestUpdateAvatarValidationErrors(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			ctx := NewTestContext()
+			ctx := NewTestContext(t)
 			defer ctx.Cleanup()
 
 			accessToken, _, teardown := setupUpdateAvatarTest(t, ctx)
@@ -129,7 +129,7 @@ func TestUpdateAvatarValidationErrors(t *testing.T) {
 
 func TestUpdateSelfUserAvatar(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken1, userID1, teardown1 := setupUpdateAvatarTest(t, tc)
@@ -141,7 +141,7 @@ func TestUpdateSelfUserAvatar(t *testing.T) {
 	t.Run("UpdateSelfUserAvatar", func(t *testing.T) {
 		tc.APIClient.SetToken(accessToken1)
 
-		avatarReq := fixtures.GenerateUpdateAvatarRequest()
+		avatarReq := tc.Fixtures.GenerateUpdateAvatarRequest()
 		err := tc.UserClient.UpdateAvatar(*avatarReq)
 		require.NoError(t, err)
 
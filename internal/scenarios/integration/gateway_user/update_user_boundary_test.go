@@ -0,0 +1,61 @@
+package gateway_user
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateUserBoundaryValues(t *testing.T) {
+	t.Parallel()
+	tc := NewTestContext(t)
+
+	cases := tc.Fixtures.BoundaryCases(tc.Fixtures.NewUserUpdate(1).Build())
+
+	for _, bc := range cases {
+		bc := bc
+		// The ID selects whose profile is updated; other IDs are covered by
+		// the authorization tests.
+		if bc.Field.Field == "ID" {
+			continue
+		}
+
+		t.Run(bc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := NewTestContext(t)
+			defer ctx.Cleanup()
+
+			accessToken, userID, teardown := setupUpdateUserTest(t, ctx)
+			defer teardown()
+
+			ctx.APIClient.SetToken(accessToken)
+
+			// Each case updates its own user, with a username and email no
+			// other case uses.
+			var response fixtures.UpdateUserResponse
+			err := ctx.APIClient.Put("/v1/users", bc.Apply(ctx.Fixtures.NewUserUpdate(userID).Build()), &response)
+
+			if !bc.Valid {
+				require.Error(t, err, "expected %s=%v to be rejected", bc.Field.JSONName, bc.Value)
+				assert.Equal(t, http.StatusBadRequest, client.StatusCode(err))
+				return
+			}
+
+			// Values too short for the run marker may be taken already;
+			// the conflict means they passed validation.
+			if err != nil && strings.Contains(err.Error(), custom_errors.ErrUsernameExists.Error()) {
+				t.Logf("%s=%q is taken, so it was accepted", bc.Field.JSONName, bc.Value)
+				return
+			}
+			require.NoError(t, err, "expected %s=%v to be accepted", bc.Field.JSONName, bc.Value)
+			assert.Equal(t, userID, response.ID)
+		})
+	}
+}
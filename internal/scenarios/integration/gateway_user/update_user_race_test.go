@@ -0,0 +1,149 @@
+package gateway_user
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/race"
+	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentUpdateUserNotTorn(t *testing.T) {
+	t.Parallel()
+	tc := NewTestContext(t)
+	defer tc.Cleanup()
+
+	user, err := tc.Factory.User(tc.APIClient.Context())
+	require.NoError(t, err, "Failed to create user")
+
+	writers := cfg.Race.Writers
+	payloads := make([]*fixtures.UpdateUserRequest, writers)
+	for i := range payloads {
+		payloads[i] = tc.Fixtures.NewUserUpdate(user.ID).Build()
+	}
+
+	errs := race.Together(writers, func(i int) error {
+		_, err := user.Users.UpdateUser(*payloads[i])
+		return err
+	})
+
+	succeeded := make(map[int]bool)
+	for i, err := range errs {
+		if err == nil {
+			succeeded[i] = true
+			continue
+		}
+		code := client.StatusCode(err)
+		assert.True(t, code >= http.StatusBadRequest && code < http.StatusInternalServerError,
+			"Writer %d: a losing concurrent update must fail with a 4xx, got %v", i, err)
+	}
+	require.NotEmpty(t, succeeded, "At least one concurrent update must succeed")
+
+	final, err := user.Users.GetUserByID(user.ID)
+	require.NoError(t, err, "Failed to read user after concurrent updates")
+
+	winner := -1
+	for i, p := range payloads {
+		if p.Username == final.Username {
+			winner = i
+		}
+	}
+	require.NotEqual(t, -1, winner, "Final username %q matches none of the submitted payloads", final.Username)
+
+	want := payloads[winner]
+	assert.Equal(t, want.FullName, final.FullName, "Torn write: full name does not come from payload %d", winner)
+	assert.Equal(t, want.Bio, final.Bio, "Torn write: bio does not come from payload %d", winner)
+	if final.Email != "" {
+		assert.Equal(t, want.Email, final.Email, "Torn write: email does not come from payload %d", winner)
+	}
+	assert.True(t, succeeded[winner], "Final state comes from payload %d whose request failed", winner)
+
+	tc.Log.Info("Concurrent user updates settled on one payload",
+		"user_id", user.ID,
+		"writers", writers,
+		"succeeded", len(succeeded),
+		"winner", winner)
+}
+
+func TestConcurrentUpdateUserUniqueCollision(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		expectedErr error
+		request     func(tc *TestContext, id int64, value string) *fixtures.UpdateUserRequest
+		value       func(tc *TestContext) string
+		lookup      func(uc *client.UserClient, value string) (*fixtures.User, error)
+	}{
+		{
+			name:        "Username",
+			expectedErr: custom_errors.ErrUsernameExists,
+			request: func(tc *TestContext, id int64, value string) *fixtures.UpdateUserRequest {
+				return tc.Fixtures.NewUserUpdate(id).WithUsername(value).Build()
+			},
+			value: func(tc *TestContext) string {
+				return tc.Fixtures.NewRegister().Build().Username
+			},
+			lookup: func(uc *client.UserClient, value string) (*fixtures.User, error) {
+				return uc.GetUserByUsername(value)
+			},
+		},
+		{
+			name:        "Email",
+			expectedErr: custom_errors.ErrEmailExists,
+			request: func(tc *TestContext, id int64, value string) *fixtures.UpdateUserRequest {
+				return tc.Fixtures.NewUserUpdate(id).WithEmail(value).Build()
+			},
+			value: func(tc *TestContext) string {
+				return tc.Fixtures.NewRegister().Build().Email
+			},
+			lookup: func(uc *client.UserClient, value string) (*fixtures.User, error) {
+				return uc.GetUserByEmail(value)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			tc := NewTestContext(t)
+			defer tc.Cleanup()
+
+			users, err := tc.Factory.Users(tc.APIClient.Context(), cfg.Race.Writers)
+			require.NoError(t, err, "Failed to create racing users")
+
+			value := testCase.value(tc)
+			errs := race.Together(len(users), func(i int) error {
+				_, err := users[i].Users.UpdateUser(*testCase.request(tc, users[i].ID, value))
+				return err
+			})
+
+			var winners []int
+			for i, err := range errs {
+				if err == nil {
+					winners = append(winners, i)
+					continue
+				}
+				code := client.StatusCode(err)
+				assert.Less(t, code, http.StatusInternalServerError, "User %d: conflict surfaced as a server error: %v", users[i].ID, err)
+				assert.Contains(t, err.Error(), testCase.expectedErr.Error(), "User %d: conflict must be reported as such", users[i].ID)
+			}
+			require.Len(t, winners, 1, "Exactly one of the colliding updates must succeed")
+			winner := winners[0]
+
+			owner, err := testCase.lookup(users[winner].Users, value)
+			require.NoError(t, err, "Failed to look up the contested %s", testCase.name)
+			assert.Equal(t, users[winner].ID, owner.ID, "The contested %s must belong to the user whose update succeeded", testCase.name)
+
+			tc.Log.Info("Concurrent unique updates produced one winner",
+				"field", testCase.name,
+				"writers", len(users),
+				"winner_id", users[winner].ID)
+		})
+	}
+}
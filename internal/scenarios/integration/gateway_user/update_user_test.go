@@ -4,7 +4,6 @@ import (
 	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
 	"testing"
 
-	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -12,8 +11,8 @@ import (
 func setupUpdateUserTest(t *testing.T, tc *TestContext) (string, int64, func()) {
 	t.Helper()
 
-	registerReq := fixtures.GenerateRegisterRequest()
-	log.Info("Setting up update user test", "test", t.Name(), "username", registerReq.Username)
+	registerReq := tc.Fixtures.GenerateRegisterRequest()
+	tc.Log.Info("Setting up update user test", "test", t.Name(), "username", registerReq.Username)
 
 	tokens, err := tc.AuthClient.Register(*registerReq)
 	require.NoError(t, err, "Failed to register test user")
@@ -24,14 +23,14 @@ func setupUpdateUserTest(t *testing.T, tc *TestContext) (string, int64, func())
 	tc.TrackUserForCleanup(userByUsername.ID, userByUsername.Username, tokens.AccessToken)
 
 	return tokens.AccessToken, userByUsername.ID, func() {
-		log.Info("Update user test complete, local cleanup", "test", t.Name())
+		tc.Log.Info("Update user test complete, local cleanup", "test", t.Name())
 		tc.APIClient.SetToken("")
 	}
 }
 
 func TestUpdateUserSuccess(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, userID, teardown := setupUpdateUserTest(t, tc)
@@ -39,7 +38,7 @@ func TestUpdateUserSuccess(t *testing.T) {
 
 	tc.APIClient.SetToken(accessToken)
 
-	updateReq := fixtures.GenerateUpdateUserRequest(userID, "", "", "", "")
+	updateReq := tc.Fixtures.GenerateUpdateUserRequest(userID, "", "", "", "")
 
 	response, err := tc.UserClient.UpdateUser(*updateReq)
 	require.NoError(t, err)
@@ -61,13 +60,13 @@ func TestUpdateUserSuccess(t *testing.T) {
 
 func TestUpdateUserUnauthorized(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	_, userID, teardown := setupUpdateUserTest(t, tc)
 	defer teardown()
 
-	updateReq := fixtures.GenerateUpdateUserRequest(userID, "new_username", "", "", "")
+	updateReq := tc.Fixtures.GenerateUpdateUserRequest(userID, "new_username", "", "", "")
 
 	t.Run("NoToken", func(t *testing.T) {
 		tc.APIClient.SetToken("")
@@ -88,7 +87,7 @@ func TestUpdateUserUnauthorized(t *testing.T) {
 
 func TestUpdateUserValidationErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken, userID, teardown := setupUpdateUserTest(t, tc)
@@ -130,7 +129,7 @@ func TestUpdateUserValidationErrors(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			ctx := NewTestContext()
+			ctx := NewTestContext(t)
 			defer ctx.Cleanup()
 
 			accessToken, _, teardown := setupUpdateUserTest(t, ctx)
@@ -138,7 +137,7 @@ func TestUpdateUserValidationErrors(t *testing.T) {
 
 			ctx.APIClient.SetToken(accessToken)
 
-			updateReq := fixtures.GenerateUpdateUserRequest(tc.id, tc.username, tc.email, tc.fullName, tc.bio)
+			updateReq := ctx.Fixtures.GenerateUpdateUserRequest(tc.id, tc.username, tc.email, tc.fullName, tc.bio)
 			_, err := ctx.UserClient.UpdateUser(*updateReq)
 			require.Error(t, err)
 			assert.Contains(t, err.Error(), tc.expectedErr)
@@ -148,13 +147,13 @@ func TestUpdateUserValidationErrors(t *testing.T) {
 
 func TestUpdateUserConflictErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken1, userID1, teardown1 := setupUpdateUserTest(t, tc)
 	defer teardown1()
 
-	registerReq2 := fixtures.GenerateRegisterRequest()
+	registerReq2 := tc.Fixtures.GenerateRegisterRequest()
 	tokens2, err := tc.AuthClient.Register(*registerReq2)
 	require.NoError(t, err, "Failed to register second test user")
 
@@ -166,7 +165,7 @@ func TestUpdateUserConflictErrors(t *testing.T) {
 	t.Run("UsernameAlreadyExists", func(t *testing.T) {
 		tc.APIClient.SetToken(accessToken1)
 
-		updateReq := fixtures.GenerateUpdateUserRequest(userID1, registerReq2.Username, "", "", "")
+		updateReq := tc.Fixtures.GenerateUpdateUserRequest(userID1, registerReq2.Username, "", "", "")
 
 		_, err := tc.UserClient.UpdateUser(*updateReq)
 		require.Error(t, err)
@@ -176,7 +175,7 @@ func TestUpdateUserConflictErrors(t *testing.T) {
 	t.Run("EmailAlreadyExists", func(t *testing.T) {
 		tc.APIClient.SetToken(accessToken1)
 
-		updateReq := fixtures.GenerateUpdateUserRequest(userID1, "", registerReq2.Email, "", "")
+		updateReq := tc.Fixtures.GenerateUpdateUserRequest(userID1, "", registerReq2.Email, "", "")
 
 		_, err := tc.UserClient.UpdateUser(*updateReq)
 		require.Error(t, err)
@@ -186,7 +185,7 @@ func TestUpdateUserConflictErrors(t *testing.T) {
 
 func TestUpdateUserPermissionErrors(t *testing.T) {
 	t.Parallel()
-	tc := NewTestContext()
+	tc := NewTestContext(t)
 	defer tc.Cleanup()
 
 	accessToken1, userID1, teardown1 := setupUpdateUserTest(t, tc)
@@ -198,7 +197,7 @@ func TestUpdateUserPermissionErrors(t *testing.T) {
 	t.Run("UpdateOtherUser", func(t *testing.T) {
 		tc.APIClient.SetToken(accessToken1)
 
-		updateReq := fixtures.GenerateUpdateUserRequest(userID2, "", "", "", "")
+		updateReq := tc.Fixtures.GenerateUpdateUserRequest(userID2, "", "", "", "")
 
 		_, err := tc.UserClient.UpdateUser(*updateReq)
 		require.Error(t, err)
@@ -208,7 +207,7 @@ func TestUpdateUserPermissionErrors(t *testing.T) {
 	t.Run("UpdateSelfUser", func(t *testing.T) {
 		tc.APIClient.SetToken(accessToken1)
 
-		updateReq := fixtures.GenerateUpdateUserRequest(userID1, "", "", "", "")
+		updateReq := tc.Fixtures.GenerateUpdateUserRequest(userID1, "", "", "", "")
 		response, err := tc.UserClient.UpdateUser(*updateReq)
 		require.NoError(t, err)
 		require.NotNil(t, response)
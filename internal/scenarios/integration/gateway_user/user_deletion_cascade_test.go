@@ -0,0 +1,220 @@
+package gateway_user
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cascadePollInterval is how often the probes are repeated while waiting for
+// asynchronous cascades to settle.
+const cascadePollInterval = 250 * time.Millisecond
+
+// danglingReference is one place that still knows a deleted user, or, if
+// Inconclusive, one that could not be checked.
+type danglingReference struct {
+	Service      string
+	Detail       string
+	Inconclusive bool
+}
+
+func (d danglingReference) String() string {
+	if d.Inconclusive {
+		return d.Service + ": could not check " + d.Detail
+	}
+	return d.Service + ": " + d.Detail
+}
+
+// connectedUser is a user wired into every service: posts, followers,
+// followees and notifications sent and received.
+type connectedUser struct {
+	*factory.User
+	Posts                 []int64
+	Followers             []*factory.User
+	Followees             []*factory.User
+	ReceivedNotifications []int64
+	// SentNotifications were sent by the user to Others[2].
+	SentNotifications []int64
+	Others            []*factory.User
+}
+
+func setupConnectedUser(t *testing.T, tc *TestContext) *connectedUser {
+	t.Helper()
+
+	ctx := tc.APIClient.Context()
+
+	target, err := tc.Factory.User(ctx)
+	require.NoError(t, err, "Failed to create target user")
+	others, err := tc.Factory.Users(ctx, 3)
+	require.NoError(t, err, "Failed to create related users")
+
+	u := &connectedUser{User: target, Others: others}
+
+	posts, err := tc.Factory.PostBy(ctx, target, 2)
+	require.NoError(t, err, "Failed to create posts of target user")
+	for _, p := range posts {
+		u.Posts = append(u.Posts, p.ID)
+	}
+
+	require.NoError(t, tc.Factory.Follow(ctx, target, others[0]))
+	require.NoError(t, tc.Factory.Follow(ctx, target, others[2]))
+	require.NoError(t, tc.Factory.Follow(ctx, others[0], target))
+	require.NoError(t, tc.Factory.Follow(ctx, others[1], target))
+	u.Followees = []*factory.User{others[0], others[2]}
+	u.Followers = []*factory.User{others[0], others[1]}
+
+	received, err := others[1].Notifications.SendNotification(*tc.Fixtures.NewNotification(target.ID).WithType(fixtures.NotificationTypeSystem).Build())
+	require.NoError(t, err, "Failed to send notification to target user")
+	u.ReceivedNotifications = append(u.ReceivedNotifications, received.NotificationID)
+
+	sent, err := target.Notifications.SendNotification(*tc.Fixtures.NewNotification(others[2].ID).WithType(fixtures.NotificationTypeSystem).Build())
+	require.NoError(t, err, "Failed to send notification from target user")
+	u.SentNotifications = append(u.SentNotifications, sent.NotificationID)
+
+	return u
+}
+
+// probeDeletedUser asks every service about u and returns what still refers
+// to it. A 404 or an empty result means the reference is gone; any other
+// error is reported as inconclusive, since it leaves the question open.
+func probeDeletedUser(u *connectedUser) []danglingReference {
+	var found []danglingReference
+	add := func(service, format string, args ...any) {
+		found = append(found, danglingReference{Service: service, Detail: fmt.Sprintf(format, args...)})
+	}
+	inconclusive := func(service, what string, err error) {
+		found = append(found, danglingReference{Service: service, Detail: fmt.Sprintf("%s: %v", what, err), Inconclusive: true})
+	}
+	expectGone := func(service, what string, err error) {
+		switch code := client.StatusCode(err); {
+		case err == nil:
+			add(service, "%s is still reachable", what)
+		case code == http.StatusNotFound:
+		default:
+			inconclusive(service, what, err)
+		}
+	}
+
+	observer := u.Others[2]
+
+	_, err := observer.Users.GetUserByID(u.ID)
+	expectGone("user", fmt.Sprintf("GetUserByID(%d)", u.ID), err)
+	_, err = observer.Users.GetUserByUsername(u.Username)
+	expectGone("user", fmt.Sprintf("GetUserByUsername(%s)", u.Username), err)
+	if resp, err := observer.Users.SearchUsers(u.Username, 1, 10); err != nil {
+		inconclusive("user", fmt.Sprintf("SearchUsers(%s)", u.Username), err)
+	} else {
+		for _, found := range resp.Users {
+			if found.ID == u.ID {
+				add("user", "SearchUsers(%s) still returns the user", u.Username)
+			}
+		}
+	}
+
+	if _, err := observer.Auth.Login(fixtures.LoginRequest{Login: u.Username, Password: u.Password}); err == nil {
+		add("auth", "login with the deleted user's password still works")
+	}
+	if _, err := observer.Auth.RefreshToken(fixtures.RefreshTokenRequest{RefreshToken: u.RefreshToken}); err == nil {
+		add("auth", "the deleted user's refresh token is still accepted")
+	}
+
+	for _, other := range u.Others {
+		if resp, err := observer.Relations.GetFollowers(other.ID, 1, 100); err != nil {
+			inconclusive("relation", fmt.Sprintf("followers of user %d", other.ID), err)
+		} else {
+			for _, f := range resp.Followers {
+				if f.ID == u.ID {
+					add("relation", "followers of user %d still contain %d", other.ID, u.ID)
+				}
+			}
+		}
+		if resp, err := observer.Relations.GetFollowees(other.ID, 1, 100); err != nil {
+			inconclusive("relation", fmt.Sprintf("followees of user %d", other.ID), err)
+		} else {
+			for _, f := range resp.Followees {
+				if f.ID == u.ID {
+					add("relation", "followees of user %d still contain %d", other.ID, u.ID)
+				}
+			}
+		}
+	}
+	// For the deleted user itself a 404 is as good as an empty list.
+	switch resp, err := observer.Relations.GetFollowers(u.ID, 1, 100); {
+	case err == nil && resp.Total > 0:
+		add("relation", "the deleted user still has %d followers", resp.Total)
+	case err != nil && client.StatusCode(err) != http.StatusNotFound:
+		inconclusive("relation", fmt.Sprintf("followers of user %d", u.ID), err)
+	}
+	switch resp, err := observer.Relations.GetFollowees(u.ID, 1, 100); {
+	case err == nil && resp.Total > 0:
+		add("relation", "the deleted user still has %d followees", resp.Total)
+	case err != nil && client.StatusCode(err) != http.StatusNotFound:
+		inconclusive("relation", fmt.Sprintf("followees of user %d", u.ID), err)
+	}
+
+	for _, id := range u.Posts {
+		_, err := observer.Posts.GetPostByID(id)
+		expectGone("post", fmt.Sprintf("post %d", id), err)
+	}
+	switch resp, err := observer.Posts.ListPosts(u.ID, time.Time{}, time.Time{}, 0, 100); {
+	case err == nil && len(resp.Posts) > 0:
+		add("post", "ListPosts(author_id=%d) still returns %d posts", u.ID, len(resp.Posts))
+	case err != nil && client.StatusCode(err) != http.StatusNotFound:
+		inconclusive("post", fmt.Sprintf("ListPosts(author_id=%d)", u.ID), err)
+	}
+
+	// Notifications are private to their recipient, so each is read as its
+	// recipient. The deleted user's access token outlives the account; if
+	// the gateway refuses it, the probe is inconclusive.
+	for _, id := range u.ReceivedNotifications {
+		_, err := u.Notifications.GetNotificationByID(id)
+		expectGone("notification", fmt.Sprintf("notification %d addressed to the deleted user", id), err)
+	}
+	recipient := u.Others[2]
+	for _, id := range u.SentNotifications {
+		_, err := recipient.Notifications.GetNotificationByID(id)
+		expectGone("notification", fmt.Sprintf("notification %d sent by the deleted user to user %d", id, recipient.ID), err)
+	}
+
+	return found
+}
+
+func TestDeleteUserCascadeConsistency(t *testing.T) {
+	t.Parallel()
+	tc := NewTestContext(t)
+	defer tc.Cleanup()
+
+	u := setupConnectedUser(t, tc)
+	tc.Log.Info("Deleting fully connected user", "user_id", u.ID, "posts", len(u.Posts),
+		"followers", len(u.Followers), "followees", len(u.Followees))
+
+	require.NoError(t, u.Users.DeleteUser(u.ID), "Failed to delete user")
+
+	// Cascades may run through the outbox, so give them the delivery bound
+	// before reporting what is still left.
+	deadline := time.Now().Add(cfg.Outbox.DeliveryBound(len(u.Posts)+len(u.Followers)+len(u.Followees)) + cfg.API.Timeout)
+	var dangling []danglingReference
+	for {
+		dangling = probeDeletedUser(u)
+		if len(dangling) == 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(cascadePollInterval)
+	}
+
+	for _, d := range dangling {
+		if d.Inconclusive {
+			t.Errorf("Inconclusive probe for deleted user %d: %s", u.ID, d)
+			continue
+		}
+		t.Errorf("Dangling reference to deleted user %d: %s", u.ID, d)
+	}
+	assert.Empty(t, dangling, "Deleting a user must remove every reference to it")
+}
@@ -1,6 +1,7 @@
 package scenarios
 
 import (
+	"context"
 	"flag"
 	"log/slog"
 	"os"
@@ -10,7 +11,9 @@ import (
 	"github.com/Soloda1/pinstack-system-tests/config"
 	"github.com/Soloda1/pinstack-system-tests/internal/client"
 	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/histogram"
 	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/Soloda1/pinstack-system-tests/internal/tracing"
 )
 
 var (
@@ -52,8 +55,11 @@ type NotificationCleanupInfo struct {
 func setup(t *testing.T) (*fixtures.UserJourney, string, string, func()) {
 	t.Helper()
 
+	// Each journey step is a subtest and gets its own span under the journey
+	apiClient.SetContext(tracing.StartTest(t))
+
 	// Create a new UserJourney for each test to isolate test data
-	journey := fixtures.CreateUserJourney()
+	journey := fixtures.ForTest(t).CreateUserJourney()
 
 	log.Info("Setting up test", "test", t.Name(), "username", journey.RegisterRequest.Username)
 
@@ -84,6 +90,7 @@ func setup(t *testing.T) (*fixtures.UserJourney, string, string, func()) {
 		log.Info("Test complete, local cleanup", "test", t.Name())
 
 		apiClient.SetToken("")
+		apiClient.SetContext(context.Background())
 	}
 }
 
@@ -93,7 +100,16 @@ func TestMain(m *testing.M) {
 
 	cfg = config.MustLoad("../../config")
 
-	log = logger.New(cfg.Env)
+	log = logger.New(cfg.Env, cfg.Test.LogLevel)
+	logger.SetRedactedFields(cfg.Redaction.Fields...)
+	logger.SetTestLevel(cfg.Env, cfg.Test.LogLevel)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Error("Failed to set up tracing", "error", err.Error())
+		os.Exit(1)
+	}
+
 	log.Info("Starting user journey e2e tests", "env", cfg.Env)
 
 	apiClient = client.NewClient(cfg, log)
@@ -105,7 +121,11 @@ func TestMain(m *testing.M) {
 
 	// Run the tests
 	log.Info("Setup completed, starting tests")
+	saveLatencies := histogram.RecordClients(cfg.Perf, "user_journey")
 	code := m.Run()
+	if err := saveLatencies(); err != nil {
+		log.Warn("Failed to save latency histograms", "error", err.Error())
+	}
 
 	// Clean up
 	if cfg.Test.Cleanup {
@@ -113,6 +133,10 @@ func TestMain(m *testing.M) {
 		cleanup()
 	}
 
+	if err := shutdownTracing(context.Background()); err != nil {
+		log.Warn("Failed to flush traces", "error", err.Error())
+	}
+
 	os.Exit(code)
 }
 
@@ -199,6 +223,8 @@ func cleanup() {
 
 // TestUserJourney tests the complete user journey from registration to usage
 func TestUserJourney(t *testing.T) {
+	tracing.StartTest(t)
+
 	t.Run("1. Registration and Login", testUserRegistrationAndLogin)
 	t.Run("2. Profile Management", testUserProfileManagement)
 	t.Run("3. Post Creation", testPostCreation)
@@ -0,0 +1,80 @@
+package security
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Splice returns base marshalled as a JSON object with field set to the raw
+// JSON value. The value is copied as is, so it may be nested deeper than
+// encoding/json would marshal.
+func Splice(base interface{}, field string, value []byte) ([]byte, error) {
+	data, err := json.Marshal(base)
+	if err != nil {
+		return nil, fmt.Errorf("marshal base: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("base is not a JSON object: %w", err)
+	}
+	delete(fields, field)
+	rest, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	key, err := json.Marshal(field)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	b.WriteByte('{')
+	b.Write(key)
+	b.WriteByte(':')
+	b.Write(value)
+	if len(rest) > 2 {
+		b.WriteByte(',')
+		b.Write(rest[1 : len(rest)-1])
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// Nested returns depth arrays nested in one another around an empty string.
+func Nested(depth int) []byte {
+	return []byte(strings.Repeat("[", depth) + `""` + strings.Repeat("]", depth))
+}
+
+// Stored describes how a stored string came back.
+type Stored string
+
+const (
+	// StoredVerbatim means unchanged markup; escaping is left to whoever
+	// renders it.
+	StoredVerbatim Stored = "verbatim"
+	// StoredEscaped means HTML-escaped.
+	StoredEscaped Stored = "escaped"
+	// StoredSanitized means changed with no markup left.
+	StoredSanitized Stored = "sanitized"
+	// StoredMangled means changed with markup left: a sanitizer that
+	// missed part of the payload.
+	StoredMangled Stored = "mangled"
+)
+
+// CheckStored compares the string read back with the one sent. A string with
+// nothing to escape that comes back unchanged counts as escaped.
+func CheckStored(sent, got string) Stored {
+	switch {
+	case got == html.EscapeString(sent):
+		return StoredEscaped
+	case got == sent:
+		return StoredVerbatim
+	case strings.Contains(got, "<"):
+		return StoredMangled
+	default:
+		return StoredSanitized
+	}
+}
@@ -0,0 +1,192 @@
+// Package security holds the payload catalogue of the injection suite and the
+// checks applied to what the gateway sends back: error bodies must not leak
+// internal details and stored strings must come back intact or escaped.
+package security
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Category groups payloads by the attack they probe.
+type Category string
+
+const (
+	// SQL payloads are sent as string values.
+	SQL Category = "sql"
+	// NoSQL payloads are raw JSON values put where a string or number is
+	// expected, such as operator objects and arrays.
+	NoSQL Category = "nosql"
+	// Traversal payloads go into path segments.
+	Traversal Category = "traversal"
+	// XSS payloads are stored and read back.
+	XSS Category = "xss"
+)
+
+// Categories lists every category a catalogue file may use.
+var Categories = []Category{SQL, NoSQL, Traversal, XSS}
+
+// Payload is one malicious value. Name is used as the subtest name.
+type Payload struct {
+	Category Category `yaml:"category"`
+	Name     string   `yaml:"name"`
+	Value    string   `yaml:"value"`
+}
+
+// Catalogue is the payloads to send and the fragments of internal error
+// details, such as driver errors and stack traces, that must never reach a
+// client.
+type Catalogue struct {
+	Payloads     []Payload `yaml:"payloads"`
+	LeakPatterns []string  `yaml:"leak_patterns"`
+}
+
+var builtinPayloads = []Payload{
+	{SQL, "quote", `'`},
+	{SQL, "tautology", `' OR '1'='1`},
+	{SQL, "tautology-comment", `' OR 1=1 --`},
+	{SQL, "union", `' UNION SELECT username, password FROM users --`},
+	{SQL, "stacked", `'; DROP TABLE users; --`},
+	{SQL, "time-based", `'; SELECT pg_sleep(5); --`},
+	{SQL, "backslash", `\`},
+
+	{NoSQL, "ne-null", `{"$ne": null}`},
+	{NoSQL, "gt-empty", `{"$gt": ""}`},
+	{NoSQL, "regex", `{"$regex": ".*"}`},
+	{NoSQL, "where", `{"$where": "sleep(1000)"}`},
+	{NoSQL, "proto", `{"__proto__": {"admin": true}}`},
+	{NoSQL, "array", `["admin"]`},
+	{NoSQL, "bool", `true`},
+
+	// No short relative paths such as ../1: they normalize to public
+	// routes like GET /v1/users/1, whose success is not an escape.
+	{Traversal, "parent", `../../../../etc/passwd`},
+	{Traversal, "dot-dot", `..`},
+	{Traversal, "encoded", `..%2f..%2f..%2fetc%2fpasswd`},
+	{Traversal, "double-encoded", `..%252f..%252fetc%252fpasswd`},
+	{Traversal, "backslash", `..\..\..\windows\win.ini`},
+	{Traversal, "null-byte", "admin\x00.json"},
+
+	{XSS, "script", `<script>alert(1)</script>`},
+	{XSS, "img-onerror", `<img src=x onerror=alert(1)>`},
+	{XSS, "svg-onload", `<svg/onload=alert(1)>`},
+	{XSS, "attribute-break", `"><script>alert(1)</script>`},
+	{XSS, "iframe", `<iframe src="javascript:alert(1)"></iframe>`},
+	{XSS, "javascript-url", `javascript:alert(1)`},
+	{XSS, "template", `{{constructor.constructor('alert(1)')()}}`},
+}
+
+var builtinLeakPatterns = []string{
+	"sqlstate",
+	"pq:",
+	"pgx",
+	"syntax error at or near",
+	"duplicate key value violates",
+	"violates foreign key",
+	"sql: ",
+	"panic:",
+	"goroutine ",
+	"runtime error",
+	"nil pointer",
+	".go:",
+	"stack trace",
+	"traceback",
+	"rpc error:",
+	"desc = ",
+	"dial tcp",
+	"connection refused",
+	"/usr/local/",
+	"/home/",
+}
+
+// Default returns the built-in catalogue.
+func Default() *Catalogue {
+	return &Catalogue{
+		Payloads:     append([]Payload(nil), builtinPayloads...),
+		LeakPatterns: append([]string(nil), builtinLeakPatterns...),
+	}
+}
+
+// Load returns the built-in catalogue extended with the YAML file at path;
+// an empty path returns the built-in one. Entries without a name are named
+// after their category and position in the file.
+func Load(path string) (*Catalogue, error) {
+	c := Default()
+	if path == "" {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read payload catalogue: %w", err)
+	}
+	var extra Catalogue
+	if err := yaml.Unmarshal(data, &extra); err != nil {
+		return nil, fmt.Errorf("parse payload catalogue %s: %w", path, err)
+	}
+
+	var errs []error
+	for i, p := range extra.Payloads {
+		if p.Name == "" {
+			p.Name = fmt.Sprintf("%s-%d", p.Category, i+1)
+		}
+		if err := p.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("payloads[%d]: %w", i, err))
+			continue
+		}
+		c.Payloads = append(c.Payloads, p)
+	}
+	for _, pattern := range extra.LeakPatterns {
+		if pattern != "" {
+			c.LeakPatterns = append(c.LeakPatterns, pattern)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("payload catalogue %s: %w", path, errors.Join(errs...))
+	}
+	return c, nil
+}
+
+func (p Payload) validate() error {
+	known := false
+	for _, cat := range Categories {
+		known = known || p.Category == cat
+	}
+	switch {
+	case !known:
+		return fmt.Errorf("unknown category %q", p.Category)
+	case p.Value == "":
+		return errors.New("empty value")
+	case p.Category == NoSQL && !json.Valid([]byte(p.Value)):
+		return fmt.Errorf("nosql value %q is not JSON", p.Value)
+	}
+	return nil
+}
+
+// Of returns the payloads of one category.
+func (c *Catalogue) Of(cat Category) []Payload {
+	var out []Payload
+	for _, p := range c.Payloads {
+		if p.Category == cat {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Leaks returns the leak patterns found in text, ignoring case.
+func (c *Catalogue) Leaks(text string) []string {
+	text = strings.ToLower(text)
+	var found []string
+	for _, pattern := range c.LeakPatterns {
+		if strings.Contains(text, strings.ToLower(pattern)) {
+			found = append(found, pattern)
+		}
+	}
+	return found
+}
@@ -0,0 +1,82 @@
+package security
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadExtendsBuiltins(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "payloads.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+payloads:
+  - { category: sql, name: mysql-comment, value: "' OR 1=1 #" }
+  - { category: xss, value: "<details open ontoggle=alert(1)>" }
+leak_patterns: ["ORA-"]
+`), 0o600))
+
+	c, err := Load(path)
+	require.NoError(t, err)
+	assert.Len(t, c.Payloads, len(builtinPayloads)+2)
+
+	sql := c.Of(SQL)
+	assert.Equal(t, "mysql-comment", sql[len(sql)-1].Name)
+	xss := c.Of(XSS)
+	assert.Equal(t, "xss-2", xss[len(xss)-1].Name)
+	assert.Equal(t, []string{"ORA-"}, c.Leaks("ora-00933: SQL command not properly ended"))
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+payloads:
+  - { category: ldap, value: "*)(uid=*" }
+  - { category: nosql, value: "{$ne: 1}" }
+`), 0o600))
+	_, err = Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `payloads[0]: unknown category "ldap"`)
+	assert.Contains(t, err.Error(), "payloads[1]: nosql value")
+}
+
+func TestBuiltinsAreValid(t *testing.T) {
+	for _, p := range Default().Payloads {
+		assert.NoError(t, p.validate(), p.Name)
+	}
+}
+
+func TestLeaks(t *testing.T) {
+	c := Default()
+	assert.Empty(t, c.Leaks(`{"status":400,"message":"invalid username"}`))
+	assert.Equal(t, []string{"sqlstate", "pq:"},
+		c.Leaks(`{"message":"pq: duplicate key (SQLSTATE 23505)"}`))
+	assert.Contains(t, c.Leaks("panic: runtime error: index out of range\ngoroutine 1 [running]:\nmain.go:12"), "goroutine ")
+}
+
+func TestSplice(t *testing.T) {
+	base := map[string]string{"login": "alice", "password": "secret"}
+
+	body, err := Splice(base, "password", []byte(`{"$ne": null}`))
+	require.NoError(t, err)
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, map[string]interface{}{"login": "alice", "password": map[string]interface{}{"$ne": nil}}, got)
+
+	// Deeper than encoding/json accepts, which is the point.
+	deep := Nested(20000)
+	body, err = Splice(base, "login", deep)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(body), `{"login":[[[`))
+	assert.True(t, strings.HasSuffix(string(body), `]]],"password":"secret"}`))
+}
+
+func TestCheckStored(t *testing.T) {
+	sent := `<script>alert(1)</script>`
+	assert.Equal(t, StoredVerbatim, CheckStored(sent, sent))
+	assert.Equal(t, StoredEscaped, CheckStored(sent, "&lt;script&gt;alert(1)&lt;/script&gt;"))
+	assert.Equal(t, StoredSanitized, CheckStored(sent, "alert(1)"))
+	assert.Equal(t, StoredMangled, CheckStored(`<img src=x onerror=alert(1)>`, `<img src=x>`))
+	assert.Equal(t, StoredEscaped, CheckStored("javascript:alert(1)", "javascript:alert(1)"))
+}
@@ -0,0 +1,108 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+)
+
+// ManifestVersion is bumped whenever the manifest layout changes
+// incompatibly.
+const ManifestVersion = 1
+
+// Manifest lists everything a seed run created. It contains passwords and
+// tokens, so it is written readable by the owner only.
+type Manifest struct {
+	Version       int                  `json:"version"`
+	Seed          int64                `json:"seed"`
+	BaseURL       string               `json:"base_url"`
+	CreatedAt     time.Time            `json:"created_at"`
+	Users         []UserRecord         `json:"users"`
+	Posts         []PostRecord         `json:"posts"`
+	Follows       []FollowRecord       `json:"follows"`
+	Notifications []NotificationRecord `json:"notifications"`
+}
+
+type UserRecord struct {
+	ID           int64  `json:"id"`
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type PostRecord struct {
+	ID       int64 `json:"id"`
+	AuthorID int64 `json:"author_id"`
+}
+
+type FollowRecord struct {
+	FollowerID int64 `json:"follower_id"`
+	FolloweeID int64 `json:"followee_id"`
+}
+
+type NotificationRecord struct {
+	ID       int64 `json:"id"`
+	UserID   int64 `json:"user_id"`
+	SenderID int64 `json:"sender_id"`
+}
+
+// Save writes the manifest as indented JSON.
+func (m *Manifest) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write manifest %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadManifest reads a manifest written by Save.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest %s: %w", path, err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse manifest %s: %w", path, err)
+	}
+	if m.Version != ManifestVersion {
+		return nil, fmt.Errorf("manifest %s has version %d, want %d", path, m.Version, ManifestVersion)
+	}
+	return &m, nil
+}
+
+// User returns the record of the seeded user with id.
+func (m *Manifest) User(id int64) (UserRecord, bool) {
+	for _, u := range m.Users {
+		if u.ID == id {
+			return u, true
+		}
+	}
+	return UserRecord{}, false
+}
+
+// Attach returns a factory user with its own clients for every seeded user,
+// in manifest order, so tests and load runs can work with the dataset without
+// creating it. The stored access tokens are used as is; callers that outlive
+// the token expiry log in again with the stored password.
+func (m *Manifest) Attach(ctx context.Context, cfg *config.Config, log *logger.Logger) []*factory.User {
+	users := make([]*factory.User, 0, len(m.Users))
+	for _, u := range m.Users {
+		profile := fixtures.User{ID: u.ID, Username: u.Username, Email: u.Email}
+		users = append(users, factory.Attach(ctx, cfg, log, profile, u.Password, u.AccessToken, u.RefreshToken))
+	}
+	return users
+}
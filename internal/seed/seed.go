@@ -0,0 +1,270 @@
+// Package seed creates large pre-populated datasets through the gateway and
+// records them in a manifest, so test and load runs can attach to an existing
+// environment instead of building their data on every run.
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/Soloda1/pinstack-system-tests/internal/socialgraph"
+)
+
+// Options sizes a dataset. Posts and notifications are totals spread
+// round-robin over the users.
+type Options struct {
+	Users         int
+	Posts         int
+	Notifications int
+	// Graph shapes the follow graph; its Seed and Users are taken from the
+	// generator and Users.
+	Graph       socialgraph.Config
+	Concurrency int
+}
+
+// Seeder creates datasets. All request data is drawn from gen up front, so a
+// seed reproduces the same dataset content.
+type Seeder struct {
+	cfg *config.Config
+	log *logger.Logger
+	gen *fixtures.Generator
+}
+
+func New(cfg *config.Config, log *logger.Logger, gen *fixtures.Generator) *Seeder {
+	return &Seeder{cfg: cfg, log: log, gen: gen}
+}
+
+// Seed creates the dataset described by opts. On failure it still returns a
+// manifest of everything created so far, so it can be torn down.
+func (s *Seeder) Seed(ctx context.Context, opts Options) (*Manifest, error) {
+	if opts.Users <= 0 {
+		return nil, fmt.Errorf("seed: users must be positive, got %d", opts.Users)
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = s.cfg.Test.Concurrent
+	}
+
+	graphCfg := opts.Graph
+	graphCfg.Seed = s.gen.Seed()
+	graphCfg.Users = opts.Users
+	graph, err := socialgraph.Generate(graphCfg)
+	if err != nil {
+		return nil, fmt.Errorf("seed: %w", err)
+	}
+
+	registers := make([]*fixtures.RegisterRequest, opts.Users)
+	for i := range registers {
+		// The dataset outlives the run, so the sweeper must not take
+		// it for leftovers.
+		registers[i] = s.gen.NewRegister().Build()
+		registers[i].Bio = fixtures.MarkKept(registers[i].Bio)
+	}
+	posts := make([]*fixtures.CreatePostRequest, opts.Posts)
+	for i := range posts {
+		posts[i] = s.gen.NewPost().Build()
+	}
+	notifications := make([]*fixtures.SendNotificationRequest, opts.Notifications)
+	for i := range notifications {
+		notifications[i] = s.gen.NewNotification(0).Build()
+	}
+
+	r := &run{
+		cfg: s.cfg,
+		log: s.log,
+		m: &Manifest{
+			Version:   ManifestVersion,
+			Seed:      s.gen.Seed(),
+			BaseURL:   s.cfg.API.BaseURL,
+			CreatedAt: time.Now().UTC(),
+		},
+		users: make([]*factory.User, opts.Users),
+	}
+
+	steps := []struct {
+		name string
+		fn   func() error
+	}{
+		{"users", func() error { return r.register(ctx, registers, opts.Concurrency) }},
+		{"posts", func() error { return r.createPosts(ctx, posts, opts.Concurrency) }},
+		{"follows", func() error {
+			return graph.Materialize(ctx, opts.Concurrency, r.follow)
+		}},
+		{"notifications", func() error { return r.notify(ctx, notifications, opts.Concurrency) }},
+	}
+	for _, step := range steps {
+		start := time.Now()
+		if err := step.fn(); err != nil {
+			r.m.sort()
+			return r.m, fmt.Errorf("seed %s: %w", step.name, err)
+		}
+		s.log.Info("Seeded "+step.name, slog.Duration("took", time.Since(start)))
+	}
+
+	r.m.sort()
+	return r.m, nil
+}
+
+// run holds the state of one Seed call. Every user has its own clients and
+// each one is only ever driven by one goroutine at a time.
+type run struct {
+	cfg   *config.Config
+	log   *logger.Logger
+	mu    sync.Mutex
+	m     *Manifest
+	users []*factory.User
+}
+
+func (r *run) register(ctx context.Context, reqs []*fixtures.RegisterRequest, concurrency int) error {
+	return forEach(ctx, len(reqs), concurrency, func(i int) error {
+		req := reqs[i]
+		u := factory.Attach(ctx, r.cfg, r.log, fixtures.User{}, req.Password, "", "")
+
+		tokens, err := u.Auth.Register(*req)
+		if err != nil {
+			return fmt.Errorf("register %s: %w", req.Username, err)
+		}
+		u.AccessToken = tokens.AccessToken
+		u.RefreshToken = tokens.RefreshToken
+		u.API.SetToken(tokens.AccessToken)
+
+		profile, err := u.Users.GetUserByUsername(req.Username)
+		if err != nil {
+			return fmt.Errorf("get registered user %s: %w", req.Username, err)
+		}
+		u.User = *profile
+		r.users[i] = u
+
+		r.mu.Lock()
+		r.m.Users = append(r.m.Users, UserRecord{
+			ID:           u.ID,
+			Username:     u.Username,
+			Email:        u.Email,
+			Password:     u.Password,
+			AccessToken:  u.AccessToken,
+			RefreshToken: u.RefreshToken,
+		})
+		r.mu.Unlock()
+		return nil
+	})
+}
+
+func (r *run) createPosts(ctx context.Context, reqs []*fixtures.CreatePostRequest, concurrency int) error {
+	return forEach(ctx, len(r.users), concurrency, func(author int) error {
+		u := r.users[author]
+		var errs []error
+		for i := author; i < len(reqs); i += len(r.users) {
+			post, err := u.Posts.CreatePost(*reqs[i])
+			if err != nil {
+				errs = append(errs, fmt.Errorf("create post by user %d: %w", u.ID, err))
+				continue
+			}
+			r.mu.Lock()
+			r.m.Posts = append(r.m.Posts, PostRecord{ID: post.ID, AuthorID: u.ID})
+			r.mu.Unlock()
+		}
+		return errors.Join(errs...)
+	})
+}
+
+func (r *run) follow(ctx context.Context, follower, followee int) error {
+	a, b := r.users[follower], r.users[followee]
+	if _, err := a.Relations.Follow(b.ID); err != nil {
+		return fmt.Errorf("user %d follow %d: %w", a.ID, b.ID, err)
+	}
+	r.mu.Lock()
+	r.m.Follows = append(r.m.Follows, FollowRecord{FollowerID: a.ID, FolloweeID: b.ID})
+	r.mu.Unlock()
+	return nil
+}
+
+// notify sends notification i to user i and has it sent by the next user, so
+// every sender's requests stay on one goroutine.
+func (r *run) notify(ctx context.Context, reqs []*fixtures.SendNotificationRequest, concurrency int) error {
+	n := len(r.users)
+	return forEach(ctx, n, concurrency, func(sender int) error {
+		u := r.users[sender]
+		var errs []error
+		for i := (sender + n - 1) % n; i < len(reqs); i += n {
+			recipient := r.users[i%n]
+			req := *reqs[i]
+			req.UserID = recipient.ID
+
+			resp, err := u.Notifications.SendNotification(req)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("send notification to user %d: %w", recipient.ID, err))
+				continue
+			}
+			r.mu.Lock()
+			r.m.Notifications = append(r.m.Notifications, NotificationRecord{
+				ID:       resp.NotificationID,
+				UserID:   recipient.ID,
+				SenderID: u.ID,
+			})
+			r.mu.Unlock()
+		}
+		return errors.Join(errs...)
+	})
+}
+
+func (m *Manifest) sort() {
+	sort.Slice(m.Users, func(i, j int) bool { return m.Users[i].ID < m.Users[j].ID })
+	sort.Slice(m.Posts, func(i, j int) bool { return m.Posts[i].ID < m.Posts[j].ID })
+	sort.Slice(m.Follows, func(i, j int) bool {
+		if m.Follows[i].FollowerID != m.Follows[j].FollowerID {
+			return m.Follows[i].FollowerID < m.Follows[j].FollowerID
+		}
+		return m.Follows[i].FolloweeID < m.Follows[j].FolloweeID
+	})
+	sort.Slice(m.Notifications, func(i, j int) bool { return m.Notifications[i].ID < m.Notifications[j].ID })
+}
+
+// forEach calls fn for 0..n-1 with at most concurrency calls in flight and
+// joins their errors. Indexes not yet started when ctx is cancelled are
+// skipped.
+func forEach(ctx context.Context, n, concurrency int, fn func(i int) error) error {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sem  = make(chan struct{}, concurrency)
+	)
+
+loop:
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			mu.Lock()
+			errs = append(errs, ctx.Err())
+			mu.Unlock()
+			break loop
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(i); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
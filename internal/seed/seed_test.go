@@ -0,0 +1,202 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/Soloda1/pinstack-system-tests/internal/socialgraph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGateway answers the endpoints seeding and teardown call. Deleted users
+// and users in changed both get a bare 401 on login, as if their password had
+// been changed; only the profile of a deleted user answers 404.
+type fakeGateway struct {
+	mu      sync.Mutex
+	calls   []string
+	nextID  int64
+	users   map[string]int64
+	deleted map[string]bool
+	changed map[string]bool
+}
+
+func (g *fakeGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/api")
+	g.calls = append(g.calls, r.Method+" "+path)
+	g.nextID++
+
+	write := func(data interface{}) {
+		_ = json.NewEncoder(w).Encode(fixtures.BaseResponse{Status: http.StatusOK, Data: data})
+	}
+
+	switch {
+	case r.Method == http.MethodPost && path == "/v1/auth/register":
+		var req fixtures.RegisterRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		g.users[req.Username] = g.nextID
+		write(fixtures.RegisterResponse{AccessToken: "a", RefreshToken: "r"})
+	case r.Method == http.MethodPost && path == "/v1/auth/login":
+		var req fixtures.LoginRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch {
+		case g.deleted[req.Login], g.changed[req.Login]:
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(fixtures.ErrorBody{Status: http.StatusUnauthorized, Message: "invalid credentials"})
+			return
+		}
+		write(fixtures.LoginResponse{AccessToken: "b", RefreshToken: "r"})
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/v1/users/username/"):
+		name := strings.TrimPrefix(path, "/v1/users/username/")
+		write(fixtures.User{ID: g.users[name], Username: name})
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/v1/users/"):
+		for name, id := range g.users {
+			if path == "/v1/users/"+strconv.FormatInt(id, 10) && g.deleted[name] {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(fixtures.ErrorBody{Status: http.StatusNotFound, Message: "user not found"})
+				return
+			}
+		}
+		write(fixtures.User{})
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, "/v1/users/"):
+		for name, id := range g.users {
+			if path == "/v1/users/"+strconv.FormatInt(id, 10) {
+				g.deleted[name] = true
+			}
+		}
+		write(map[string]string{"message": "ok"})
+	case r.Method == http.MethodPost && path == "/v1/posts":
+		write(fixtures.CreatePostResponse{ID: g.nextID})
+	case r.Method == http.MethodPost && path == "/v1/notification/send":
+		write(fixtures.SendNotificationResponse{NotificationID: g.nextID})
+	default:
+		write(map[string]string{"message": "ok"})
+	}
+}
+
+func (g *fakeGateway) count(prefix string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	n := 0
+	for _, c := range g.calls {
+		if strings.HasPrefix(c, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSeedManifestAndTeardown(t *testing.T) {
+	gw := &fakeGateway{users: map[string]int64{}, deleted: map[string]bool{}, changed: map[string]bool{}}
+	srv := httptest.NewServer(gw)
+	defer srv.Close()
+
+	cfg := &config.Config{
+		API:  config.API{BaseURL: srv.URL + "/api", Timeout: time.Second},
+		Test: config.Test{Concurrent: 4},
+	}
+	log := logger.ForTest(t)
+	ctx := context.Background()
+
+	opts := Options{
+		Users:         10,
+		Posts:         25,
+		Notifications: 7,
+		Graph:         socialgraph.ScaledConfig(0, 10),
+	}
+	m, err := New(cfg, log, fixtures.NewGenerator(5)).Seed(ctx, opts)
+	require.NoError(t, err)
+
+	graphCfg := opts.Graph
+	graphCfg.Seed, graphCfg.Users = 5, opts.Users
+	graph, err := socialgraph.Generate(graphCfg)
+	require.NoError(t, err)
+
+	assert.Len(t, m.Users, opts.Users)
+	assert.Len(t, m.Posts, opts.Posts)
+	assert.Len(t, m.Notifications, opts.Notifications)
+	assert.Len(t, m.Follows, len(graph.Edges()))
+	for _, n := range m.Notifications {
+		assert.NotEqual(t, n.UserID, n.SenderID)
+	}
+
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	require.NoError(t, m.Save(path))
+	loaded, err := LoadManifest(path)
+	require.NoError(t, err)
+	assert.Equal(t, m.Users, loaded.Users)
+	assert.Equal(t, m.Follows, loaded.Follows)
+
+	attached := loaded.Attach(ctx, cfg, log)
+	require.Len(t, attached, opts.Users)
+	assert.Equal(t, m.Users[0].AccessToken, attached[0].API.GetToken())
+
+	res, err := Teardown(ctx, cfg, log, loaded, 3)
+	require.NoError(t, err)
+	assert.Equal(t, TeardownResult{
+		Notifications: opts.Notifications,
+		Follows:       len(m.Follows),
+		Posts:         opts.Posts,
+		Users:         opts.Users,
+	}, res)
+	assert.Equal(t, len(m.Follows), gw.count("POST /v1/relation/unfollow"))
+
+	res, err = Teardown(ctx, cfg, log, loaded, 3)
+	require.NoError(t, err)
+	assert.Equal(t, opts.Users+opts.Notifications+len(m.Follows)+opts.Posts, res.Missing,
+		"a second teardown finds every user gone and skips their entities")
+	assert.Zero(t, res.Unverified)
+}
+
+func TestTeardownReportsUsersItCannotLogInAs(t *testing.T) {
+	gw := &fakeGateway{users: map[string]int64{}, deleted: map[string]bool{}, changed: map[string]bool{}}
+	srv := httptest.NewServer(gw)
+	defer srv.Close()
+
+	cfg := &config.Config{
+		API:  config.API{BaseURL: srv.URL + "/api", Timeout: time.Second},
+		Test: config.Test{Concurrent: 4},
+	}
+	log := logger.ForTest(t)
+	ctx := context.Background()
+
+	m, err := New(cfg, log, fixtures.NewGenerator(5)).Seed(ctx, Options{Users: 3, Posts: 6})
+	require.NoError(t, err)
+
+	locked := m.Users[0]
+	gw.mu.Lock()
+	gw.changed[locked.Username] = true
+	gw.mu.Unlock()
+	owned := 1
+	for _, p := range m.Posts {
+		if p.AuthorID == locked.ID {
+			owned++
+		}
+	}
+	for _, f := range m.Follows {
+		if f.FollowerID == locked.ID {
+			owned++
+		}
+	}
+
+	res, err := Teardown(ctx, cfg, log, m, 2)
+	require.Error(t, err, "unverified entities must fail the teardown")
+	assert.Contains(t, err.Error(), "login "+locked.Username)
+	assert.Equal(t, owned, res.Unverified)
+	assert.Zero(t, res.Missing, "an unreachable user is not a deleted one")
+	assert.Equal(t, len(m.Users)-1, res.Users)
+}
@@ -0,0 +1,196 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/soloda1/pinstack-proto-definitions/custom_errors"
+)
+
+// TeardownResult counts what a teardown removed. Entities that were already
+// gone are counted as Missing, not as errors. Entities of users the teardown
+// could not log in as, although the account may still exist, are Unverified:
+// they may have leaked, and Teardown returns an error for them.
+type TeardownResult struct {
+	Notifications int
+	Follows       int
+	Posts         int
+	Users         int
+	Missing       int
+	Unverified    int
+}
+
+// Teardown deletes everything listed in m: notifications, follows, posts and
+// finally the users. Every user logs in again with the stored password first,
+// since the manifest tokens may have expired. A user whose login fails and
+// whose profile is not found is gone, and so is what it owned; running it
+// twice is harmless.
+func Teardown(ctx context.Context, cfg *config.Config, log *logger.Logger, m *Manifest, concurrency int) (TeardownResult, error) {
+	if concurrency <= 0 {
+		concurrency = cfg.Test.Concurrent
+	}
+
+	var (
+		notifications, follows, posts, users, missing, unverified atomic.Int64
+
+		sessions = make(map[int64]*factory.User, len(m.Users))
+		loggedIn = make([]*factory.User, len(m.Users))
+		goneIDs  = make([]bool, len(m.Users))
+		gone     = make(map[int64]bool)
+	)
+
+	err := forEach(ctx, len(m.Users), concurrency, func(i int) error {
+		rec := m.Users[i]
+		u := factory.Attach(ctx, cfg, log, fixtures.User{ID: rec.ID, Username: rec.Username, Email: rec.Email}, rec.Password, "", "")
+
+		tokens, err := u.Auth.Login(fixtures.LoginRequest{Login: rec.Username, Password: rec.Password})
+		if err != nil {
+			if accountGone(u, err) {
+				goneIDs[i] = true
+				return nil
+			}
+			return fmt.Errorf("login %s: %w", rec.Username, err)
+		}
+		u.AccessToken = tokens.AccessToken
+		u.RefreshToken = tokens.RefreshToken
+		u.API.SetToken(tokens.AccessToken)
+		loggedIn[i] = u
+		return nil
+	})
+	for i, u := range loggedIn {
+		if u != nil {
+			sessions[u.ID] = u
+		}
+		if goneIDs[i] {
+			gone[m.Users[i].ID] = true
+		}
+	}
+
+	// del runs one delete per record on the session of its owner, with the
+	// records of each owner handled sequentially.
+	del := func(owners []int64, fn func(u *factory.User, i int) error, counter *atomic.Int64) error {
+		byOwner := make(map[int64][]int)
+		var order []int64
+		for i, owner := range owners {
+			if _, ok := byOwner[owner]; !ok {
+				order = append(order, owner)
+			}
+			byOwner[owner] = append(byOwner[owner], i)
+		}
+
+		return forEach(ctx, len(order), concurrency, func(k int) error {
+			u, ok := sessions[order[k]]
+			if !ok {
+				if gone[order[k]] {
+					missing.Add(int64(len(byOwner[order[k]])))
+				} else {
+					unverified.Add(int64(len(byOwner[order[k]])))
+				}
+				return nil
+			}
+			var errs []error
+			for _, i := range byOwner[order[k]] {
+				switch err := fn(u, i); {
+				case err == nil:
+					counter.Add(1)
+				case isGone(err):
+					missing.Add(1)
+				default:
+					errs = append(errs, err)
+				}
+			}
+			return errors.Join(errs...)
+		})
+	}
+
+	errs := []error{err}
+
+	owners := make([]int64, len(m.Notifications))
+	for i, n := range m.Notifications {
+		owners[i] = n.UserID
+	}
+	errs = append(errs, del(owners, func(u *factory.User, i int) error {
+		_, err := u.Notifications.RemoveNotification(m.Notifications[i].ID)
+		return wrap(err, "remove notification %d", m.Notifications[i].ID)
+	}, &notifications))
+
+	owners = make([]int64, len(m.Follows))
+	for i, f := range m.Follows {
+		owners[i] = f.FollowerID
+	}
+	errs = append(errs, del(owners, func(u *factory.User, i int) error {
+		_, err := u.Relations.Unfollow(m.Follows[i].FolloweeID)
+		return wrap(err, "user %d unfollow %d", u.ID, m.Follows[i].FolloweeID)
+	}, &follows))
+
+	owners = make([]int64, len(m.Posts))
+	for i, p := range m.Posts {
+		owners[i] = p.AuthorID
+	}
+	errs = append(errs, del(owners, func(u *factory.User, i int) error {
+		return wrap(u.Posts.DeletePost(m.Posts[i].ID), "delete post %d", m.Posts[i].ID)
+	}, &posts))
+
+	owners = make([]int64, len(m.Users))
+	for i, u := range m.Users {
+		owners[i] = u.ID
+	}
+	errs = append(errs, del(owners, func(u *factory.User, i int) error {
+		return wrap(u.Users.DeleteUser(u.ID), "delete user %d", u.ID)
+	}, &users))
+
+	res := TeardownResult{
+		Notifications: int(notifications.Load()),
+		Follows:       int(follows.Load()),
+		Posts:         int(posts.Load()),
+		Users:         int(users.Load()),
+		Missing:       int(missing.Load()),
+		Unverified:    int(unverified.Load()),
+	}
+	log.Info("Teardown finished",
+		slog.Int("notifications", res.Notifications),
+		slog.Int("follows", res.Follows),
+		slog.Int("posts", res.Posts),
+		slog.Int("users", res.Users),
+		slog.Int("missing", res.Missing),
+		slog.Int("unverified", res.Unverified),
+	)
+	if res.Unverified > 0 {
+		errs = append(errs, fmt.Errorf("%d entities of users that could not log in were not deleted and may have leaked", res.Unverified))
+	}
+	return res, errors.Join(errs...)
+}
+
+// accountGone reports whether the failed login of u means the account was
+// deleted. The status a login for a deleted account gets is not pinned down,
+// and a 401 alone does not tell it from a changed password, so
+// ErrUserNotFound or a 404 for the profile decides.
+func accountGone(u *factory.User, loginErr error) bool {
+	if isGone(loginErr) || strings.Contains(loginErr.Error(), custom_errors.ErrUserNotFound.Error()) {
+		return true
+	}
+	_, err := u.Users.GetUserByID(u.ID)
+	return isGone(err)
+}
+
+// isGone reports whether err means the entity no longer exists.
+func isGone(err error) bool {
+	return client.StatusCode(err) == http.StatusNotFound
+}
+
+func wrap(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf(format+": %w", append(args, err)...)
+}
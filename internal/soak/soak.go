@@ -0,0 +1,312 @@
+// Package soak runs the load mix for hours, samples latency, error rates and
+// the runner's own resources in fixed windows, and flags upward drift
+// between the early and the late part of the run.
+package soak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/latency"
+	"github.com/Soloda1/pinstack-system-tests/internal/load"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+)
+
+// Point is one sampling window of the time series.
+type Point struct {
+	Window    int       `json:"window"`
+	Start     time.Time `json:"start"`
+	ElapsedS  float64   `json:"elapsed_s"`
+	Requests  int       `json:"requests"`
+	Errors    int       `json:"errors"`
+	ErrorRate float64   `json:"error_rate"`
+	P50Ms     float64   `json:"p50_ms"`
+	P95Ms     float64   `json:"p95_ms"`
+	P99Ms     float64   `json:"p99_ms"`
+	// UnreadChecks counts unread-count reads compared with the model and
+	// UnreadDiverged those outside it.
+	UnreadChecks   int `json:"unread_checks"`
+	UnreadDiverged int `json:"unread_diverged"`
+	// Goroutines and HeapBytes describe the runner process, to tell client
+	// leaks apart from gateway drift.
+	Goroutines int    `json:"goroutines"`
+	HeapBytes  uint64 `json:"heap_bytes"`
+}
+
+// Collector aggregates samples into windows. Observe may be called from many
+// goroutines.
+type Collector struct {
+	mu        sync.Mutex
+	runStart  time.Time
+	winStart  time.Time
+	window    int
+	latencies []time.Duration
+	errors    int
+	checks    int
+	diverged  int
+}
+
+func NewCollector(start time.Time) *Collector {
+	return &Collector{runStart: start, winStart: start}
+}
+
+// Observe adds a sample to the current window.
+func (c *Collector) Observe(s load.Sample) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.latencies = append(c.latencies, s.Latency)
+	if s.Err != nil {
+		c.errors++
+	}
+	if s.Endpoint == "GET /v1/notification/unread-count" && s.Err == nil {
+		c.checks++
+		if s.Diverged {
+			c.diverged++
+		}
+	}
+}
+
+// Flush closes the current window at now and returns it.
+func (c *Collector) Flush(now time.Time) Point {
+	c.mu.Lock()
+	sum := latency.Summarize(c.latencies)
+	p := Point{
+		Window:         c.window,
+		Start:          c.winStart,
+		ElapsedS:       now.Sub(c.runStart).Seconds(),
+		Requests:       len(c.latencies),
+		Errors:         c.errors,
+		P50Ms:          ms(sum.P50),
+		P95Ms:          ms(sum.P95),
+		P99Ms:          ms(sum.P99),
+		UnreadChecks:   c.checks,
+		UnreadDiverged: c.diverged,
+	}
+	c.window++
+	c.winStart = now
+	c.latencies = c.latencies[:0]
+	c.errors, c.checks, c.diverged = 0, 0, 0
+	c.mu.Unlock()
+
+	if p.Requests > 0 {
+		p.ErrorRate = float64(p.Errors) / float64(p.Requests)
+	}
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	p.Goroutines = runtime.NumGoroutine()
+	p.HeapBytes = mem.HeapAlloc
+	return p
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// Thresholds bound the drift a run may show; see config.Soak.
+type Thresholds struct {
+	P95Growth       float64
+	ErrorRateGrowth float64
+	ResourceGrowth  float64
+}
+
+func ThresholdsFrom(s config.Soak) Thresholds {
+	return Thresholds{P95Growth: s.P95Growth, ErrorRateGrowth: s.ErrorRateGrowth, ResourceGrowth: s.ResourceGrowth}
+}
+
+// Verdict is the outcome of a run. Early and late values are taken over the
+// first and the last third of the windows.
+type Verdict struct {
+	Pass     bool
+	Windows  int
+	Findings []string
+
+	EarlyP95Ms, LateP95Ms           float64
+	EarlyErrorRate, LateErrorRate   float64
+	EarlyGoroutines, LateGoroutines float64
+	EarlyHeapBytes, LateHeapBytes   float64
+	UnreadChecks, UnreadDiverged    int
+	FirstDivergedWindow             int
+}
+
+// minWindows is the fewest windows drift can be judged on.
+const minWindows = 3
+
+// Analyze compares the late part of the run with the early part. Median
+// per-window values are compared, so one slow window does not decide the
+// verdict; any unread count outside the model fails it outright.
+func Analyze(points []Point, th Thresholds) Verdict {
+	v := Verdict{Pass: true, Windows: len(points), FirstDivergedWindow: -1}
+	for _, p := range points {
+		v.UnreadChecks += p.UnreadChecks
+		v.UnreadDiverged += p.UnreadDiverged
+		if p.UnreadDiverged > 0 && v.FirstDivergedWindow < 0 {
+			v.FirstDivergedWindow = p.Window
+		}
+	}
+	if v.UnreadDiverged > 0 {
+		v.fail("unread counts diverged from the model in %d of %d checks, first in window %d",
+			v.UnreadDiverged, v.UnreadChecks, v.FirstDivergedWindow)
+	}
+
+	if len(points) < minWindows {
+		v.Findings = append(v.Findings, fmt.Sprintf("only %d windows, drift not judged", len(points)))
+		return v
+	}
+
+	third := len(points) / 3
+	early, late := points[:third], points[len(points)-third:]
+
+	v.EarlyP95Ms = median(early, func(p Point) float64 { return p.P95Ms })
+	v.LateP95Ms = median(late, func(p Point) float64 { return p.P95Ms })
+	v.EarlyErrorRate, v.LateErrorRate = errorRate(early), errorRate(late)
+	v.EarlyGoroutines = median(early, func(p Point) float64 { return float64(p.Goroutines) })
+	v.LateGoroutines = median(late, func(p Point) float64 { return float64(p.Goroutines) })
+	v.EarlyHeapBytes = median(early, func(p Point) float64 { return float64(p.HeapBytes) })
+	v.LateHeapBytes = median(late, func(p Point) float64 { return float64(p.HeapBytes) })
+
+	if grew(v.EarlyP95Ms, v.LateP95Ms, th.P95Growth) {
+		v.fail("p95 grew from %.1fms to %.1fms (limit x%.2f)", v.EarlyP95Ms, v.LateP95Ms, th.P95Growth)
+	}
+	if v.LateErrorRate-v.EarlyErrorRate > th.ErrorRateGrowth {
+		v.fail("error rate grew from %.2f%% to %.2f%% (limit +%.2f%%)",
+			100*v.EarlyErrorRate, 100*v.LateErrorRate, 100*th.ErrorRateGrowth)
+	}
+	if grew(v.EarlyGoroutines, v.LateGoroutines, th.ResourceGrowth) {
+		v.fail("runner goroutines grew from %.0f to %.0f (limit x%.2f)", v.EarlyGoroutines, v.LateGoroutines, th.ResourceGrowth)
+	}
+	if grew(v.EarlyHeapBytes, v.LateHeapBytes, th.ResourceGrowth) {
+		v.fail("runner heap grew from %.1fMiB to %.1fMiB (limit x%.2f)",
+			v.EarlyHeapBytes/(1<<20), v.LateHeapBytes/(1<<20), th.ResourceGrowth)
+	}
+	return v
+}
+
+func (v *Verdict) fail(format string, args ...any) {
+	v.Pass = false
+	v.Findings = append(v.Findings, fmt.Sprintf(format, args...))
+}
+
+func (v Verdict) String() string {
+	var b strings.Builder
+	status := "PASS"
+	if !v.Pass {
+		status = "FAIL"
+	}
+	fmt.Fprintf(&b, "soak verdict: %s over %d windows\n", status, v.Windows)
+	fmt.Fprintf(&b, "  p95:        %.1fms -> %.1fms\n", v.EarlyP95Ms, v.LateP95Ms)
+	fmt.Fprintf(&b, "  error rate: %.2f%% -> %.2f%%\n", 100*v.EarlyErrorRate, 100*v.LateErrorRate)
+	fmt.Fprintf(&b, "  goroutines: %.0f -> %.0f, heap %.1fMiB -> %.1fMiB\n",
+		v.EarlyGoroutines, v.LateGoroutines, v.EarlyHeapBytes/(1<<20), v.LateHeapBytes/(1<<20))
+	fmt.Fprintf(&b, "  unread:     %d of %d checks outside the model\n", v.UnreadDiverged, v.UnreadChecks)
+	for _, f := range v.Findings {
+		fmt.Fprintf(&b, "  - %s\n", f)
+	}
+	return b.String()
+}
+
+// grew reports whether late exceeds early by more than factor. Without an
+// early value there is nothing to compare against.
+func grew(early, late, factor float64) bool {
+	if early <= 0 {
+		return false
+	}
+	return late/early > factor
+}
+
+func median(points []Point, value func(Point) float64) float64 {
+	if len(points) == 0 {
+		return 0
+	}
+	vals := make([]float64, len(points))
+	for i, p := range points {
+		vals[i] = value(p)
+	}
+	sort.Float64s(vals)
+	return vals[len(vals)/2]
+}
+
+func errorRate(points []Point) float64 {
+	var req, errs int
+	for _, p := range points {
+		req += p.Requests
+		errs += p.Errors
+	}
+	if req == 0 {
+		return 0
+	}
+	return float64(errs) / float64(req)
+}
+
+// Options controls a soak run.
+type Options struct {
+	Duration   time.Duration
+	Window     time.Duration
+	Thresholds Thresholds
+}
+
+// Run drives r for opts.Duration, writes one JSON line per window to out as
+// each window closes and returns the verdict over all windows. The VUs must
+// already be started; an Observe already set on r still gets every sample.
+func Run(ctx context.Context, r *load.Runner, opts Options, out io.Writer, log *logger.Logger) (Verdict, error) {
+	ctx, cancel := context.WithTimeout(ctx, opts.Duration)
+	defer cancel()
+
+	c := NewCollector(time.Now())
+	if observe := r.Observe; observe != nil {
+		r.Observe = func(s load.Sample) {
+			c.Observe(s)
+			observe(s)
+		}
+	} else {
+		r.Observe = c.Observe
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- r.Run(ctx) }()
+
+	enc := json.NewEncoder(out)
+	var points []Point
+	record := func(p Point) error {
+		points = append(points, p)
+		log.Info("Soak window",
+			"window", p.Window,
+			"requests", p.Requests,
+			"error_rate", p.ErrorRate,
+			"p95_ms", p.P95Ms,
+			"unread_diverged", p.UnreadDiverged,
+			"goroutines", p.Goroutines)
+		return enc.Encode(p)
+	}
+
+	ticker := time.NewTicker(opts.Window)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			if err := record(c.Flush(now)); err != nil {
+				cancel()
+				<-done
+				return Verdict{}, fmt.Errorf("write time series: %w", err)
+			}
+		case err := <-done:
+			if err != nil {
+				return Verdict{}, err
+			}
+			// A trailing partial window is kept only if it saw traffic.
+			if p := c.Flush(time.Now()); p.Requests > 0 {
+				if err := record(p); err != nil {
+					return Verdict{}, fmt.Errorf("write time series: %w", err)
+				}
+			}
+			return Analyze(points, opts.Thresholds), nil
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package soak
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/load"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var th = Thresholds{P95Growth: 1.5, ErrorRateGrowth: 0.01, ResourceGrowth: 2}
+
+func steady(n int) []Point {
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = Point{Window: i, Requests: 1000, Errors: 2, P95Ms: 40, Goroutines: 50, HeapBytes: 8 << 20}
+	}
+	return points
+}
+
+func TestAnalyze(t *testing.T) {
+	t.Run("steady run passes", func(t *testing.T) {
+		v := Analyze(steady(9), th)
+		assert.True(t, v.Pass, v.String())
+		assert.Equal(t, 40.0, v.EarlyP95Ms)
+	})
+
+	t.Run("one slow window does not fail", func(t *testing.T) {
+		points := steady(9)
+		points[8].P95Ms = 400
+		assert.True(t, Analyze(points, th).Pass)
+	})
+
+	t.Run("p95 drift", func(t *testing.T) {
+		points := steady(9)
+		for i := 6; i < 9; i++ {
+			points[i].P95Ms = 70
+		}
+		v := Analyze(points, th)
+		assert.False(t, v.Pass)
+		assert.Contains(t, v.String(), "p95 grew from 40.0ms to 70.0ms")
+	})
+
+	t.Run("errors creeping up", func(t *testing.T) {
+		points := steady(9)
+		for i := 6; i < 9; i++ {
+			points[i].Errors = 30
+		}
+		v := Analyze(points, th)
+		assert.False(t, v.Pass)
+		assert.Contains(t, v.Findings[0], "error rate grew")
+	})
+
+	t.Run("runner leak", func(t *testing.T) {
+		points := steady(9)
+		for i := 6; i < 9; i++ {
+			points[i].Goroutines = 500
+		}
+		assert.False(t, Analyze(points, th).Pass)
+	})
+
+	t.Run("unread divergence fails even in short runs", func(t *testing.T) {
+		points := steady(2)
+		points[1].UnreadChecks, points[1].UnreadDiverged = 10, 1
+		v := Analyze(points, th)
+		assert.False(t, v.Pass)
+		assert.Equal(t, 1, v.FirstDivergedWindow)
+	})
+}
+
+func TestCollectorFlush(t *testing.T) {
+	start := time.Now()
+	c := NewCollector(start)
+	c.Observe(load.Sample{Endpoint: "GET /v1/users/{id}", Latency: 10 * time.Millisecond})
+	c.Observe(load.Sample{Endpoint: "GET /v1/users/{id}", Latency: 30 * time.Millisecond, Err: errors.New("boom")})
+	c.Observe(load.Sample{Endpoint: "GET /v1/notification/unread-count", Latency: 20 * time.Millisecond, Diverged: true})
+
+	p := c.Flush(start.Add(time.Minute))
+	assert.Equal(t, 0, p.Window)
+	assert.Equal(t, 3, p.Requests)
+	assert.Equal(t, 1, p.Errors)
+	assert.InDelta(t, 1.0/3, p.ErrorRate, 1e-9)
+	assert.Equal(t, 30.0, p.P95Ms)
+	assert.Equal(t, 1, p.UnreadChecks)
+	assert.Equal(t, 1, p.UnreadDiverged)
+	assert.Positive(t, p.Goroutines)
+
+	next := c.Flush(start.Add(2 * time.Minute))
+	assert.Equal(t, 1, next.Window)
+	assert.Zero(t, next.Requests)
+	assert.Equal(t, 120.0, next.ElapsedS)
+}
+
+func TestRunWritesTimeSeries(t *testing.T) {
+	cfg := &config.Config{API: config.API{BaseURL: "http://127.0.0.1:0", Timeout: time.Second}}
+	u := factory.Attach(context.Background(), cfg, logger.ForTest(t), fixtures.User{ID: 1, Username: "soak"}, "pw", "", "")
+	r := &load.Runner{
+		Mix:   load.Mix{{Name: "noop", Weight: 1, Do: func(*load.VU) error { return nil }}},
+		VUs:   []*load.VU{load.NewVU(0, u, nil, fixtures.NewGenerator(1), nil)},
+		Think: time.Millisecond,
+	}
+
+	var out bytes.Buffer
+	v, err := Run(context.Background(), r, Options{Duration: 120 * time.Millisecond, Window: 25 * time.Millisecond, Thresholds: th}, &out, logger.ForTest(t))
+	require.NoError(t, err)
+
+	var lines int
+	sc := bufio.NewScanner(&out)
+	for sc.Scan() {
+		var p Point
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &p))
+		assert.Equal(t, lines, p.Window)
+		lines++
+	}
+	assert.GreaterOrEqual(t, lines, 3)
+	assert.Equal(t, lines, v.Windows)
+}
@@ -0,0 +1,251 @@
+package socialgraph
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Config describes the shape of a generated follow graph. Nodes are indexed
+// 0..Users-1; the first Celebrities nodes are the celebrities.
+type Config struct {
+	Seed  int64
+	Users int
+
+	// FollowsPerUser is how many accounts each user follows by preferential
+	// attachment, which yields a power-law follower distribution.
+	FollowsPerUser int
+
+	// Celebrities are followed by CelebrityReach (0..1) of all other users.
+	Celebrities    int
+	CelebrityReach float64
+
+	// Cliques groups of CliqueSize users all follow each other.
+	Cliques    int
+	CliqueSize int
+
+	// Chains of ChainLength users follow the next user in the chain.
+	Chains      int
+	ChainLength int
+}
+
+// DefaultConfig is a small graph with every shape present, sized for a
+// functional run.
+func DefaultConfig(seed int64) Config {
+	return Config{
+		Seed:           seed,
+		Users:          40,
+		FollowsPerUser: 2,
+		Celebrities:    2,
+		CelebrityReach: 0.6,
+		Cliques:        2,
+		CliqueSize:     4,
+		Chains:         2,
+		ChainLength:    5,
+	}
+}
+
+// ScaledConfig keeps the shape mix of DefaultConfig for a graph of any size:
+// a couple of celebrities and one clique and one chain per twenty users.
+func ScaledConfig(seed int64, users int) Config {
+	cfg := DefaultConfig(seed)
+	cfg.Users = users
+	cfg.Celebrities = min(cfg.Celebrities, users)
+	cfg.Cliques = users / 20
+	cfg.Chains = users / 20
+	return cfg
+}
+
+func (c Config) validate() error {
+	switch {
+	case c.Users <= 0:
+		return fmt.Errorf("users must be positive, got %d", c.Users)
+	case c.FollowsPerUser < 0:
+		return fmt.Errorf("follows per user must not be negative, got %d", c.FollowsPerUser)
+	case c.Celebrities < 0 || c.Celebrities > c.Users:
+		return fmt.Errorf("celebrities must be in 0..%d, got %d", c.Users, c.Celebrities)
+	case c.CelebrityReach < 0 || c.CelebrityReach > 1:
+		return fmt.Errorf("celebrity reach must be in 0..1, got %v", c.CelebrityReach)
+	case c.Cliques < 0 || c.CliqueSize < 0 || c.Cliques*c.CliqueSize > c.Users:
+		return fmt.Errorf("%d cliques of %d do not fit into %d users", c.Cliques, c.CliqueSize, c.Users)
+	case c.Chains < 0 || c.ChainLength < 0 || c.Chains*c.ChainLength > c.Users:
+		return fmt.Errorf("%d chains of %d do not fit into %d users", c.Chains, c.ChainLength, c.Users)
+	}
+	return nil
+}
+
+// Edge is a follow relation between two node indices.
+type Edge struct {
+	Follower int
+	Followee int
+}
+
+// Graph is a generated follow graph. It is built once and read-only after.
+type Graph struct {
+	cfg       Config
+	edges     []Edge
+	seen      map[Edge]struct{}
+	followers [][]int
+	followees [][]int
+}
+
+// Generate builds a graph for cfg. The same config always yields the same
+// graph.
+func Generate(cfg Config) (*Graph, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("socialgraph: %w", err)
+	}
+
+	g := &Graph{
+		cfg:       cfg,
+		seen:      make(map[Edge]struct{}),
+		followers: make([][]int, cfg.Users),
+		followees: make([][]int, cfg.Users),
+	}
+	r := rand.New(rand.NewSource(cfg.Seed))
+
+	g.preferentialAttachment(r)
+	g.celebrities(r)
+	g.cliques(r)
+	g.chains(r)
+
+	for i := range g.followers {
+		sort.Ints(g.followers[i])
+		sort.Ints(g.followees[i])
+	}
+	return g, nil
+}
+
+func (g *Graph) add(follower, followee int) bool {
+	e := Edge{Follower: follower, Followee: followee}
+	if follower == followee {
+		return false
+	}
+	if _, ok := g.seen[e]; ok {
+		return false
+	}
+	g.seen[e] = struct{}{}
+	g.edges = append(g.edges, e)
+	g.followers[followee] = append(g.followers[followee], follower)
+	g.followees[follower] = append(g.followees[follower], followee)
+	return true
+}
+
+// preferentialAttachment lets every user follow FollowsPerUser earlier users
+// picked with probability proportional to their follower count plus one.
+func (g *Graph) preferentialAttachment(r *rand.Rand) {
+	// targets holds each node once plus once per follower it has, so a
+	// uniform pick from it is a pick weighted by in-degree + 1.
+	var targets []int
+	for n := 0; n < g.cfg.Users; n++ {
+		want := g.cfg.FollowsPerUser
+		if want > n {
+			want = n
+		}
+		for attempts := 0; want > 0 && attempts < 10*g.cfg.FollowsPerUser; attempts++ {
+			followee := targets[r.Intn(len(targets))]
+			if g.add(n, followee) {
+				targets = append(targets, followee)
+				want--
+			}
+		}
+		targets = append(targets, n)
+	}
+}
+
+func (g *Graph) celebrities(r *rand.Rand) {
+	for c := 0; c < g.cfg.Celebrities; c++ {
+		for _, n := range r.Perm(g.cfg.Users) {
+			if r.Float64() < g.cfg.CelebrityReach {
+				g.add(n, c)
+			}
+		}
+	}
+}
+
+// cliques and chains use disjoint runs of non-celebrity users taken from a
+// shuffled order.
+func (g *Graph) cliques(r *rand.Rand) {
+	order := g.regularUsers(r)
+	for c := 0; c < g.cfg.Cliques && (c+1)*g.cfg.CliqueSize <= len(order); c++ {
+		members := order[c*g.cfg.CliqueSize : (c+1)*g.cfg.CliqueSize]
+		for _, a := range members {
+			for _, b := range members {
+				g.add(a, b)
+			}
+		}
+	}
+}
+
+func (g *Graph) chains(r *rand.Rand) {
+	order := g.regularUsers(r)
+	for c := 0; c < g.cfg.Chains && (c+1)*g.cfg.ChainLength <= len(order); c++ {
+		members := order[c*g.cfg.ChainLength : (c+1)*g.cfg.ChainLength]
+		for i := 0; i+1 < len(members); i++ {
+			g.add(members[i], members[i+1])
+		}
+	}
+}
+
+func (g *Graph) regularUsers(r *rand.Rand) []int {
+	var users []int
+	for _, n := range r.Perm(g.cfg.Users) {
+		if n >= g.cfg.Celebrities {
+			users = append(users, n)
+		}
+	}
+	return users
+}
+
+// Config returns the config the graph was generated from.
+func (g *Graph) Config() Config {
+	return g.cfg
+}
+
+// Size returns the number of nodes.
+func (g *Graph) Size() int {
+	return g.cfg.Users
+}
+
+// Edges returns all follow relations in generation order.
+func (g *Graph) Edges() []Edge {
+	return append([]Edge(nil), g.edges...)
+}
+
+// Followers returns the sorted followers of node n.
+func (g *Graph) Followers(n int) []int {
+	return append([]int(nil), g.followers[n]...)
+}
+
+// Followees returns the sorted accounts node n follows.
+func (g *Graph) Followees(n int) []int {
+	return append([]int(nil), g.followees[n]...)
+}
+
+// FollowerCount is the expected follower total of node n.
+func (g *Graph) FollowerCount(n int) int {
+	return len(g.followers[n])
+}
+
+// FolloweeCount is the expected followee total of node n.
+func (g *Graph) FolloweeCount(n int) int {
+	return len(g.followees[n])
+}
+
+// Follows reports whether follower follows followee.
+func (g *Graph) Follows(follower, followee int) bool {
+	_, ok := g.seen[Edge{Follower: follower, Followee: followee}]
+	return ok
+}
+
+// MostFollowed returns node indices ordered by follower count, highest first.
+func (g *Graph) MostFollowed() []int {
+	nodes := make([]int, g.cfg.Users)
+	for i := range nodes {
+		nodes[i] = i
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return len(g.followers[nodes[i]]) > len(g.followers[nodes[j]])
+	})
+	return nodes
+}
@@ -0,0 +1,155 @@
+package socialgraph
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateDeterministic(t *testing.T) {
+	a, err := Generate(DefaultConfig(42))
+	require.NoError(t, err)
+	b, err := Generate(DefaultConfig(42))
+	require.NoError(t, err)
+	c, err := Generate(DefaultConfig(43))
+	require.NoError(t, err)
+
+	assert.Equal(t, a.Edges(), b.Edges())
+	assert.NotEqual(t, a.Edges(), c.Edges())
+}
+
+func TestGenerateCountsMatchEdges(t *testing.T) {
+	g, err := Generate(DefaultConfig(7))
+	require.NoError(t, err)
+
+	followers := make(map[int]int)
+	followees := make(map[int]int)
+	seen := make(map[Edge]bool)
+	for _, e := range g.Edges() {
+		assert.NotEqual(t, e.Follower, e.Followee, "self follow")
+		assert.False(t, seen[e], "duplicate edge %v", e)
+		seen[e] = true
+		followers[e.Followee]++
+		followees[e.Follower]++
+	}
+
+	for n := 0; n < g.Size(); n++ {
+		assert.Equal(t, followers[n], g.FollowerCount(n), "followers of %d", n)
+		assert.Equal(t, followees[n], g.FolloweeCount(n), "followees of %d", n)
+	}
+}
+
+func TestGenerateShapes(t *testing.T) {
+	cfg := Config{
+		Seed:           1,
+		Users:          200,
+		FollowsPerUser: 2,
+		Celebrities:    1,
+		CelebrityReach: 0.9,
+		Cliques:        1,
+		CliqueSize:     5,
+		Chains:         1,
+		ChainLength:    6,
+	}
+	g, err := Generate(cfg)
+	require.NoError(t, err)
+
+	top := g.MostFollowed()
+	assert.Equal(t, 0, top[0], "celebrity should be the most followed node")
+	assert.Greater(t, g.FollowerCount(0), cfg.Users/2)
+
+	median := g.FollowerCount(top[len(top)/2])
+	assert.Greater(t, g.FollowerCount(top[1]), 3*median+1, "preferential attachment should produce hubs")
+}
+
+func TestGenerateCliquesAndChains(t *testing.T) {
+	cfg := Config{Seed: 3, Users: 12, Cliques: 1, CliqueSize: 4, Chains: 1, ChainLength: 8}
+	g, err := Generate(cfg)
+	require.NoError(t, err)
+
+	var clique []int
+	for n := 0; n < g.Size(); n++ {
+		if g.FolloweeCount(n) >= cfg.CliqueSize-1 {
+			clique = append(clique, n)
+		}
+	}
+	require.Len(t, clique, cfg.CliqueSize)
+	for _, a := range clique {
+		for _, b := range clique {
+			if a != b {
+				assert.True(t, g.Follows(a, b), "%d should follow %d in clique", a, b)
+			}
+		}
+	}
+
+	assert.Len(t, g.Edges(), cfg.CliqueSize*(cfg.CliqueSize-1)+cfg.ChainLength-1)
+}
+
+func TestGenerateInvalidConfig(t *testing.T) {
+	_, err := Generate(Config{Users: 0})
+	assert.Error(t, err)
+
+	_, err = Generate(Config{Users: 5, Cliques: 2, CliqueSize: 3})
+	assert.Error(t, err)
+
+	_, err = Generate(Config{Users: 5, CelebrityReach: 2})
+	assert.Error(t, err)
+}
+
+func TestMaterialize(t *testing.T) {
+	g, err := Generate(DefaultConfig(11))
+	require.NoError(t, err)
+
+	var (
+		mu      sync.Mutex
+		created = make(map[Edge]bool)
+		active  = make(map[int]bool)
+		running atomic.Int32
+		peak    atomic.Int32
+	)
+	follow := func(ctx context.Context, follower, followee int) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		mu.Lock()
+		assert.False(t, active[follower], "follower %d used concurrently", follower)
+		active[follower] = true
+		mu.Unlock()
+
+		runtime.Gosched()
+
+		mu.Lock()
+		active[follower] = false
+		created[Edge{Follower: follower, Followee: followee}] = true
+		mu.Unlock()
+		return nil
+	}
+
+	require.NoError(t, g.Materialize(context.Background(), 3, follow))
+	assert.Len(t, created, len(g.Edges()))
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+}
+
+func TestMaterializeCollectsErrors(t *testing.T) {
+	g, err := Generate(Config{Seed: 1, Users: 4, Chains: 1, ChainLength: 4})
+	require.NoError(t, err)
+
+	boom := errors.New("boom")
+	err = g.Materialize(context.Background(), 2, func(ctx context.Context, follower, followee int) error {
+		return boom
+	})
+	require.ErrorIs(t, err, boom)
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 3)
+}
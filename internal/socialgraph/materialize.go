@@ -0,0 +1,79 @@
+package socialgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+)
+
+// FollowFunc creates one follow relation between two node indices.
+type FollowFunc func(ctx context.Context, follower, followee int) error
+
+// Materialize creates every edge of g through follow. Edges are grouped by
+// follower and each follower's edges run sequentially, so a per-user client is
+// never used from two goroutines; at most concurrency followers run at once.
+// It keeps going after failures and returns all of them joined; it stops
+// early only when ctx is cancelled.
+func (g *Graph) Materialize(ctx context.Context, concurrency int, follow FollowFunc) error {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sem  = make(chan struct{}, concurrency)
+	)
+	fail := func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}
+
+	for follower, followees := range g.followees {
+		if len(followees) == 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return errors.Join(append(errs, ctx.Err())...)
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(follower int, followees []int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			for _, followee := range followees {
+				if ctx.Err() != nil {
+					return
+				}
+				if err := follow(ctx, follower, followee); err != nil {
+					fail(fmt.Errorf("edge %d->%d: %w", follower, followee, err))
+				}
+			}
+		}(follower, followees)
+	}
+
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// FactoryFollow returns a FollowFunc that follows through the relation client
+// of the factory user at each node index, so every edge is unfollowed when
+// the test ends.
+func FactoryFollow(f *factory.Factory, users []*factory.User) FollowFunc {
+	return func(ctx context.Context, follower, followee int) error {
+		return f.Follow(ctx, users[follower], users[followee])
+	}
+}
@@ -0,0 +1,349 @@
+// Package sweep removes test data that runs left behind when they died before
+// their cleanup. Users are found by the run marker the fixtures generators put
+// on every user, and are deleted together with their posts, follows and
+// notifications.
+package sweep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/factory"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+)
+
+const (
+	pageSize = 100
+	// DefaultMinAge keeps the sweeper away from users of a run that is still
+	// going.
+	DefaultMinAge = time.Hour
+)
+
+// Options selects what to sweep.
+type Options struct {
+	// RunID limits the sweep to one run; empty sweeps every run.
+	RunID string
+	// Queries are extra SearchUsers queries for users whose username lost
+	// the marker prefix; they are still only swept if their bio carries the
+	// run tag.
+	Queries []string
+	// MinAge skips users created more recently than this.
+	MinAge      time.Duration
+	DryRun      bool
+	Concurrency int
+}
+
+// Leftover is one marked user and what it still owns.
+type Leftover struct {
+	User          fixtures.User
+	RunID         string
+	Posts         []int64
+	Followees     []int64
+	Notifications []int64
+}
+
+// Report lists the leftovers found and, unless it was a dry run, how many of
+// each were deleted.
+type Report struct {
+	DryRun        bool
+	Leftovers     []Leftover
+	Users         int
+	Posts         int
+	Follows       int
+	Notifications int
+}
+
+type Sweeper struct {
+	cfg *config.Config
+	log *logger.Logger
+	now func() time.Time
+}
+
+func New(cfg *config.Config, log *logger.Logger) *Sweeper {
+	return &Sweeper{cfg: cfg, log: log, now: time.Now}
+}
+
+// Sweep finds leftovers and deletes them unless opts.DryRun is set. Per-user
+// failures, including users that could not be inspected, do not stop the
+// sweep; they are returned joined.
+func (s *Sweeper) Sweep(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = s.cfg.Test.Concurrent
+	}
+
+	leftovers, findErr := s.Find(ctx, opts)
+	report := &Report{DryRun: opts.DryRun, Leftovers: leftovers}
+	if opts.DryRun {
+		return report, findErr
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = []error{findErr}
+		sem  = make(chan struct{}, opts.Concurrency)
+	)
+	for _, l := range leftovers {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(l Leftover) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			counts, err := s.delete(ctx, l)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Notifications += counts.Notifications
+			report.Follows += counts.Follows
+			report.Posts += counts.Posts
+			report.Users += counts.Users
+			if err != nil {
+				errs = append(errs, fmt.Errorf("user %d (%s): %w", l.User.ID, l.User.Username, err))
+			}
+		}(l)
+	}
+	wg.Wait()
+
+	s.log.Info("Sweep finished",
+		slog.Int("users", report.Users),
+		slog.Int("posts", report.Posts),
+		slog.Int("follows", report.Follows),
+		slog.Int("notifications", report.Notifications),
+	)
+	return report, errors.Join(errs...)
+}
+
+// Find lists the leftovers without deleting anything.
+func (s *Sweeper) Find(ctx context.Context, opts Options) ([]Leftover, error) {
+	if opts.MinAge == 0 {
+		opts.MinAge = DefaultMinAge
+	}
+	cutoff := s.now().Add(-opts.MinAge)
+
+	users, errs, err := s.search(ctx, append([]string{fixtures.UsernamePrefix(opts.RunID)}, opts.Queries...))
+	if err != nil {
+		return nil, err
+	}
+
+	var leftovers []Leftover
+	for _, u := range users {
+		runID, ok := fixtures.RunIDOf(u)
+		if !ok || (opts.RunID != "" && runID != opts.RunID) {
+			continue
+		}
+		switch {
+		case fixtures.Kept(u):
+			s.log.Debug("Skipping user of a persistent dataset", slog.Int64("user_id", u.ID), slog.String("run_id", runID))
+			continue
+		case u.CreatedAt.IsZero():
+			s.log.Warn("Skipping user of unknown age", slog.Int64("user_id", u.ID), slog.String("run_id", runID))
+			continue
+		case u.CreatedAt.After(cutoff):
+			s.log.Debug("Skipping recent user", slog.Int64("user_id", u.ID), slog.String("run_id", runID))
+			continue
+		}
+
+		l, err := s.inspect(ctx, u, runID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("inspect user %d (%s): %w", u.ID, u.Username, err))
+			continue
+		}
+		leftovers = append(leftovers, l)
+	}
+	return leftovers, errors.Join(errs...)
+}
+
+// search runs every query through SearchUsers and returns the profiles of
+// the distinct users, ordered by ID. Search results need not carry the bio or
+// the creation time, so every profile is read before it is judged; users whose
+// profile cannot be read are left out and reported in lookupErrs. Searching
+// needs an authenticated caller but no particular user, so the sweeper
+// registers one of its own for the duration of the search.
+func (s *Sweeper) search(ctx context.Context, queries []string) (users []fixtures.User, lookupErrs []error, err error) {
+	searcher, err := s.searcher(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if delErr := searcher.Users.DeleteUser(searcher.ID); delErr != nil {
+			err = errors.Join(err, fmt.Errorf("delete search user %d: %w", searcher.ID, delErr))
+		}
+	}()
+
+	seen := make(map[int64]fixtures.User)
+	for _, q := range queries {
+		for page := 1; ; page++ {
+			resp, err := searcher.Users.SearchUsers(q, page, pageSize)
+			if err != nil {
+				return nil, nil, fmt.Errorf("search users %q: %w", q, err)
+			}
+			for _, u := range resp.Users {
+				if u.ID != searcher.ID {
+					seen[u.ID] = u
+				}
+			}
+			if len(resp.Users) < pageSize || page*pageSize >= resp.Total {
+				break
+			}
+		}
+	}
+
+	users = make([]fixtures.User, 0, len(seen))
+	for id := range seen {
+		profile, err := searcher.Users.GetUserByID(id)
+		if err != nil {
+			lookupErrs = append(lookupErrs, fmt.Errorf("get user %d: %w", id, err))
+			continue
+		}
+		users = append(users, *profile)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users, lookupErrs, nil
+}
+
+func (s *Sweeper) inspect(ctx context.Context, u fixtures.User, runID string) (Leftover, error) {
+	l := Leftover{User: u, RunID: runID}
+
+	sess, err := s.session(ctx, u, runID)
+	if err != nil {
+		return l, err
+	}
+
+	for offset := 0; ; offset += pageSize {
+		resp, err := sess.Posts.ListPosts(u.ID, time.Time{}, time.Time{}, offset, pageSize)
+		if err != nil {
+			return l, fmt.Errorf("list posts: %w", err)
+		}
+		for _, p := range resp.Posts {
+			l.Posts = append(l.Posts, p.ID)
+		}
+		if len(resp.Posts) < pageSize {
+			break
+		}
+	}
+
+	for page := 1; ; page++ {
+		resp, err := sess.Relations.GetFollowees(u.ID, page, pageSize)
+		if err != nil {
+			return l, fmt.Errorf("get followees: %w", err)
+		}
+		for _, f := range resp.Followees {
+			l.Followees = append(l.Followees, f.ID)
+		}
+		if len(resp.Followees) < pageSize {
+			break
+		}
+	}
+
+	for page := 1; ; page++ {
+		resp, err := sess.Notifications.GetUserNotificationFeed(u.ID, page, pageSize)
+		if err != nil {
+			if client.StatusCode(err) == http.StatusNotFound {
+				break
+			}
+			return l, fmt.Errorf("get notification feed: %w", err)
+		}
+		for _, n := range resp.Notifications {
+			l.Notifications = append(l.Notifications, n.ID)
+		}
+		if len(resp.Notifications) < pageSize || page >= resp.TotalPages {
+			break
+		}
+	}
+
+	return l, nil
+}
+
+// delete removes what l owns and then the user, in that order so no
+// relation or notification outlives its user.
+func (s *Sweeper) delete(ctx context.Context, l Leftover) (Report, error) {
+	var (
+		counts Report
+		errs   []error
+	)
+
+	sess, err := s.session(ctx, l.User, l.RunID)
+	if err != nil {
+		return counts, err
+	}
+
+	try := func(counter *int, err error, format string, args ...any) {
+		switch {
+		case err == nil:
+			*counter++
+		case client.StatusCode(err) == http.StatusNotFound:
+		default:
+			errs = append(errs, fmt.Errorf(format+": %w", append(args, err)...))
+		}
+	}
+
+	for _, id := range l.Notifications {
+		_, err := sess.Notifications.RemoveNotification(id)
+		try(&counts.Notifications, err, "remove notification %d", id)
+	}
+	for _, id := range l.Followees {
+		_, err := sess.Relations.Unfollow(id)
+		try(&counts.Follows, err, "unfollow %d", id)
+	}
+	for _, id := range l.Posts {
+		try(&counts.Posts, sess.Posts.DeletePost(id), "delete post %d", id)
+	}
+	try(&counts.Users, sess.Users.DeleteUser(l.User.ID), "delete user")
+
+	return counts, errors.Join(errs...)
+}
+
+// session logs in as u with the password the generators gave every user of
+// run runID. Users that were renamed are tried by email as well. A user whose
+// password a test changed cannot be logged in as and is reported as an error.
+func (s *Sweeper) session(ctx context.Context, u fixtures.User, runID string) (*factory.User, error) {
+	password := fixtures.RunPassword(runID)
+	sess := factory.Attach(ctx, s.cfg, s.log, u, password, "", "")
+
+	var errs []error
+	for _, login := range []string{u.Username, u.Email} {
+		if login == "" {
+			continue
+		}
+		tokens, err := sess.Auth.Login(fixtures.LoginRequest{Login: login, Password: password})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sess.AccessToken = tokens.AccessToken
+		sess.RefreshToken = tokens.RefreshToken
+		sess.API.SetToken(tokens.AccessToken)
+		return sess, nil
+	}
+	return nil, fmt.Errorf("log in: %w", errors.Join(errs...))
+}
+
+// searcher registers a user for the sweeper's own searches. It carries this
+// process's run marker, so a sweep that dies before deleting it leaves a
+// user the next sweep removes.
+func (s *Sweeper) searcher(ctx context.Context) (*factory.User, error) {
+	req := fixtures.NewRegister().Build()
+	sess := factory.Attach(ctx, s.cfg, s.log, fixtures.User{}, req.Password, "", "")
+
+	tokens, err := sess.Auth.Register(*req)
+	if err != nil {
+		return nil, fmt.Errorf("register search user: %w", err)
+	}
+	sess.API.SetToken(tokens.AccessToken)
+
+	profile, err := sess.Users.GetUserByUsername(req.Username)
+	if err != nil {
+		return nil, fmt.Errorf("get search user %s: %w", req.Username, err)
+	}
+	sess.User = *profile
+	return sess, nil
+}
@@ -0,0 +1,205 @@
+package sweep
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/fixtures"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+
+// searcherID is the ID the fake gateway gives the sweeper's own search user.
+const searcherID = 99
+
+// fakeGateway serves a fixed set of users, each owning one post, one followee
+// and one notification, and records every mutating call with the user the
+// caller's token names. Logins succeed only with the run password of the
+// user's run, and never for users whose password a test changed. Search
+// results carry only IDs and names; the rest is in the profiles.
+type fakeGateway struct {
+	mu      sync.Mutex
+	users   []fixtures.User
+	changed map[int64]bool
+	calls   []string
+}
+
+func token(userID int64) string {
+	claims, _ := json.Marshal(map[string]int64{"user_id": userID})
+	return "header." + base64.RawURLEncoding.EncodeToString(claims) + ".signature"
+}
+
+func (g *fakeGateway) login(req fixtures.LoginRequest) (fixtures.LoginResponse, bool) {
+	for _, u := range g.users {
+		if req.Login != u.Username && req.Login != u.Email {
+			continue
+		}
+		runID, ok := fixtures.RunIDOf(u)
+		if !ok || g.changed[u.ID] || req.Password != fixtures.RunPassword(runID) {
+			return fixtures.LoginResponse{}, false
+		}
+		return fixtures.LoginResponse{AccessToken: token(u.ID)}, true
+	}
+	return fixtures.LoginResponse{}, false
+}
+
+func (g *fakeGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/api")
+	write := func(data interface{}) {
+		_ = json.NewEncoder(w).Encode(fixtures.BaseResponse{Status: http.StatusOK, Data: data})
+	}
+
+	switch {
+	case path == "/v1/auth/login":
+		var req fixtures.LoginRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		resp, ok := g.login(req)
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(fixtures.ErrorBody{Status: http.StatusUnauthorized, Message: "invalid credentials"})
+			return
+		}
+		write(resp)
+	case path == "/v1/auth/register":
+		write(fixtures.RegisterResponse{AccessToken: token(searcherID)})
+	case strings.HasPrefix(path, "/v1/users/username/"):
+		write(fixtures.User{ID: searcherID, Username: strings.TrimPrefix(path, "/v1/users/username/")})
+	case path == "/v1/users/search":
+		found := make([]fixtures.User, len(g.users))
+		for i, u := range g.users {
+			found[i] = fixtures.User{ID: u.ID, Username: u.Username, Email: u.Email}
+		}
+		write(fixtures.SearchUsersResponse{Users: found, Total: len(found)})
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/v1/users/"):
+		for _, u := range g.users {
+			if path == "/v1/users/"+strconv.FormatInt(u.ID, 10) {
+				write(u)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(fixtures.ErrorBody{Status: http.StatusNotFound, Message: "user not found"})
+	case path == "/v1/posts/list":
+		write(fixtures.ListPostsResponse{Posts: []fixtures.Post{{ID: 100}}, Total: 1})
+	case strings.HasSuffix(path, "/followees"):
+		write(fixtures.GetFolloweesResponse{Followees: []*fixtures.RelationUser{{ID: 200}}, Total: 1})
+	case path == "/v1/notification/feed":
+		write(fixtures.GetUserNotificationFeedResponse{Notifications: []fixtures.Notification{{ID: 300}}, Total: 1, TotalPages: 1})
+	default:
+		g.calls = append(g.calls, r.Method+" "+path+" as "+tokenUser(r))
+		write(map[string]string{"message": "ok"})
+	}
+}
+
+func tokenUser(r *http.Request) string {
+	parts := strings.Split(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), ".")
+	if len(parts) != 3 {
+		return "?"
+	}
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	var claims struct {
+		UserID json.Number `json:"user_id"`
+	}
+	_ = json.Unmarshal(payload, &claims)
+	return claims.UserID.String()
+}
+
+func newSweeper(t *testing.T, gw *fakeGateway) *Sweeper {
+	srv := httptest.NewServer(gw)
+	t.Cleanup(srv.Close)
+
+	cfg := &config.Config{
+		API:  config.API{BaseURL: srv.URL + "/api", Timeout: time.Second},
+		Test: config.Test{Concurrent: 2},
+	}
+	s := New(cfg, logger.ForTest(t))
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func testUsers() []fixtures.User {
+	old := now.Add(-2 * time.Hour)
+	return []fixtures.User{
+		{ID: 1, Username: fixtures.MarkUsername("run1", "alice"), CreatedAt: old},
+		{ID: 2, Username: "renamed", Email: "renamed@example.com", Bio: fixtures.MarkBio("run1", "bio"), CreatedAt: old},
+		{ID: 3, Username: fixtures.MarkUsername("run2", "bob"), CreatedAt: old},
+		{ID: 4, Username: fixtures.MarkUsername("run1", "fresh"), CreatedAt: now.Add(-time.Minute)},
+		{ID: 5, Username: "e2e_someone_real", CreatedAt: old},
+		{ID: 6, Username: fixtures.MarkUsername("run1", "seeded"), Bio: fixtures.MarkKept(fixtures.MarkBio("run1", "bio")), CreatedAt: old},
+		{ID: 7, Username: fixtures.MarkUsername("run1", "ageless")},
+	}
+}
+
+func TestSweepDryRun(t *testing.T) {
+	gw := &fakeGateway{users: testUsers()}
+	s := newSweeper(t, gw)
+
+	report, err := s.Sweep(context.Background(), Options{DryRun: true})
+	require.NoError(t, err)
+
+	var ids []int64
+	for _, l := range report.Leftovers {
+		ids = append(ids, l.User.ID)
+		assert.Equal(t, []int64{100}, l.Posts)
+		assert.Equal(t, []int64{200}, l.Followees)
+		assert.Equal(t, []int64{300}, l.Notifications)
+	}
+	assert.Equal(t, []int64{1, 2, 3}, ids, "recent, unmarked, seeded and ageless users are left alone")
+	assert.Equal(t, []string{"DELETE /v1/users/99 as 99"}, gw.calls,
+		"dry run must delete nothing but its own search user")
+}
+
+func TestSweepDeletesOneRun(t *testing.T) {
+	gw := &fakeGateway{users: testUsers()}
+	s := newSweeper(t, gw)
+
+	report, err := s.Sweep(context.Background(), Options{RunID: "run1"})
+	require.NoError(t, err)
+	assert.Len(t, report.Leftovers, 2)
+	assert.Equal(t, 2, report.Users)
+	assert.Equal(t, 2, report.Posts)
+	assert.Equal(t, 2, report.Follows)
+	assert.Equal(t, 2, report.Notifications)
+
+	byUser := map[string][]string{}
+	for _, c := range gw.calls {
+		i := strings.LastIndex(c, " as ")
+		byUser[c[i+4:]] = append(byUser[c[i+4:]], c[:i])
+	}
+	assert.Equal(t, []string{
+		"DELETE /v1/notification/300",
+		"POST /v1/relation/unfollow",
+		"DELETE /v1/posts/100",
+		"DELETE /v1/users/1",
+	}, byUser["1"])
+	assert.Len(t, byUser["2"], 4, "renamed users are logged in as by email")
+	assert.NotContains(t, byUser, "3")
+	assert.Equal(t, []string{"DELETE /v1/users/99"}, byUser["99"])
+}
+
+func TestSweepReportsUsersItCannotLogInAs(t *testing.T) {
+	gw := &fakeGateway{users: testUsers(), changed: map[int64]bool{1: true}}
+	s := newSweeper(t, gw)
+
+	report, err := s.Sweep(context.Background(), Options{RunID: "run1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "inspect user 1")
+	assert.Contains(t, err.Error(), "log in")
+	assert.Len(t, report.Leftovers, 1, "the other users are still swept")
+	assert.Equal(t, 1, report.Users)
+}
@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sync"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// fileClient is an otlptrace client that appends every batch to a file as one
+// line of OTLP/JSON, an ExportTraceServiceRequest. That is the format the
+// collector's file exporter writes and its otlpjsonfile receiver reads, so a
+// run's traces can be replayed into Jaeger or Tempo.
+type fileClient struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+func (c *fileClient) Start(context.Context) error { return nil }
+
+func (c *fileClient) Stop(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.f.Close()
+}
+
+func (c *fileClient) UploadTraces(_ context.Context, spans []*tracepb.ResourceSpans) error {
+	data, err := otlpJSON(&coltracepb.ExportTraceServiceRequest{ResourceSpans: spans})
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err = c.f.Write(append(data, '\n'))
+	return err
+}
+
+// otlpJSON marshals req as OTLP/JSON, which differs from plain protojson in
+// encoding enums as numbers and trace and span IDs as hex instead of base64.
+func otlpJSON(req *coltracepb.ExportTraceServiceRequest) ([]byte, error) {
+	data, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	hexIDs(doc)
+	return json.Marshal(doc)
+}
+
+func hexIDs(v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, nested := range val {
+			switch k {
+			case "traceId", "spanId", "parentSpanId":
+				if s, ok := nested.(string); ok {
+					if id, err := base64.StdEncoding.DecodeString(s); err == nil {
+						val[k] = hex.EncodeToString(id)
+					}
+				}
+			default:
+				hexIDs(nested)
+			}
+		}
+	case []interface{}:
+		for _, nested := range val {
+			hexIDs(nested)
+		}
+	}
+}
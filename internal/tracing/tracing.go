@@ -0,0 +1,118 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+)
+
+// InstrumentationName is the tracer name used for test spans.
+const InstrumentationName = "github.com/Soloda1/pinstack-system-tests"
+
+const AttrTestName = "test.name"
+
+// Setup installs a global tracer provider for the configured exporter and
+// returns a shutdown function that flushes pending spans. With exporter
+// "none" it is a no-op and spans are not recorded.
+func Setup(ctx context.Context, cfg config.Tracing) (func(context.Context) error, error) {
+	var exporter sdktrace.SpanExporter
+
+	switch cfg.Exporter {
+	case config.TracingExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case config.TracingExporterFile:
+		// Test packages run as parallel processes sharing one file, so
+		// it is appended to rather than truncated.
+		f, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("create trace file: %w", err)
+		}
+		exp, err := otlptrace.New(ctx, &fileClient{f: f})
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("create file exporter: %w", err)
+		}
+		exporter = exp
+	case config.TracingExporterOTLP:
+		exp, err := otlptracehttp.New(ctx,
+			otlptracehttp.WithEndpoint(cfg.OTLPEndpoint),
+			otlptracehttp.WithInsecure(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("create otlp exporter: %w", err)
+		}
+		exporter = exp
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+
+	tp := NewProvider(exporter, cfg.ServiceName)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
+
+// NewProvider builds a tracer provider exporting to exporter. It is exposed
+// so tests can plug in an in-memory exporter.
+func NewProvider(exporter sdktrace.SpanExporter, serviceName string) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	)
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// testContexts maps running test names to their span context so subtests,
+// which are the scenario steps of a journey, nest under their parent.
+var testContexts sync.Map
+
+// StartTest starts a span for t that ends when the test finishes and is
+// marked as an error if the test failed. Calling it again for the same test
+// returns the existing context. The returned context should be
+// handed to the client with Client.SetContext so HTTP spans nest under it.
+func StartTest(t testing.TB) context.Context {
+	t.Helper()
+
+	if ctx, ok := testContexts.Load(t.Name()); ok {
+		return ctx.(context.Context)
+	}
+
+	parent := context.Background()
+	if i := strings.LastIndex(t.Name(), "/"); i > 0 {
+		if ctx, ok := testContexts.Load(t.Name()[:i]); ok {
+			parent = ctx.(context.Context)
+		}
+	}
+
+	ctx, span := tracer().Start(parent, t.Name(), trace.WithAttributes(attribute.String(AttrTestName, t.Name())))
+	testContexts.Store(t.Name(), ctx)
+
+	t.Cleanup(func() {
+		testContexts.Delete(t.Name())
+		if t.Failed() {
+			span.SetStatus(codes.Error, "test failed")
+		} else if t.Skipped() {
+			span.SetAttributes(attribute.Bool("test.skipped", true))
+		}
+		span.End()
+	})
+
+	return ctx
+}
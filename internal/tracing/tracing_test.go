@@ -0,0 +1,126 @@
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+
+	"github.com/Soloda1/pinstack-system-tests/config"
+	"github.com/Soloda1/pinstack-system-tests/internal/client"
+	"github.com/Soloda1/pinstack-system-tests/internal/logger"
+)
+
+// exportedSpan is the part of an OTLP/JSON span the tests look at.
+type exportedSpan struct {
+	Name         string `json:"name"`
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Status       struct {
+		Code int `json:"code"`
+	} `json:"status"`
+	Attributes []struct {
+		Key   string                 `json:"key"`
+		Value map[string]interface{} `json:"value"`
+	} `json:"attributes"`
+}
+
+// attr returns the value of an attribute as OTLP/JSON writes it: strings as
+// is and integers as decimal strings.
+func (s exportedSpan) attr(key string) interface{} {
+	for _, a := range s.Attributes {
+		if a.Key == key {
+			for _, v := range a.Value {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+// readSpans reads a trace file, one ExportTraceServiceRequest per line.
+func readSpans(t *testing.T, path string) map[string]exportedSpan {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	spans := map[string]exportedSpan{}
+	dec := json.NewDecoder(bufio.NewReader(f))
+	for dec.More() {
+		var req struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []exportedSpan `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		require.NoError(t, dec.Decode(&req))
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, s := range ss.Spans {
+					spans[s.Name] = s
+				}
+			}
+		}
+	}
+	return spans
+}
+
+func TestFileExporterRecordsTestStepAndHTTPSpans(t *testing.T) {
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	shutdown, err := Setup(context.Background(), config.Tracing{Exporter: config.TracingExporterFile, FilePath: path, ServiceName: "test"})
+	require.NoError(t, err)
+
+	var gotTraceParent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTraceParent = r.Header.Get(client.HeaderTraceParent)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"status":404,"message":"not found"}`))
+	}))
+	defer srv.Close()
+
+	t.Run("journey", func(t *testing.T) {
+		c := client.NewClient(&config.Config{API: config.API{BaseURL: srv.URL, Timeout: time.Second}}, logger.ForTest(t))
+		StartTest(t)
+
+		// Subtests are the steps of a journey.
+		t.Run("lookup user", func(t *testing.T) {
+			c.SetContext(StartTest(t))
+			err := c.Get("/v1/users/1", nil, nil)
+			assert.ErrorAs(t, err, new(*client.APIError))
+		})
+	})
+
+	require.NoError(t, shutdown(context.Background()))
+	spans := readSpans(t, path)
+
+	testSpan, ok := spans[t.Name()+"/journey"]
+	require.True(t, ok, "test span exported")
+	stepSpan, ok := spans[t.Name()+"/journey/lookup_user"]
+	require.True(t, ok, "step span exported")
+	httpSpan, ok := spans["GET /v1/users/{id}"]
+	require.True(t, ok, "http span exported")
+
+	assert.Len(t, httpSpan.TraceID, 32, "trace IDs are hex")
+	assert.Equal(t, testSpan.SpanID, stepSpan.ParentSpanID)
+	assert.Equal(t, stepSpan.SpanID, httpSpan.ParentSpanID)
+	assert.Equal(t, 2, httpSpan.Status.Code, "STATUS_CODE_ERROR")
+	assert.Equal(t, "GET /v1/users/{id}", httpSpan.attr(client.AttrEndpoint), "spans group by route, not by ID")
+	assert.Equal(t, strconv.Itoa(http.StatusNotFound), httpSpan.attr(client.AttrStatusCode))
+	assert.Equal(t, "00-"+httpSpan.TraceID+"-"+httpSpan.SpanID+"-01", gotTraceParent)
+}